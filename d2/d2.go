@@ -0,0 +1,296 @@
+package d2
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the graph of shapes and connectors of scenes as D2
+// diagrams.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{graph: graph.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	graph  *graph.Graph
+	opts   *Options
+	b      strings.Builder
+	indent int
+}
+
+var nodeShapes = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:             "rectangle",
+	fig.ShapeWithTextTypeRoundedRectangle:   "rectangle",
+	fig.ShapeWithTextTypeEllipse:            "oval",
+	fig.ShapeWithTextTypeDiamond:            "diamond",
+	fig.ShapeWithTextTypeParallelogramRight: "parallelogram",
+	fig.ShapeWithTextTypeParallelogramLeft:  "parallelogram",
+	fig.ShapeWithTextTypeEngDatabase:        "cylinder",
+	fig.ShapeWithTextTypeEngQueue:           "queue",
+	fig.ShapeWithTextTypeEngFile:            "page",
+	fig.ShapeWithTextTypeEngFolder:          "package",
+	fig.ShapeWithTextTypePredefinedProcess:  "rectangle",
+	fig.ShapeWithTextTypeDocumentSingle:     "document",
+	fig.ShapeWithTextTypeDocumentMultiple:   "document",
+	fig.ShapeWithTextTypeHexagon:            "hexagon",
+	fig.ShapeWithTextTypeChevron:            "step",
+	fig.ShapeWithTextTypeSummingJunction:    "circle",
+	fig.ShapeWithTextTypeOr:                 "circle",
+	fig.ShapeWithTextTypeSpeechBubble:       "callout",
+	fig.ShapeWithTextTypeInternalStorage:    "stored_data",
+}
+
+var kindShapes = map[scene.Kind]string{
+	scene.KindSticky:      "rectangle",
+	scene.KindText:        "text",
+	scene.KindTable:       "rectangle",
+	scene.KindLinkPreview: "rectangle",
+	scene.KindRectangle:   "rectangle",
+	scene.KindEllipse:     "oval",
+}
+
+var arrowheads = map[fig.StrokeCap]string{
+	fig.StrokeCapArrowLines:       "arrow",
+	fig.StrokeCapArrowEquilateral: "triangle",
+	fig.StrokeCapTriangleFilled:   "triangle",
+	fig.StrokeCapDiamondFilled:    "diamond",
+	fig.StrokeCapCircleFilled:     "circle",
+}
+
+// Quote returns s as a double-quoted D2 string.
+func Quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// blockDelimiter returns the pipes enclosing s as a block string: at least
+// three, and more than any run of pipes within s, which would otherwise end
+// the block early.
+func blockDelimiter(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '|' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("|", max(3, longest+1))
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+func (c *Compiler) warn(format string, args ...any) {
+	c.line("# %s", graph.Warning(format, args...))
+}
+
+var directions = map[scene.Side]string{
+	scene.SideRight:  "right",
+	scene.SideLeft:   "left",
+	scene.SideBottom: "down",
+	scene.SideTop:    "up",
+}
+
+// Compile returns the D2 diagram for the scene.
+func (c *Compiler) Compile() string {
+	s := c.graph.Scene
+	c.line("# This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.line("# Input file: %s", c.opts.FilePath)
+	if root := s.Root; root != s.Page {
+		c.line("# Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+	}
+	c.line("direction: %s", directions[c.graph.Flow()])
+	for _, v := range c.graph.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range c.graph.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range c.graph.Edges {
+		c.writeEdge(e)
+	}
+	return c.b.String()
+}
+
+// path returns the key of an element placed within cluster, which is
+// qualified by the keys of its containers.
+func path(id string, cluster *graph.Cluster) string {
+	keys := []string{graph.SafeID(id)}
+	for v := cluster; v != nil; v = v.Parent {
+		keys = append([]string{graph.SafeID(v.ID)}, keys...)
+	}
+	return strings.Join(keys, ".")
+}
+
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	c.line("%s: %s {", graph.SafeID(v.ID), Quote(v.Label))
+	c.indent++
+	if v.Fill != nil {
+		c.line("style.fill: %s", Quote(v.Fill.Hex()))
+	}
+	if v.Kind == scene.KindGroup {
+		c.line("style.stroke-dash: 3")
+	}
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+	c.indent--
+	c.line("}")
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	id := graph.SafeID(n.ID)
+	shape, ok := kindShapes[n.Kind]
+	if n.Kind == scene.KindShape {
+		if shape, ok = nodeShapes[n.Shape]; !ok {
+			c.warn("%s has a shape D2 cannot draw, and is drawn as a rectangle", id)
+		}
+	}
+	if !ok {
+		shape = "rectangle"
+	}
+
+	if n.Kind == scene.KindCodeBlock {
+		language, ok := scene.CodeLanguage(n.Element.Node.CodeBlockLanguage)
+		if !ok {
+			language = "text"
+		}
+		delimiter := blockDelimiter(n.Label)
+		c.line("%s: %s%s", id, delimiter, language)
+		for _, v := range strings.Split(n.Label, "\n") {
+			c.line("%s", v)
+		}
+		c.line("%s", delimiter)
+		return
+	}
+
+	c.line("%s: %s {", id, Quote(n.Label))
+	c.indent++
+	c.line("shape: %s", shape)
+	switch n.Kind {
+	case scene.KindLine, scene.KindVector:
+		c.warn("%s is a %s, drawn as a box", id, n.Kind)
+	case scene.KindOther:
+		c.warn("%s holds an image, which is not exported", id)
+	}
+	switch n.Shape {
+	case fig.ShapeWithTextTypeRoundedRectangle:
+		if n.Kind == scene.KindShape {
+			c.line("style.border-radius: 8")
+		}
+	case fig.ShapeWithTextTypeDocumentMultiple:
+		c.line("style.multiple: true")
+	case fig.ShapeWithTextTypePredefinedProcess:
+		c.line("style.double-border: true")
+	}
+	switch {
+	case n.Kind == scene.KindText:
+		if n.Fill != nil {
+			c.line("style.font-color: %s", Quote(n.Fill.Hex()))
+		}
+	case n.Fill != nil:
+		c.line("style.fill: %s", Quote(n.Fill.Hex()))
+		if n.Fill.IsDark() {
+			c.line("style.font-color: %s", Quote("#ffffff"))
+		}
+	}
+	if n.Stroke != nil && n.Kind != scene.KindText {
+		c.line("style.stroke: %s", Quote(n.Stroke.Hex()))
+	}
+	c.indent--
+	c.line("}")
+}
+
+// endpoint returns the key of the node or container an edge end is
+// attached to, or an empty string when it is not part of the diagram.
+func endpoint(end graph.Endpoint) string {
+	switch {
+	case end.Node != nil:
+		return path(end.Node.ID, end.Node.Cluster)
+	case end.Cluster != nil:
+		return path(end.Cluster.ID, end.Cluster.Parent)
+	}
+	return ""
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	source, target := endpoint(e.Source), endpoint(e.Target)
+	if source == "" || target == "" {
+		c.warn("connector %s is not attached to both ends, and is omitted", e.ID)
+		return
+	}
+
+	tail, hasTail := arrowheads[e.StartCap]
+	head, hasHead := arrowheads[e.EndCap]
+	op := "--"
+	switch {
+	case hasTail && hasHead:
+		op = "<->"
+	case hasTail:
+		op = "<-"
+	case hasHead:
+		op = "->"
+	}
+
+	var attrs []string
+	if hasTail && tail != "triangle" {
+		attrs = append(attrs, "source-arrowhead.shape: "+tail)
+	}
+	if e.StartCap == fig.StrokeCapDiamondFilled || e.StartCap == fig.StrokeCapCircleFilled {
+		attrs = append(attrs, "source-arrowhead.style.filled: true")
+	}
+	if hasHead && head != "triangle" {
+		attrs = append(attrs, "target-arrowhead.shape: "+head)
+	}
+	if e.EndCap == fig.StrokeCapDiamondFilled || e.EndCap == fig.StrokeCapCircleFilled {
+		attrs = append(attrs, "target-arrowhead.style.filled: true")
+	}
+	if e.Dashed {
+		attrs = append(attrs, "style.stroke-dash: 3")
+	}
+	if e.Color != nil {
+		attrs = append(attrs, "style.stroke: "+Quote(e.Color.Hex()))
+	}
+
+	edge := source + " " + op + " " + target
+	if e.Label != "" {
+		edge += ": " + Quote(e.Label)
+	}
+	if len(attrs) == 0 {
+		c.line("%s", edge)
+		return
+	}
+	c.line("%s {", edge)
+	c.indent++
+	for _, v := range attrs {
+		c.line("%s", v)
+	}
+	c.indent--
+	c.line("}")
+}
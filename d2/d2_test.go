@@ -0,0 +1,69 @@
+package d2
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, s *scene.Scene) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: &Options{FilePath: "board.jam"}}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, scenetest.Scene(t))
+	scenetest.Golden(t, "board.d2", []byte(out))
+
+	for _, want := range []string{
+		// The only connector runs from right to left.
+		"direction: left",
+		`n1_10: "Ideas" {`,
+		`n1_12: "Write \"tests\" & docs" {`,
+		"n1_50: |||go\nfmt.Println(\"hi\")\n|||\n",
+		// Nodes within containers are referred to by their full path.
+		`n1_20 -> n1_10.n1_11: "yes" {`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestBlockDelimiter(t *testing.T) {
+	for s, want := range map[string]string{
+		"fmt.Println()":     "|||",
+		"a || b":            "|||",
+		"x ||| y":           "||||",
+		"a |||||| b ||| c":  "|||||||",
+		"echo a | grep |||": "||||",
+	} {
+		if got := blockDelimiter(s); got != want {
+			t.Errorf("blockDelimiter(%q): expected %s, got %s", s, want, got)
+		}
+	}
+}
+
+func TestCodeBlockWithPipes(t *testing.T) {
+	page := &fig.NodeChange{Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{{
+		Guid: &fig.GUID{SessionId: 1, LocalId: 1}, Type: fig.NodeTypeCodeBlock, Visible: true, Opacity: 1,
+		Transform: &fig.Matrix{M00: 1, M11: 1}, Size: &fig.Vector{X: 100, Y: 40},
+		CodeBlockLanguage: fig.CodeBlockLanguageBash,
+		TextData:          &fig.TextData{Characters: "echo '|||' | cat"},
+	}}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := render(t, s)
+	if !strings.Contains(out, "n1_1: ||||sh\necho '|||' | cat\n||||\n") {
+		t.Errorf("expected the block to be delimited by four pipes, got:\n%s", out)
+	}
+}
@@ -10,13 +10,44 @@ import (
 	"github.com/heyvito/gokiwi"
 	"io"
 	"os"
+	"path"
 	"slices"
+	"strings"
+)
+
+// Container is the format of a file.
+type Container string
+
+const (
+	// ContainerZip is a ZIP archive holding the canvas and its images.
+	ContainerZip Container = "zip"
+	// ContainerRaw is a bare fig-jam canvas.
+	ContainerRaw Container = "raw"
 )
 
 type Document struct {
-	Version uint32
-	Root    *fig.NodeChange
-	Blobs   []*fig.Blob
+	Version   uint32
+	Container Container
+	Root      *fig.NodeChange
+	Blobs     []*fig.Blob
+
+	// Images contains the contents of the archive's images directory, keyed
+	// by their hex-encoded hash. It is empty for raw fig-jam files.
+	Images map[string][]byte
+
+	// Viewports contains the viewports saved along with the file.
+	Viewports []*fig.Viewport
+
+	// Message is the decoded message, holding every node of the file, and
+	// Schema the decompressed kiwi schema it was encoded with. Nodes keep
+	// their ParentIndex, placing them within their parent.
+	Message *fig.Message
+	Schema  []byte
+
+	// Compression is the method the canvas's chunks are compressed with,
+	// and ArchiveCompression the one of the canvas within ZIP containers.
+	Compression        string
+	ArchiveCompression string
 }
 
 func Decode(path string) (*Document, error) {
@@ -66,9 +97,11 @@ func decodeJamFromZip(file *os.File, size int64) (*Document, error) {
 	}
 	ok := false
 	uncompressedSize := uint64(0)
+	var method uint16
 	for _, v := range r.File {
 		if v.Name == "canvas.fig" {
 			uncompressedSize = v.UncompressedSize64
+			method = v.Method
 			ok = true
 			break
 		}
@@ -90,7 +123,37 @@ func decodeJamFromZip(file *os.File, size int64) (*Document, error) {
 		return nil, fmt.Errorf("divergent read and uncompressed size: expected %d, found %d", uncompressedSize, readSize)
 	}
 
-	return decodeFigJam(data)
+	doc, err := decodeFigJam(data)
+	if err != nil {
+		return nil, err
+	}
+	doc.Container = ContainerZip
+	switch method {
+	case zip.Store:
+		doc.ArchiveCompression = "store"
+	case zip.Deflate:
+		doc.ArchiveCompression = "deflate"
+	default:
+		doc.ArchiveCompression = fmt.Sprintf("method %d", method)
+	}
+
+	for _, v := range r.File {
+		if !strings.HasPrefix(v.Name, "images/") || v.FileInfo().IsDir() {
+			continue
+		}
+		img, err := r.Open(v.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open image %s from jam file: %w", v.Name, err)
+		}
+		data, err := io.ReadAll(img)
+		_ = img.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read image %s from jam file: %w", v.Name, err)
+		}
+		doc.Images[path.Base(v.Name)] = data
+	}
+
+	return doc, nil
 }
 
 func decodeFigJam(data []byte) (*Document, error) {
@@ -115,6 +178,14 @@ func decodeFigJam(data []byte) (*Document, error) {
 		return nil, fmt.Errorf("invalid chunk size; expected at least 2, got %d", len(chunks))
 	}
 
+	// The schema is only kept for reference, as messages are decoded with
+	// the one fig was generated from. It is kept as found when it cannot be
+	// decompressed.
+	schema, err := io.ReadAll(flate.NewReader(bytes.NewReader(chunks[0])))
+	if err != nil {
+		schema = chunks[0]
+	}
+
 	zr := flate.NewReader(bytes.NewReader(chunks[1]))
 	encodedData, err := io.ReadAll(zr)
 	if err != nil {
@@ -151,13 +222,22 @@ func decodeFigJam(data []byte) (*Document, error) {
 		}
 	}
 
-	for _, node := range nodes {
-		node.ParentIndex = nil
+	var viewports []*fig.Viewport
+	for _, change := range struc.UserChanges {
+		if change.Viewport != nil && change.Viewport.CanvasSpaceBounds != nil {
+			viewports = append(viewports, change.Viewport)
+		}
 	}
 
 	return &Document{
-		Version: version,
-		Root:    nodes["0:0"],
-		Blobs:   blobs,
+		Version:     version,
+		Container:   ContainerRaw,
+		Root:        nodes["0:0"],
+		Blobs:       blobs,
+		Images:      map[string][]byte{},
+		Viewports:   viewports,
+		Message:     struc,
+		Schema:      schema,
+		Compression: "deflate",
 	}, nil
 }
@@ -0,0 +1,316 @@
+package dot
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// precision is the amount of decimal places numbers are printed with.
+const precision = 2
+
+// pointsPerUnit converts canvas units, which are CSS pixels, into points.
+const pointsPerUnit = 0.75
+
+// unitsPerInch converts canvas units into inches, which node sizes are
+// given in.
+const unitsPerInch = 96
+
+type Options struct {
+	FilePath string
+
+	// Positions keeps the original position and size of nodes, for
+	// rendering with neato -n.
+	Positions bool
+}
+
+// Renderer renders the graph of shapes and connectors of scenes in the
+// Graphviz DOT language.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{graph: graph.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	graph  *graph.Graph
+	opts   *Options
+	b      strings.Builder
+	indent int
+}
+
+var nodeShapes = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:             "box",
+	fig.ShapeWithTextTypeRoundedRectangle:   "box",
+	fig.ShapeWithTextTypeEllipse:            "ellipse",
+	fig.ShapeWithTextTypeDiamond:            "diamond",
+	fig.ShapeWithTextTypeTriangleUp:         "triangle",
+	fig.ShapeWithTextTypeTriangleDown:       "invtriangle",
+	fig.ShapeWithTextTypeParallelogramRight: "parallelogram",
+	fig.ShapeWithTextTypeParallelogramLeft:  "parallelogram",
+	fig.ShapeWithTextTypeEngDatabase:        "cylinder",
+	fig.ShapeWithTextTypeEngQueue:           "cylinder",
+	fig.ShapeWithTextTypeEngFile:            "note",
+	fig.ShapeWithTextTypeEngFolder:          "folder",
+	fig.ShapeWithTextTypeTrapezoid:          "trapezium",
+	fig.ShapeWithTextTypePredefinedProcess:  "box",
+	fig.ShapeWithTextTypeShield:             "invhouse",
+	fig.ShapeWithTextTypeDocumentSingle:     "note",
+	fig.ShapeWithTextTypeDocumentMultiple:   "note",
+	fig.ShapeWithTextTypeManualInput:        "box",
+	fig.ShapeWithTextTypeHexagon:            "hexagon",
+	fig.ShapeWithTextTypeChevron:            "cds",
+	fig.ShapeWithTextTypePentagon:           "pentagon",
+	fig.ShapeWithTextTypeOctagon:            "octagon",
+	fig.ShapeWithTextTypeStar:               "star",
+	fig.ShapeWithTextTypePlus:               "box",
+	fig.ShapeWithTextTypeArrowLeft:          "larrow",
+	fig.ShapeWithTextTypeArrowRight:         "rarrow",
+	fig.ShapeWithTextTypeSummingJunction:    "Mcircle",
+	fig.ShapeWithTextTypeOr:                 "circle",
+	fig.ShapeWithTextTypeSpeechBubble:       "box",
+	fig.ShapeWithTextTypeInternalStorage:    "box",
+}
+
+var kindShapes = map[scene.Kind]string{
+	scene.KindSticky:      "box",
+	scene.KindText:        "plaintext",
+	scene.KindTable:       "box",
+	scene.KindCodeBlock:   "box",
+	scene.KindLinkPreview: "box",
+	scene.KindRectangle:   "box",
+	scene.KindEllipse:     "ellipse",
+	scene.KindLine:        "underline",
+	scene.KindStar:        "star",
+	scene.KindPolygon:     "polygon",
+	scene.KindVector:      "box",
+	scene.KindOther:       "box",
+}
+
+var arrowTypes = map[fig.StrokeCap]string{
+	fig.StrokeCapArrowLines:       "vee",
+	fig.StrokeCapArrowEquilateral: "normal",
+	fig.StrokeCapTriangleFilled:   "normal",
+	fig.StrokeCapDiamondFilled:    "diamond",
+	fig.StrokeCapCircleFilled:     "dot",
+}
+
+// Quote returns s as a double-quoted DOT string.
+func Quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// attributes formats a DOT attribute list, skipping empty values.
+func attributes(pairs ...string) string {
+	var attrs []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			attrs = append(attrs, pairs[i]+"="+pairs[i+1])
+		}
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+// Compile returns the DOT graph for the scene.
+func (c *Compiler) Compile() string {
+	s := c.graph.Scene
+	c.line("// This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.line("// Input file: %s", c.opts.FilePath)
+	name := s.Page.Name
+	if root := s.Root; root != s.Page {
+		c.line("// Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+		name = root.Name
+	}
+	c.line("digraph %s {", Quote(name))
+	c.indent++
+	c.line("compound=true;")
+	c.line("node [fontname=\"Helvetica\"];")
+	c.line("edge [fontname=\"Helvetica\"];")
+	for _, v := range c.graph.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range c.graph.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range c.graph.Edges {
+		c.writeEdge(e)
+	}
+	c.indent--
+	c.line("}")
+	return c.b.String()
+}
+
+// writeCluster writes sections and frames as clusters. Groups do not
+// outline their content, so their nodes are written in place.
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	group := v.Kind == scene.KindGroup
+	if !group {
+		c.line("subgraph %s {", Quote("cluster_"+strings.ReplaceAll(v.ID, ":", "_")))
+		c.indent++
+		c.line("label=%s;", Quote(v.Label))
+		if v.Fill != nil {
+			c.line("style=filled;")
+			c.line("fillcolor=%s;", Quote(v.Fill.Hex()))
+		}
+	}
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+	if !group {
+		c.indent--
+		c.line("}")
+	}
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	shape, ok := kindShapes[n.Kind]
+	if n.Kind == scene.KindShape {
+		shape, ok = nodeShapes[n.Shape]
+	}
+	if !ok {
+		shape = "box"
+	}
+
+	var style []string
+	if n.Kind == scene.KindShape && n.Shape == fig.ShapeWithTextTypeRoundedRectangle {
+		style = append(style, "rounded")
+	}
+	var fill, color, fontColor, fontName, sides string
+	if n.Fill != nil && n.Kind != scene.KindText {
+		style = append(style, "filled")
+		fill = Quote(n.Fill.Hex())
+		if n.Fill.IsDark() {
+			fontColor = Quote("white")
+		}
+	}
+	if n.Kind == scene.KindText && n.Fill != nil {
+		fontColor = Quote(n.Fill.Hex())
+	}
+	if n.Stroke != nil {
+		color = Quote(n.Stroke.Hex())
+	}
+	if n.Kind == scene.KindCodeBlock {
+		fontName = Quote("Courier")
+	}
+	if n.Kind == scene.KindPolygon {
+		sides = strconv.Itoa(max(int(n.Element.Node.Count), 3))
+	}
+
+	var pos, width, height, fixed string
+	if c.opts.Positions {
+		center := n.Bounds.Center()
+		size := n.Bounds.Size()
+		pos = Quote(fmt.Sprintf("%s,%s!", scene.FormatNumber(center.X*pointsPerUnit, precision), scene.FormatNumber(-center.Y*pointsPerUnit, precision)))
+		width = scene.FormatNumber(size.X/unitsPerInch, precision)
+		height = scene.FormatNumber(size.Y/unitsPerInch, precision)
+		fixed = "true"
+	}
+
+	c.line("%s%s;", Quote(n.ID), attributes(
+		"label", Quote(n.Label),
+		"shape", shape,
+		"sides", sides,
+		"style", quoteList(style),
+		"fillcolor", fill,
+		"color", color,
+		"fontcolor", fontColor,
+		"fontname", fontName,
+		"pos", pos,
+		"width", width,
+		"height", height,
+		"fixedsize", fixed,
+	))
+}
+
+func quoteList(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return Quote(strings.Join(values, ","))
+}
+
+// endpoint returns the node an edge is drawn from or to, and the cluster
+// it is clipped to when attached to a container. Ends outside of the graph
+// are drawn as points.
+func (c *Compiler) endpoint(e *graph.Edge, end graph.Endpoint, suffix string) (node, cluster string) {
+	switch {
+	case end.Node != nil:
+		return Quote(end.Node.ID), ""
+	case end.Cluster != nil && end.Cluster.FirstNode() != nil:
+		if end.Cluster.Kind != scene.KindGroup {
+			cluster = Quote("cluster_" + strings.ReplaceAll(end.Cluster.ID, ":", "_"))
+		}
+		return Quote(end.Cluster.FirstNode().ID), cluster
+	}
+	node = Quote(e.ID + ":" + suffix)
+	var pos string
+	if c.opts.Positions {
+		pos = Quote(fmt.Sprintf("%s,%s!", scene.FormatNumber(end.Point.X*pointsPerUnit, precision), scene.FormatNumber(-end.Point.Y*pointsPerUnit, precision)))
+	}
+	c.line("%s%s;", node, attributes("shape", "point", "label", `""`, "width", "0.05", "pos", pos))
+	return node, ""
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	source, tail := c.endpoint(e, e.Source, "start")
+	target, head := c.endpoint(e, e.Target, "end")
+
+	arrowHead, ok := arrowTypes[e.EndCap]
+	if !ok {
+		arrowHead = "none"
+	}
+	var dir, arrowTail string
+	if t, ok := arrowTypes[e.StartCap]; ok {
+		dir, arrowTail = "both", t
+	}
+	if arrowHead == "normal" {
+		arrowHead = ""
+	}
+	var style, color string
+	if e.Dashed {
+		style = "dashed"
+	}
+	if e.Color != nil {
+		color = Quote(e.Color.Hex())
+	}
+	var label string
+	if e.Label != "" {
+		label = Quote(e.Label)
+	}
+
+	c.line("%s -> %s%s;", source, target, attributes(
+		"label", label,
+		"dir", dir,
+		"arrowhead", arrowHead,
+		"arrowtail", arrowTail,
+		"style", style,
+		"color", color,
+		"ltail", tail,
+		"lhead", head,
+	))
+}
@@ -0,0 +1,60 @@
+package dot
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, s *scene.Scene, opts *Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: opts}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, scenetest.Scene(t), &Options{FilePath: "board.jam"})
+	scenetest.Golden(t, "board.dot", []byte(out))
+
+	for _, want := range []string{
+		`subgraph "cluster_1_10" {`,
+		`"1:12" [label="Write \"tests\" & docs"`,
+		`"1:20" -> "1:11" [label="yes"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "pos=") {
+		t.Errorf("expected no positions unless requested, got:\n%s", out)
+	}
+
+	// Positions are given in points, with the y axis pointing up.
+	out = render(t, scenetest.Scene(t), &Options{Positions: true})
+	if !strings.Contains(out, `"1:20" [label="Decide", shape=diamond, style="filled", fillcolor="#cce6cc", pos="420,-75!", width=1.25, height=0.83, fixedsize=true];`) {
+		t.Errorf("expected the diamond to keep its position, got:\n%s", out)
+	}
+}
+
+func TestDanglingEndsBecomePoints(t *testing.T) {
+	page := &fig.NodeChange{Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{{
+		Guid: &fig.GUID{SessionId: 1, LocalId: 1}, Type: fig.NodeTypeConnector, Visible: true, Opacity: 1,
+		Transform:      &fig.Matrix{M00: 1, M11: 1},
+		ConnectorStart: &fig.ConnectorEndpoint{Position: &fig.Vector{}},
+		ConnectorEnd:   &fig.ConnectorEndpoint{Position: &fig.Vector{X: 100}},
+	}}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := render(t, s, nil)
+	if !strings.Contains(out, `"1:1:start" [shape=point`) || !strings.Contains(out, `"1:1:start" -> "1:1:end"`) {
+		t.Errorf("expected detached ends to be drawn as points, got:\n%s", out)
+	}
+}
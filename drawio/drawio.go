@@ -0,0 +1,358 @@
+package drawio
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strings"
+)
+
+// precision is the amount of decimal places numbers are printed with.
+const precision = 2
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders scenes as uncompressed draw.io (diagrams.net) files.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	return r.RenderPages([]*scene.Scene{s}, w)
+}
+
+// RenderPages writes each of the given scenes as a diagram of its own.
+func (r *Renderer) RenderPages(pages []*scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{opts: opts, ids: map[*scene.Element]string{}}
+	for _, s := range pages {
+		c.graphs = append(c.graphs, graph.Build(s))
+	}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	graphs []*graph.Graph
+	opts   *Options
+	b      strings.Builder
+	indent int
+
+	// ids holds the ids given to elements without a GUID, which every cell
+	// needs.
+	ids map[*scene.Element]string
+}
+
+var textEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	`"`, `&quot;`,
+	"\r", "",
+	"\n", "&#10;",
+	"\t", "&#9;",
+)
+
+var htmlEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	"\r", "",
+	"\n", "<br>",
+)
+
+// imageURI returns img as a data URI, or an empty string when its format is
+// not supported. Semicolons separate the entries of draw.io styles, so the
+// encoding is left out of the URI.
+func imageURI(img *scene.Image) string {
+	mime := img.MIMEType()
+	if mime == "" {
+		return ""
+	}
+	return "data:" + mime + "," + base64.StdEncoding.EncodeToString(img.Data)
+}
+
+// attribute is an XML attribute. Attributes with an empty value are
+// omitted.
+type attribute struct {
+	name, value string
+}
+
+func attr(name, value string) attribute {
+	return attribute{name, value}
+}
+
+func attrString(attrs []attribute) string {
+	var b strings.Builder
+	for _, a := range attrs {
+		if a.value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, ` %s="%s"`, a.name, textEscaper.Replace(a.value))
+	}
+	return b.String()
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+func (c *Compiler) open(name string, attrs ...attribute) {
+	c.line("<%s%s>", name, attrString(attrs))
+	c.indent++
+}
+
+func (c *Compiler) close(name string) {
+	c.indent--
+	c.line("</%s>", name)
+}
+
+func (c *Compiler) leaf(name string, attrs ...attribute) {
+	c.line("<%s%s/>", name, attrString(attrs))
+}
+
+func (c *Compiler) point(p scene.Point, as string) {
+	c.leaf("mxPoint", attr("x", scene.FormatNumber(p.X, precision)), attr("y", scene.FormatNumber(p.Y, precision)), attr("as", as))
+}
+
+func (c *Compiler) comment(format string, args ...any) {
+	c.line("<!-- %s -->", scene.EscapeComment(fmt.Sprintf(format, args...)))
+}
+
+// Compile returns the draw.io file holding a diagram for each scene.
+func (c *Compiler) Compile() string {
+	c.line(`<?xml version="1.0" encoding="UTF-8"?>`)
+	c.comment("This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.comment("Input file: %s", c.opts.FilePath)
+	c.open("mxfile", attr("host", "figz"))
+	for i, g := range c.graphs {
+		c.writeDiagram(g, i)
+	}
+	c.close("mxfile")
+	return c.b.String()
+}
+
+// writeDiagram writes the i-th page of the file.
+func (c *Compiler) writeDiagram(g *graph.Graph, i int) {
+	s := g.Scene
+	name := s.Page.Name
+	if root := s.Root; root != s.Page {
+		c.comment("Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+		name = root.Name
+	}
+	id := fmt.Sprintf("page-%d", i+1)
+	if s.Root.Guid != nil {
+		id = scene.GUIDKey(s.Root.Guid)
+	}
+	c.open("diagram", attr("id", id), attr("name", name))
+	c.open("mxGraphModel",
+		attr("grid", "1"), attr("gridSize", "10"), attr("guides", "1"), attr("tooltips", "1"),
+		attr("connect", "1"), attr("arrows", "1"), attr("fold", "1"), attr("page", "0"),
+		attr("pageScale", "1"), attr("math", "0"), attr("shadow", "0"))
+	c.open("root")
+	c.leaf("mxCell", attr("id", "0"))
+	c.leaf("mxCell", attr("id", "1"), attr("parent", "0"))
+	for _, v := range g.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range g.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range g.Edges {
+		c.writeEdge(e)
+	}
+	c.close("root")
+	c.close("mxGraphModel")
+	c.close("diagram")
+}
+
+// cellID returns the id of the cell drawing e. Elements without a GUID are
+// given one of their own, which cannot clash with GUIDs as it has no colon.
+func (c *Compiler) cellID(e *scene.Element) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	id, ok := c.ids[e]
+	if !ok {
+		id = fmt.Sprintf("figz-%d", len(c.ids)+1)
+		c.ids[e] = id
+	}
+	return id
+}
+
+// placement is the position of a cell as draw.io describes it: an
+// unrotated box, mirrored within itself, then rotated clockwise by Rotation
+// degrees around its center.
+type placement struct {
+	Box          scene.Rect
+	Rotation     float64
+	FlipH, FlipV bool
+}
+
+// geometry returns the placement of e in the scene's coordinates.
+func geometry(e *scene.Element) placement {
+	if e.Transform.IsTranslation() {
+		return placement{Box: e.Bounds}
+	}
+	center, half := e.Center(), e.Size.Mul(0.5)
+	p := placement{Box: scene.Rect{Min: center.Sub(half), Max: center.Add(half)}}
+	p.Rotation, p.FlipH, p.FlipV = e.Transform.Orientation()
+	return p
+}
+
+func flip(on bool) string {
+	if on {
+		return "1"
+	}
+	return "0"
+}
+
+// parentID returns the id of the cell holding the children of v. Elements
+// outside of containers are placed on the default layer.
+func (c *Compiler) parentID(v *graph.Cluster) string {
+	if v == nil {
+		return "1"
+	}
+	return c.cellID(v.Element)
+}
+
+// vertex writes the cell drawing e with its box placed relative to the
+// container holding it, as draw.io expects. draw.io offsets children by the
+// unrotated box of their parent and does not rotate them along with it, so
+// children of rotated containers keep the rotation they have in the scene.
+func (c *Compiler) vertex(e *scene.Element, label string, st style, parent *graph.Cluster) {
+	p := geometry(e)
+	if p.Rotation != 0 {
+		st.set("rotation", scene.FormatNumber(p.Rotation, precision))
+	}
+	// Later entries take precedence, and mirroring a shape already drawn
+	// flipped undoes its flip.
+	if p.FlipH {
+		st.set("flipH", flip(!st.has("flipH")))
+	}
+	if p.FlipV {
+		st.set("flipV", flip(!st.has("flipV")))
+	}
+	box := p.Box
+	if parent != nil {
+		origin := geometry(parent.Element).Box.Min
+		box = scene.Rect{Min: box.Min.Sub(origin), Max: box.Max.Sub(origin)}
+	}
+
+	id := c.cellID(e)
+	cell := []attribute{attr("style", st.String()), attr("vertex", "1"), attr("parent", c.parentID(parent))}
+	if e.Link != "" {
+		// Links are only kept by cells wrapped in user objects.
+		c.open("UserObject", attr("id", id), attr("label", label), attr("link", e.Link))
+		c.open("mxCell", cell...)
+	} else {
+		c.open("mxCell", append([]attribute{attr("id", id), attr("value", label)}, cell...)...)
+	}
+	size := box.Size()
+	c.leaf("mxGeometry",
+		attr("x", scene.FormatNumber(box.Min.X, precision)), attr("y", scene.FormatNumber(box.Min.Y, precision)),
+		attr("width", scene.FormatNumber(size.X, precision)), attr("height", scene.FormatNumber(size.Y, precision)),
+		attr("as", "geometry"))
+	c.close("mxCell")
+	if e.Link != "" {
+		c.close("UserObject")
+	}
+}
+
+// writeCluster writes sections and frames as containers, and groups as
+// draw.io groups.
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	label := v.Label
+	if v.Kind == scene.KindGroup {
+		label = ""
+	}
+	c.vertex(v.Element, label, clusterStyle(v.Element), v.Parent)
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	label := n.Label
+	if n.Kind != scene.KindCodeBlock {
+		// Labels are only wrapped as HTML.
+		label = htmlEscaper.Replace(label)
+	}
+	if n.Kind == scene.KindOther {
+		label = ""
+		if img := n.Element.Images[0]; img.Err != nil || imageURI(img.Image) == "" {
+			c.comment("Skipping image of %s: unsupported or missing image", n.Element.Node.Name)
+		}
+	}
+	c.vertex(n.Element, label, nodeStyle(n.Element), n.Cluster)
+}
+
+// endpointID returns the id of the cell an edge end is attached to, or an
+// empty string when it is not part of the diagram.
+func (c *Compiler) endpointID(end graph.Endpoint) string {
+	switch {
+	case end.Node != nil:
+		return c.cellID(end.Node.Element)
+	case end.Cluster != nil:
+		return c.cellID(end.Cluster.Element)
+	}
+	return ""
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	conn := e.Element.Connector
+	c.open("mxCell",
+		attr("id", c.cellID(e.Element)),
+		attr("value", e.Label),
+		attr("style", edgeStyle(e.Element).String()),
+		attr("edge", "1"),
+		attr("parent", "1"),
+		attr("source", c.endpointID(e.Source)),
+		attr("target", c.endpointID(e.Target)))
+
+	// Labels are placed along edges from -1, at their source, to 1.
+	var labelX string
+	if conn.Label != nil && conn.LabelPosition != 0.5 {
+		labelX = scene.FormatNumber(conn.LabelPosition*2-1, precision)
+	}
+	attrs := []attribute{attr("x", labelX), attr("relative", "1"), attr("as", "geometry")}
+	var waypoints []scene.Point
+	if conn.Route == scene.RoutePolyline && len(conn.Points) > 2 {
+		waypoints = conn.Points[1 : len(conn.Points)-1]
+	}
+	if !e.Source.IsDangling() && !e.Target.IsDangling() && len(waypoints) == 0 {
+		c.leaf("mxGeometry", attrs...)
+		c.close("mxCell")
+		return
+	}
+
+	// Ends not attached to a cell are placed at their original position.
+	c.open("mxGeometry", attrs...)
+	if e.Source.IsDangling() {
+		c.point(e.Source.Point, "sourcePoint")
+	}
+	if e.Target.IsDangling() {
+		c.point(e.Target.Point, "targetPoint")
+	}
+	if len(waypoints) > 0 {
+		c.open("Array", attr("as", "points"))
+		for _, p := range waypoints {
+			c.point(p, "")
+		}
+		c.close("Array")
+	}
+	c.close("mxGeometry")
+	c.close("mxCell")
+}
@@ -0,0 +1,106 @@
+package drawio
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, s *scene.Scene) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: &Options{FilePath: "board.jam"}}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func build(t *testing.T, children ...*fig.NodeChange) *scene.Scene {
+	t.Helper()
+	page := &fig.NodeChange{Type: fig.NodeTypeCanvas, Name: "Page", Visible: true, Children: children}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func shape(guid *fig.GUID, m *fig.Matrix, children ...*fig.NodeChange) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid, Type: fig.NodeTypeShapeWithText, Visible: true, Opacity: 1,
+		Transform: m, Size: &fig.Vector{X: 40, Y: 40},
+		ShapeWithTextType: fig.ShapeWithTextTypeSquare,
+		Children:          children,
+	}
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, scenetest.Scene(t))
+	scenetest.Golden(t, "board.drawio", []byte(out))
+
+	for _, want := range []string{
+		`<diagram id="0:1" name="Board">`,
+		`<mxCell id="1:11" value="Ship it"`,
+		`parent="1:10"`,
+		`value="Write &quot;tests&quot; &amp;amp; docs"`,
+		`source="1:20" target="1:11"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestFallbackIDs(t *testing.T) {
+	out := render(t, build(t, shape(nil, &fig.Matrix{M00: 1, M11: 1}), shape(nil, &fig.Matrix{M00: 1, M11: 1, M02: 100})))
+	for _, want := range []string{`<diagram id="page-1" name="Page">`, `<mxCell id="figz-1"`, `<mxCell id="figz-2"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFlips(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		m    *fig.Matrix
+		want string
+	}{
+		{"horizontal", &fig.Matrix{M00: -1, M11: 1, M02: 40}, "strokeColor=none;flipH=1;"},
+		{"vertical", &fig.Matrix{M00: 1, M11: -1, M12: 40}, "strokeColor=none;flipV=1;"},
+		{"rotated", &fig.Matrix{M01: 1, M10: 1}, "strokeColor=none;rotation=-90;flipH=1;"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out := render(t, build(t, shape(&fig.GUID{SessionId: 1, LocalId: 1}, tt.m)))
+			if !strings.Contains(out, tt.want+`" vertex="1"`) {
+				t.Errorf("expected style %q, got:\n%s", tt.want, out)
+			}
+			if !strings.Contains(out, `<mxGeometry x="0" y="0" width="40" height="40"`) {
+				t.Errorf("expected the box to be kept in place, got:\n%s", out)
+			}
+		})
+	}
+}
+
+// draw.io offsets children by the unrotated box of their parent, without
+// rotating them along with it.
+func TestChildOfRotatedParent(t *testing.T) {
+	section := &fig.NodeChange{
+		Guid: &fig.GUID{SessionId: 1, LocalId: 1}, Type: fig.NodeTypeSection, Name: "Turned", Visible: true, Opacity: 1,
+		Transform: &fig.Matrix{M01: -1, M10: 1, M02: 200}, Size: &fig.Vector{X: 200, Y: 100},
+		Children: []*fig.NodeChange{shape(&fig.GUID{SessionId: 1, LocalId: 2}, &fig.Matrix{M00: 1, M11: 1, M02: 20, M12: 20})},
+	}
+	out := render(t, build(t, section))
+	for _, want := range []string{
+		`<mxGeometry x="50" y="50" width="200" height="100" as="geometry"/>`,
+		`strokeColor=none;rotation=90;" vertex="1" parent="1:1">`,
+		`<mxGeometry x="90" y="-30" width="40" height="40" as="geometry"/>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
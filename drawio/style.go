@@ -0,0 +1,320 @@
+package drawio
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strconv"
+	"strings"
+)
+
+var shapeStyles = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:             "rounded=0",
+	fig.ShapeWithTextTypeRoundedRectangle:   "rounded=1",
+	fig.ShapeWithTextTypeEllipse:            "ellipse",
+	fig.ShapeWithTextTypeDiamond:            "rhombus",
+	fig.ShapeWithTextTypeTriangleUp:         "triangle;direction=north",
+	fig.ShapeWithTextTypeTriangleDown:       "triangle;direction=south",
+	fig.ShapeWithTextTypeParallelogramRight: "shape=parallelogram;perimeter=parallelogramPerimeter",
+	fig.ShapeWithTextTypeParallelogramLeft:  "shape=parallelogram;perimeter=parallelogramPerimeter;flipH=1",
+	fig.ShapeWithTextTypeEngDatabase:        "shape=cylinder3;boundedLbl=1;size=15",
+	fig.ShapeWithTextTypeEngQueue:           "shape=cylinder3;boundedLbl=1;size=15;direction=south",
+	fig.ShapeWithTextTypeEngFile:            "shape=note;size=15",
+	fig.ShapeWithTextTypeEngFolder:          "shape=folder;tabWidth=40;tabHeight=14;tabPosition=left",
+	fig.ShapeWithTextTypeTrapezoid:          "shape=trapezoid;perimeter=trapezoidPerimeter",
+	fig.ShapeWithTextTypePredefinedProcess:  "shape=process",
+	fig.ShapeWithTextTypeShield:             "shape=offPageConnector",
+	fig.ShapeWithTextTypeDocumentSingle:     "shape=document;boundedLbl=1",
+	fig.ShapeWithTextTypeDocumentMultiple:   "shape=mxgraph.flowchart.multi-document",
+	fig.ShapeWithTextTypeManualInput:        "shape=manualInput;size=15",
+	fig.ShapeWithTextTypeHexagon:            "shape=hexagon;perimeter=hexagonPerimeter2",
+	fig.ShapeWithTextTypeChevron:            "shape=step;perimeter=stepPerimeter",
+	fig.ShapeWithTextTypePentagon:           "shape=mxgraph.basic.pentagon",
+	fig.ShapeWithTextTypeOctagon:            "shape=mxgraph.basic.octagon2",
+	fig.ShapeWithTextTypeStar:               "shape=mxgraph.basic.star",
+	fig.ShapeWithTextTypePlus:               "shape=cross",
+	fig.ShapeWithTextTypeArrowLeft:          "shape=singleArrow;direction=west",
+	fig.ShapeWithTextTypeArrowRight:         "shape=singleArrow",
+	fig.ShapeWithTextTypeSummingJunction:    "shape=sumEllipse;perimeter=ellipsePerimeter",
+	fig.ShapeWithTextTypeOr:                 "shape=orEllipse;perimeter=ellipsePerimeter",
+	fig.ShapeWithTextTypeSpeechBubble:       "shape=callout",
+	fig.ShapeWithTextTypeInternalStorage:    "shape=internalStorage",
+}
+
+var kindStyles = map[scene.Kind]string{
+	scene.KindSticky:      "rounded=0;shadow=1;align=left;verticalAlign=top;spacing=12",
+	scene.KindText:        "text;verticalAlign=top",
+	scene.KindTable:       "rounded=0;align=left;verticalAlign=top;spacing=8",
+	scene.KindCodeBlock:   "rounded=1;align=left;verticalAlign=top;spacing=8;fontFamily=Courier New",
+	scene.KindLinkPreview: "rounded=1",
+	scene.KindRectangle:   "rounded=0",
+	scene.KindEllipse:     "ellipse",
+	scene.KindLine:        "line",
+	scene.KindStar:        "shape=mxgraph.basic.star",
+	scene.KindVector:      "rounded=0",
+	scene.KindOther:       "shape=image;imageAspect=0",
+}
+
+// polygonStyles holds the shapes regular polygons with the given number of
+// sides are drawn as. Other polygons are drawn as ellipses.
+var polygonStyles = map[uint]string{
+	3: "triangle;direction=north",
+	4: "rhombus",
+	5: "shape=mxgraph.basic.pentagon",
+	6: "shape=hexagon;perimeter=hexagonPerimeter2",
+	8: "shape=mxgraph.basic.octagon2",
+}
+
+var clusterStyles = map[scene.Kind]string{
+	scene.KindSection: "rounded=1;arcSize=2;container=1;collapsible=0;align=left;verticalAlign=top;spacingLeft=8",
+	scene.KindFrame:   "rounded=0;container=1;collapsible=0;align=left;verticalAlign=top;spacingLeft=8",
+	scene.KindGroup:   "group",
+}
+
+// arrows holds the markers drawn for stroke caps, and whether they are
+// filled.
+var arrows = map[fig.StrokeCap]struct {
+	name   string
+	filled bool
+}{
+	fig.StrokeCapArrowLines:       {"open", false},
+	fig.StrokeCapArrowEquilateral: {"block", true},
+	fig.StrokeCapTriangleFilled:   {"classic", true},
+	fig.StrokeCapDiamondFilled:    {"diamond", true},
+	fig.StrokeCapCircleFilled:     {"oval", true},
+}
+
+var edgeStyles = map[fig.ConnectorLineStyle]string{
+	fig.ConnectorLineStyleElbowed:  "edgeStyle=orthogonalEdgeStyle;rounded=1",
+	fig.ConnectorLineStyleStraight: "edgeStyle=none;rounded=0",
+	fig.ConnectorLineStyleCurved:   "edgeStyle=orthogonalEdgeStyle;curved=1",
+}
+
+var horizontalAligns = map[fig.TextAlignHorizontal]string{
+	fig.TextAlignHorizontalLeft:      "left",
+	fig.TextAlignHorizontalCenter:    "center",
+	fig.TextAlignHorizontalRight:     "right",
+	fig.TextAlignHorizontalJustified: "left",
+}
+
+// style is a draw.io style string, made of bare shape names and key=value
+// pairs separated by semicolons.
+type style []string
+
+func (s *style) add(entries string) {
+	if entries != "" {
+		*s = append(*s, strings.Split(entries, ";")...)
+	}
+}
+
+func (s *style) set(key, value string) {
+	*s = append(*s, key+"="+value)
+}
+
+func (s style) has(key string) bool {
+	for _, v := range s {
+		if strings.HasPrefix(v, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s style) String() string {
+	return strings.Join(s, ";") + ";"
+}
+
+// color returns c in the format draw.io styles expect, or none when c is
+// nil.
+func color(c *scene.Color) string {
+	if c == nil {
+		return "none"
+	}
+	return strings.ToUpper(c.Hex())
+}
+
+// percent formats a fraction between 0 and 1 as a percentage.
+func percent(v float64) string {
+	return strconv.Itoa(int(v*100 + 0.5))
+}
+
+// magnetPoint returns the point of its node's unrotated bounds a magnet
+// sits on, as fractions of the node's size. Automatic magnets are left for
+// draw.io to pick.
+func magnetPoint(magnet fig.ConnectorMagnet) (x, y string, ok bool) {
+	switch magnet {
+	case fig.ConnectorMagnetTop:
+		return "0.5", "0", true
+	case fig.ConnectorMagnetBottom:
+		return "0.5", "1", true
+	case fig.ConnectorMagnetLeft:
+		return "0", "0.5", true
+	case fig.ConnectorMagnetRight:
+		return "1", "0.5", true
+	case fig.ConnectorMagnetCenter:
+		return "0.5", "0.5", true
+	}
+	return "", "", false
+}
+
+// textStyle sets the font of n's text. Font families already set by the
+// style are kept.
+func textStyle(s *style, n *fig.NodeChange) {
+	if n.FontSize > 0 {
+		s.set("fontSize", scene.FormatNumber(n.FontSize, precision))
+	}
+	if f := n.FontName; f != nil {
+		if f.Family != "" && !s.has("fontFamily") {
+			s.set("fontFamily", f.Family)
+		}
+		fontStyle := 0
+		name := strings.ToLower(f.Style)
+		if strings.Contains(name, "bold") {
+			fontStyle |= 1
+		}
+		if strings.Contains(name, "italic") {
+			fontStyle |= 2
+		}
+		if fontStyle != 0 {
+			s.set("fontStyle", strconv.Itoa(fontStyle))
+		}
+	}
+}
+
+// nodeStyle returns the style of a vertex drawing e.
+func nodeStyle(e *scene.Element) style {
+	var s style
+	switch e.Kind {
+	case scene.KindShape:
+		if v, ok := shapeStyles[e.Node.ShapeWithTextType]; ok {
+			s.add(v)
+		} else {
+			s.add("rounded=0")
+		}
+	case scene.KindPolygon:
+		if v, ok := polygonStyles[max(e.Node.Count, 3)]; ok {
+			s.add(v)
+		} else {
+			s.add("ellipse")
+		}
+	default:
+		s.add(kindStyles[e.Kind])
+	}
+	if e.Kind == scene.KindCodeBlock {
+		s.set("html", "0")
+	} else {
+		s.set("html", "1")
+		s.set("whiteSpace", "wrap")
+	}
+
+	if e.Kind == scene.KindText {
+		s.set("align", horizontalAligns[e.Node.TextAlignHorizontal])
+		s.set("fillColor", "none")
+		s.set("strokeColor", "none")
+		if e.Style.Fill != nil {
+			s.set("fontColor", color(e.Style.Fill))
+		}
+		textStyle(&s, e.Node)
+		return s
+	}
+
+	if e.Kind == scene.KindOther && len(e.Images) > 0 && e.Images[0].Image != nil {
+		if uri := imageURI(e.Images[0].Image); uri != "" {
+			s.set("image", uri)
+		}
+	}
+	s.set("fillColor", color(e.Style.Fill))
+	if e.Style.FillOpacity < 1 {
+		s.set("fillOpacity", percent(e.Style.FillOpacity))
+	}
+	if e.Style.Stroked() {
+		s.set("strokeColor", color(e.Style.Stroke))
+		if e.Style.StrokeWidth != 1 {
+			s.set("strokeWidth", scene.FormatNumber(e.Style.StrokeWidth, precision))
+		}
+		if len(e.Style.Dash) > 0 {
+			s.set("dashed", "1")
+		}
+	} else if e.Kind != scene.KindLine {
+		s.set("strokeColor", "none")
+	}
+	if e.Style.Fill.IsDark() {
+		s.set("fontColor", "#FFFFFF")
+	}
+	if e.Style.Opacity < 1 {
+		s.set("opacity", percent(e.Style.Opacity))
+	}
+	textStyle(&s, e.Node)
+	return s
+}
+
+// clusterStyle returns the style of a container vertex drawing e.
+func clusterStyle(e *scene.Element) style {
+	var s style
+	s.add(clusterStyles[e.Kind])
+	if e.Kind == scene.KindGroup {
+		return s
+	}
+	s.set("fillColor", color(e.Style.Fill))
+	if e.Style.Stroked() {
+		s.set("strokeColor", color(e.Style.Stroke))
+	}
+	if e.Style.Fill.IsDark() {
+		s.set("fontColor", "#FFFFFF")
+	}
+	return s
+}
+
+// edgeStyle returns the style of the edge drawing connector e.
+func edgeStyle(e *scene.Element) style {
+	conn := e.Connector
+	var s style
+	s.add(edgeStyles[conn.LineStyle])
+	s.set("html", "0")
+	for _, end := range []struct {
+		prefix string
+		cap    fig.StrokeCap
+	}{{"start", conn.StartCap}, {"end", conn.EndCap}} {
+		arrow, ok := arrows[end.cap]
+		if !ok {
+			s.set(end.prefix+"Arrow", "none")
+			continue
+		}
+		s.set(end.prefix+"Arrow", arrow.name)
+		if arrow.filled {
+			s.set(end.prefix+"Fill", "1")
+		} else {
+			s.set(end.prefix+"Fill", "0")
+		}
+	}
+	for _, end := range []struct {
+		prefix string
+		e      scene.Endpoint
+	}{{"exit", conn.Start}, {"entry", conn.End}} {
+		if end.e.Element == nil {
+			continue
+		}
+		if x, y, ok := magnetPoint(end.e.Magnet); ok {
+			s.set(end.prefix+"X", x)
+			s.set(end.prefix+"Y", y)
+		}
+	}
+	if e.Style.Stroke != nil {
+		s.set("strokeColor", color(e.Style.Stroke))
+	} else {
+		s.set("strokeColor", "#000000")
+	}
+	if e.Style.StrokeWidth > 0 && e.Style.StrokeWidth != 1 {
+		s.set("strokeWidth", scene.FormatNumber(e.Style.StrokeWidth, precision))
+	}
+	if len(e.Style.Dash) > 0 {
+		s.set("dashed", "1")
+	}
+	if e.Style.Opacity < 1 {
+		s.set("opacity", percent(e.Style.Opacity))
+	}
+	if conn.Label != nil {
+		s.set("labelBackgroundColor", "#FFFFFF")
+		textStyle(&s, e.Node)
+	}
+	return s
+}
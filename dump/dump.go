@@ -0,0 +1,218 @@
+package dump
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+type Options struct {
+	Format Format
+
+	// Depth limits how many levels of children are included below the
+	// dumped node. Negative values include the whole subtree.
+	Depth int
+
+	// Fields, when not empty, lists the only fields of nodes to include.
+	// Names are matched regardless of case.
+	Fields []string
+
+	// All includes zero-valued fields, and the *Tag fields recording which
+	// fields were present in the file.
+	All bool
+}
+
+// maxHexBytes is the size up to which byte fields, such as hashes, are
+// printed in full. Larger ones are summarized by their size.
+const maxHexBytes = 32
+
+// Value is the dumped representation of a value: an object, a list, or a
+// scalar held as a string, bool, int64, uint64 or float64, or nil.
+type Value any
+
+// Field is an entry of an object.
+type Field struct {
+	Name  string
+	Value Value
+}
+
+// Object holds fields in the order they are declared in.
+type Object []Field
+
+type List []Value
+
+// Dumper converts decoded values into their dumped representation.
+type Dumper struct {
+	opts   *Options
+	blobs  []*fig.Blob
+	fields map[string]bool
+}
+
+// New returns a dumper resolving blob references against blobs.
+func New(opts *Options, blobs []*fig.Blob) *Dumper {
+	if opts == nil {
+		opts = &Options{Depth: -1}
+	}
+	d := &Dumper{opts: opts, blobs: blobs}
+	if len(opts.Fields) > 0 {
+		d.fields = map[string]bool{}
+		for _, v := range opts.Fields {
+			d.fields[strings.ToLower(strings.TrimSpace(v))] = true
+		}
+	}
+	return d
+}
+
+// Message returns the dumped representation of m. Nodes are listed as they
+// appear in the message, without their children.
+func (d *Dumper) Message(m *fig.Message) Value {
+	return d.value(reflect.ValueOf(m), "", 0, false)
+}
+
+// Node returns the dumped representation of n, along with its children up
+// to the configured depth.
+func (d *Dumper) Node(n *fig.NodeChange) Value {
+	return d.value(reflect.ValueOf(n), "", 0, true)
+}
+
+// Write writes v in the configured format.
+func (d *Dumper) Write(v Value, w io.Writer) error {
+	var out string
+	if d.opts.Format == FormatYAML {
+		out = YAML(v)
+	} else {
+		out = JSON(v)
+	}
+	_, err := io.WriteString(w, out)
+	return err
+}
+
+var (
+	nodeChangeType = reflect.TypeOf(fig.NodeChange{})
+	figPackage     = nodeChangeType.PkgPath()
+)
+
+// value converts v, held by the field named name. level is the depth of the
+// node being converted, and tree is set when children are to be included.
+func (d *Dumper) value(v reflect.Value, name string, level int, tree bool) Value {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return d.value(v.Elem(), name, level, tree)
+	case reflect.Struct:
+		return d.object(v, level, tree)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return d.bytes(v.Bytes())
+		}
+		res := make(List, v.Len())
+		for i := range res {
+			res[i] = d.value(v.Index(i), name, level, tree)
+		}
+		return res
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Float32, reflect.Float64:
+		// Floats are stored with single precision, and printed with the
+		// digits needed to tell them apart.
+		f, _ := strconv.ParseFloat(strconv.FormatFloat(v.Float(), 'g', -1, 32), 64)
+		return f
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type().PkgPath() == figPackage {
+			if s, ok := fig.EnumName(v.Interface()); ok {
+				return s
+			}
+		}
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if strings.HasSuffix(name, "Blob") {
+			return d.blob(v.Uint())
+		}
+		return v.Uint()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// object converts the struct v. Children of nodes, which are not part of
+// the schema, are only included in trees, up to the configured depth.
+func (d *Dumper) object(v reflect.Value, level int, tree bool) Object {
+	t := v.Type()
+	isNode := t == nodeChangeType
+	res := Object{}
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if isNode && f.Name == "Children" {
+			if !tree || fv.Len() == 0 || (d.opts.Depth >= 0 && level >= d.opts.Depth) {
+				continue
+			}
+			children := make(List, fv.Len())
+			for j := range children {
+				children[j] = d.value(fv.Index(j), f.Name, level+1, tree)
+			}
+			res = append(res, Field{f.Name, children})
+			continue
+		}
+		if isNode && d.fields != nil && !d.fields[strings.ToLower(f.Name)] {
+			continue
+		}
+		if !d.opts.All && (isTag(t, f) || isZero(f, fv)) {
+			continue
+		}
+		res = append(res, Field{f.Name, d.value(fv, f.Name, level, tree)})
+	}
+	return res
+}
+
+// isZero reports whether fv holds the zero value of f, or an empty slice.
+// Blob references are never zero, as 0 is the index of the first blob.
+func isZero(f reflect.StructField, fv reflect.Value) bool {
+	if fv.Kind() == reflect.Uint && strings.HasSuffix(f.Name, "Blob") {
+		return false
+	}
+	return fv.IsZero() || (fv.Kind() == reflect.Slice && fv.Len() == 0)
+}
+
+// isTag reports whether f records the presence of another field of t, such
+// as StrokeCapTag for StrokeCap.
+func isTag(t reflect.Type, f reflect.StructField) bool {
+	name, ok := strings.CutSuffix(f.Name, "Tag")
+	if !ok || f.Type.Kind() != reflect.Uint {
+		return false
+	}
+	_, ok = t.FieldByName(name)
+	return ok
+}
+
+// bytes summarizes b, printing short values such as hashes in hex.
+func (d *Dumper) bytes(b []byte) Value {
+	if len(b) <= maxHexBytes {
+		return hex.EncodeToString(b)
+	}
+	return fmt.Sprintf("<%d bytes>", len(b))
+}
+
+// blob summarizes the blob at index i of the message.
+func (d *Dumper) blob(i uint64) Value {
+	if i >= uint64(len(d.blobs)) || d.blobs[i] == nil {
+		return fmt.Sprintf("<blob %d: missing>", i)
+	}
+	return fmt.Sprintf("<blob %d: %d bytes>", i, len(d.blobs[i].Bytes))
+}
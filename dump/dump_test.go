@@ -0,0 +1,116 @@
+package dump
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"math"
+	"strings"
+	"testing"
+)
+
+func tree() *fig.NodeChange {
+	leaf := &fig.NodeChange{Guid: &fig.GUID{SessionId: 1, LocalId: 3}, Type: fig.NodeTypeText, Name: "yes"}
+	child := &fig.NodeChange{
+		Guid: &fig.GUID{SessionId: 1, LocalId: 2}, Type: fig.NodeTypeFrame, Name: "Frame",
+		ParentIndex: &fig.ParentIndex{Guid: &fig.GUID{LocalId: 1}, Position: "!"},
+		Children:    []*fig.NodeChange{leaf},
+	}
+	return &fig.NodeChange{Guid: &fig.GUID{LocalId: 1}, Type: fig.NodeTypeCanvas, Name: "Page", Children: []*fig.NodeChange{child}}
+}
+
+func TestNode(t *testing.T) {
+	var buf bytes.Buffer
+	d := New(&Options{Format: FormatJSON, Depth: 1, Fields: []string{"name", " type "}}, nil)
+	if err := d.Write(d.Node(tree()), &buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "Type": "NodeTypeCanvas",
+  "Name": "Page",
+  "Children": [
+    {
+      "Type": "NodeTypeFrame",
+      "Name": "Frame"
+    }
+  ]
+}
+`
+	if got := buf.String(); got != want {
+		t.Errorf("expected fields in declaration order, down to the depth, got:\n%s", got)
+	}
+}
+
+// Nodes of messages are listed without their children, which their parent
+// index places.
+func TestMessage(t *testing.T) {
+	page := tree()
+	v := New(nil, nil).Message(&fig.Message{NodeChanges: []*fig.NodeChange{page, page.Children[0]}})
+	out := JSON(v)
+	if strings.Contains(out, `"Children"`) {
+		t.Errorf("expected children to be left out, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"ParentIndex": {`) {
+		t.Errorf("expected parent indexes to be kept, got:\n%s", out)
+	}
+}
+
+func TestBlobs(t *testing.T) {
+	d := New(nil, []*fig.Blob{{Bytes: make([]byte, 40)}})
+	if got := d.blob(0); got != "<blob 0: 40 bytes>" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if got := d.blob(3); got != "<blob 3: missing>" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if got := d.bytes([]byte{0xca, 0xfe}); got != "cafe" {
+		t.Errorf("expected short values in hex, got %q", got)
+	}
+}
+
+func TestJSONNonFinite(t *testing.T) {
+	got := JSON(Object{{"a", math.NaN()}, {"b", math.Inf(1)}, {"c", 1.5}})
+	want := "{\n  \"a\": null,\n  \"b\": null,\n  \"c\": 1.5\n}\n"
+	if got != want {
+		t.Errorf("expected NaN and infinities as null, got:\n%s", got)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	got := YAML(Object{
+		{"name", "Page"},
+		{"list", List{int64(1), Object{{"a", true}, {"b", nil}}, List{}}},
+		{"empty", Object{}},
+		{"ratio", math.NaN()},
+		{"limit", math.Inf(-1)},
+	})
+	want := `name: Page
+list:
+  - 1
+  - a: true
+    b: null
+  - []
+empty: {}
+ratio: .nan
+limit: -.inf
+`
+	if got != want {
+		t.Errorf("unexpected YAML:\n%s", got)
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	for _, s := range []string{
+		"", "true", "No", "~", "null", " padded", "12", "1.5e3", "0x1F", "0o17", "1_000",
+		"1:30", ".inf", "-.Inf", ".NaN", "=", "2024-01-31", "- item", "key: value",
+		"a #comment", "ends:", "tab\there", "'quoted'",
+	} {
+		if got := yamlString(s); got == s {
+			t.Errorf("expected %q to be quoted", s)
+		}
+	}
+	for _, s := range []string{"Page", "Ship it", "v1.2.3", "a:b", "C#", "1 vote"} {
+		if got := yamlString(s); got != s {
+			t.Errorf("expected %q to be left plain, got %s", s, got)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// JSON formats v as an indented JSON document, keeping the order of the
+// fields of objects.
+func JSON(v Value) string {
+	var b strings.Builder
+	writeJSON(&b, v, "")
+	b.WriteString("\n")
+	return b.String()
+}
+
+func writeJSON(b *strings.Builder, v Value, indent string) {
+	inner := indent + "  "
+	switch v := v.(type) {
+	case Object:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{\n")
+		for i, f := range v {
+			b.WriteString(inner)
+			b.WriteString(jsonString(f.Name))
+			b.WriteString(": ")
+			writeJSON(b, f.Value, inner)
+			if i < len(v)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	case List:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for i, item := range v {
+			b.WriteString(inner)
+			writeJSON(b, item, inner)
+			if i < len(v)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "]")
+	default:
+		b.WriteString(jsonScalar(v))
+	}
+}
+
+// jsonScalar formats v as a JSON value. JSON has no representation for NaN
+// and infinities, which are written as null.
+func jsonScalar(v Value) string {
+	if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return "null"
+	}
+	return scalar(v, jsonString)
+}
+
+// scalar formats v, quoting strings with quote.
+func scalar(v Value, quote func(string) string) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return "null"
+}
+
+// jsonString quotes s as a JSON string, leaving characters such as < and &
+// as they are.
+func jsonString(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
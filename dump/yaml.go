@@ -0,0 +1,146 @@
+package dump
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// YAML formats v as a YAML document, keeping the order of the fields of
+// objects.
+func YAML(v Value) string {
+	var b strings.Builder
+	switch v := v.(type) {
+	case Object:
+		if len(v) == 0 {
+			b.WriteString("{}\n")
+			break
+		}
+		writeYAMLObject(&b, v, "")
+	case List:
+		if len(v) == 0 {
+			b.WriteString("[]\n")
+			break
+		}
+		writeYAMLList(&b, v, "")
+	default:
+		b.WriteString(yamlScalar(v) + "\n")
+	}
+	return b.String()
+}
+
+// writeYAMLObject writes the fields of v, the first one without indentation
+// so it can follow a list item's dash.
+func writeYAMLObject(b *strings.Builder, v Object, indent string) {
+	for i, f := range v {
+		if i > 0 {
+			b.WriteString(indent)
+		}
+		b.WriteString(yamlString(f.Name) + ":")
+		writeYAMLValue(b, f.Value, indent+"  ")
+	}
+}
+
+func writeYAMLList(b *strings.Builder, v List, indent string) {
+	for i, item := range v {
+		if i > 0 {
+			b.WriteString(indent)
+		}
+		b.WriteString("-")
+		switch item := item.(type) {
+		case Object:
+			if len(item) > 0 {
+				b.WriteString(" ")
+				writeYAMLObject(b, item, indent+"  ")
+				continue
+			}
+		case List:
+			if len(item) > 0 {
+				b.WriteString(" ")
+				writeYAMLList(b, item, indent+"  ")
+				continue
+			}
+		}
+		writeYAMLValue(b, item, indent+"  ")
+	}
+}
+
+// writeYAMLValue writes v after a key or dash, either on the same line or
+// as a block indented by indent.
+func writeYAMLValue(b *strings.Builder, v Value, indent string) {
+	switch v := v.(type) {
+	case Object:
+		if len(v) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+		b.WriteString("\n" + indent)
+		writeYAMLObject(b, v, indent)
+	case List:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n" + indent)
+		writeYAMLList(b, v, indent)
+	default:
+		b.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// yamlScalar formats v as a YAML scalar, writing NaN and infinities the way
+// YAML spells them.
+func yamlScalar(v Value) string {
+	if f, ok := v.(float64); ok {
+		switch {
+		case math.IsNaN(f):
+			return ".nan"
+		case math.IsInf(f, 1):
+			return ".inf"
+		case math.IsInf(f, -1):
+			return "-.inf"
+		}
+	}
+	return scalar(v, yamlString)
+}
+
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "null": true, "~": true,
+	"yes": true, "no": true, "on": true, "off": true, "y": true, "n": true,
+}
+
+// yamlImplicit matches the scalars YAML 1.1 and 1.2 parsers resolve to
+// numbers, timestamps or the value key rather than strings, such as 0x1F,
+// 1_000, 1:30, .inf or 2024-01-31.
+var yamlImplicit = regexp.MustCompile(`^(?:[-+]?(?:0b[01_]+|0o?[0-7_]+|0x[0-9a-fA-F_]+|[0-9][0-9_]*(?::[0-5]?[0-9])*|[0-9_]*\.[0-9_]*(?:[eE][-+]?[0-9]+)?|\.(?:inf|Inf|INF))|\.(?:nan|NaN|NAN)|=|[0-9]{4}-[0-9]{1,2}-[0-9]{1,2}(?:[Tt ].*)?)$`)
+
+// yamlString returns s as a plain scalar when it cannot be mistaken for
+// another value or for YAML syntax, and double-quoted otherwise.
+func yamlString(s string) string {
+	if plainYAML(s) {
+		return s
+	}
+	return jsonString(s)
+}
+
+func plainYAML(s string) bool {
+	if s == "" || yamlReserved[strings.ToLower(s)] || s != strings.TrimSpace(s) {
+		return false
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil || yamlImplicit.MatchString(s) {
+		return false
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`<") {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
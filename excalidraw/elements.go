@@ -0,0 +1,114 @@
+package excalidraw
+
+// file is the content of a .excalidraw file.
+type file struct {
+	Type     string              `json:"type"`
+	Version  int                 `json:"version"`
+	Source   string              `json:"source"`
+	Elements []any               `json:"elements"`
+	AppState appState            `json:"appState"`
+	Files    map[string]fileData `json:"files"`
+}
+
+type appState struct {
+	Name                string `json:"name,omitempty"`
+	ViewBackgroundColor string `json:"viewBackgroundColor"`
+	GridSize            *int   `json:"gridSize"`
+}
+
+// fileData is an image referenced by image elements.
+type fileData struct {
+	ID       string `json:"id"`
+	MimeType string `json:"mimeType"`
+	DataURL  string `json:"dataURL"`
+	Created  int64  `json:"created"`
+}
+
+type roundness struct {
+	Type int `json:"type"`
+}
+
+// Roundness types, as understood by Excalidraw.
+const (
+	roundnessProportional = 2
+	roundnessAdaptive     = 3
+)
+
+type boundElement struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type binding struct {
+	ElementID string  `json:"elementId"`
+	Focus     float64 `json:"focus"`
+	Gap       float64 `json:"gap"`
+}
+
+// element holds the properties shared by every kind of element. Angles are
+// in radians, and the position is the top left corner of the element before
+// it is rotated around its center.
+type element struct {
+	ID              string         `json:"id"`
+	Type            string         `json:"type"`
+	X               float64        `json:"x"`
+	Y               float64        `json:"y"`
+	Width           float64        `json:"width"`
+	Height          float64        `json:"height"`
+	Angle           float64        `json:"angle"`
+	StrokeColor     string         `json:"strokeColor"`
+	BackgroundColor string         `json:"backgroundColor"`
+	FillStyle       string         `json:"fillStyle"`
+	StrokeWidth     float64        `json:"strokeWidth"`
+	StrokeStyle     string         `json:"strokeStyle"`
+	Roughness       int            `json:"roughness"`
+	Opacity         int            `json:"opacity"`
+	GroupIDs        []string       `json:"groupIds"`
+	FrameID         *string        `json:"frameId"`
+	Roundness       *roundness     `json:"roundness"`
+	Seed            uint32         `json:"seed"`
+	Version         int            `json:"version"`
+	VersionNonce    uint32         `json:"versionNonce"`
+	IsDeleted       bool           `json:"isDeleted"`
+	BoundElements   []boundElement `json:"boundElements"`
+	Updated         int64          `json:"updated"`
+	Link            *string        `json:"link"`
+	Locked          bool           `json:"locked"`
+}
+
+type textElement struct {
+	element
+	Text          string  `json:"text"`
+	OriginalText  string  `json:"originalText"`
+	FontSize      float64 `json:"fontSize"`
+	FontFamily    int     `json:"fontFamily"`
+	TextAlign     string  `json:"textAlign"`
+	VerticalAlign string  `json:"verticalAlign"`
+	ContainerID   *string `json:"containerId"`
+	LineHeight    float64 `json:"lineHeight"`
+	AutoResize    bool    `json:"autoResize"`
+}
+
+// linearElement is a line or an arrow. Points are relative to the
+// element's position.
+type linearElement struct {
+	element
+	Points         [][2]float64 `json:"points"`
+	Polygon        bool         `json:"polygon,omitempty"`
+	StartBinding   *binding     `json:"startBinding"`
+	EndBinding     *binding     `json:"endBinding"`
+	StartArrowhead *string      `json:"startArrowhead"`
+	EndArrowhead   *string      `json:"endArrowhead"`
+}
+
+type imageElement struct {
+	element
+	FileID string     `json:"fileId"`
+	Status string     `json:"status"`
+	Scale  [2]float64 `json:"scale"`
+}
+
+type frameElement struct {
+	element
+	Name string `json:"name"`
+}
@@ -0,0 +1,515 @@
+package excalidraw
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"hash/fnv"
+	"io"
+	"math"
+	"strings"
+)
+
+const (
+	defaultFontSize = 16
+	lineHeight      = 1.25
+	// bindingGap is the distance Excalidraw keeps between bound arrows and
+	// the elements they are attached to.
+	bindingGap = 5
+	// curveSteps is the number of segments curves are approximated with.
+	curveSteps = 8
+)
+
+// Font families, as numbered by Excalidraw.
+const (
+	fontHelvetica = 2
+	fontCascadia  = 3
+)
+
+// Renderer renders the graph of shapes and connectors of scenes as
+// Excalidraw scenes.
+type Renderer struct{}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	c := &Compiler{
+		graph:    graph.Build(s),
+		elements: map[string]*element{},
+		files:    map[string]fileData{},
+	}
+	data, err := json.MarshalIndent(c.Compile(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+type Compiler struct {
+	graph *graph.Graph
+	out   []any
+	// elements holds the elements written so far by id, for binding
+	// arrows and labels to them.
+	elements map[string]*element
+	files    map[string]fileData
+}
+
+var shapeTypes = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:           "rectangle",
+	fig.ShapeWithTextTypeRoundedRectangle: "rectangle",
+	fig.ShapeWithTextTypeEllipse:          "ellipse",
+	fig.ShapeWithTextTypeDiamond:          "diamond",
+}
+
+var kindTypes = map[scene.Kind]string{
+	scene.KindSticky:      "rectangle",
+	scene.KindTable:       "rectangle",
+	scene.KindCodeBlock:   "rectangle",
+	scene.KindLinkPreview: "rectangle",
+	scene.KindRectangle:   "rectangle",
+	scene.KindEllipse:     "ellipse",
+}
+
+var arrowheads = map[fig.StrokeCap]string{
+	fig.StrokeCapArrowLines:       "arrow",
+	fig.StrokeCapArrowEquilateral: "triangle",
+	fig.StrokeCapTriangleFilled:   "triangle",
+	fig.StrokeCapDiamondFilled:    "diamond",
+	fig.StrokeCapCircleFilled:     "dot",
+}
+
+var textAligns = map[fig.TextAlignHorizontal]string{
+	fig.TextAlignHorizontalLeft:      "left",
+	fig.TextAlignHorizontalCenter:    "center",
+	fig.TextAlignHorizontalRight:     "right",
+	fig.TextAlignHorizontalJustified: "left",
+}
+
+// seed derives the random seed Excalidraw sketches an element with from
+// its id, so that output is stable across runs.
+func seed(id string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return h.Sum32()
+}
+
+func color(c *scene.Color, fallback string) string {
+	if c == nil {
+		return fallback
+	}
+	return c.Hex()
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// geometry returns the unrotated box of e in the scene's coordinates, and
+// the angle in radians it is rotated by around its center once mirrored.
+// Rectangles, ellipses and diamonds look the same mirrored, and text is kept
+// readable, so only images and outlines are mirrored themselves.
+func geometry(e *scene.Element) (scene.Rect, float64) {
+	if e.Transform.IsTranslation() {
+		return e.Bounds, 0
+	}
+	center, half := e.Center(), e.Size.Mul(0.5)
+	rotation, _, _ := e.Transform.Orientation()
+	return scene.Rect{Min: center.Sub(half), Max: center.Add(half)}, rotation * math.Pi / 180
+}
+
+// Compile returns the Excalidraw scene for the graph.
+func (c *Compiler) Compile() any {
+	for _, v := range c.graph.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range c.graph.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range c.graph.Edges {
+		c.writeEdge(e)
+	}
+
+	s := c.graph.Scene
+	name := s.Page.Name
+	if s.Root != s.Page {
+		name = s.Root.Name
+	}
+	if c.out == nil {
+		c.out = []any{}
+	}
+	return &file{
+		Type:     "excalidraw",
+		Version:  2,
+		Source:   "https://github.com/heyvito/figz",
+		Elements: c.out,
+		AppState: appState{Name: name, ViewBackgroundColor: "#ffffff"},
+		Files:    c.files,
+	}
+}
+
+// newElement returns an element of the given kind filling box, placed
+// within the frame and groups of cluster.
+func (c *Compiler) newElement(id, kind string, box scene.Rect, angle float64, cluster *graph.Cluster) element {
+	size := box.Size()
+	res := element{
+		ID:              id,
+		Type:            kind,
+		X:               round(box.Min.X),
+		Y:               round(box.Min.Y),
+		Width:           round(size.X),
+		Height:          round(size.Y),
+		Angle:           angle,
+		StrokeColor:     "#1e1e1e",
+		BackgroundColor: "transparent",
+		FillStyle:       "solid",
+		StrokeWidth:     1,
+		StrokeStyle:     "solid",
+		Opacity:         100,
+		GroupIDs:        []string{},
+		Seed:            seed(id),
+		Version:         1,
+		VersionNonce:    seed(id + ":version"),
+		BoundElements:   []boundElement{},
+	}
+	// Excalidraw frames cannot be nested, so elements belong to the closest
+	// enclosing one, and groups are listed from the innermost.
+	for v := cluster; v != nil; v = v.Parent {
+		if v.Kind == scene.KindGroup {
+			res.GroupIDs = append(res.GroupIDs, v.ID)
+		} else if res.FrameID == nil {
+			id := v.ID
+			res.FrameID = &id
+		}
+	}
+	return res
+}
+
+// add appends v, whose common properties are held by e, to the scene.
+func (c *Compiler) add(e *element, v any) {
+	c.elements[e.ID] = e
+	c.out = append(c.out, v)
+}
+
+// style applies the fill, stroke and opacity of s to e.
+func style(e *element, s scene.Style) {
+	e.BackgroundColor = color(s.Fill, "transparent")
+	if s.Stroked() {
+		e.StrokeColor = s.Stroke.Hex()
+		e.StrokeWidth = round(s.StrokeWidth)
+		if len(s.Dash) > 0 {
+			e.StrokeStyle = "dashed"
+		}
+	} else {
+		e.StrokeColor = "transparent"
+	}
+	e.Opacity = int(s.Opacity*100 + 0.5)
+}
+
+// writeCluster writes sections and frames as frames, ahead of their content
+// so that it is drawn above them. Groups are written as the groups of the
+// elements they hold.
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	if v.Kind != scene.KindGroup {
+		box, angle := geometry(v.Element)
+		// Frames are only placed in groups, never in other frames.
+		f := &frameElement{element: c.newElement(v.ID, "frame", box, angle, v.Parent), Name: v.Label}
+		f.FrameID = nil
+		f.StrokeColor = "#bbbbbb"
+		c.add(&f.element, f)
+	}
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	e := n.Element
+	box, angle := geometry(e)
+	switch n.Kind {
+	case scene.KindText:
+		t := c.newText(e.ID, n.Label, e.Node, box, angle, n.Cluster)
+		t.StrokeColor = color(e.Style.Fill, "#1e1e1e")
+		t.TextAlign = textAligns[e.Node.TextAlignHorizontal]
+		t.VerticalAlign = "top"
+		c.add(&t.element, t)
+		return
+	case scene.KindOther:
+		c.writeImage(n, box, angle)
+		return
+	}
+
+	kind, ok := kindTypes[n.Kind]
+	if n.Kind == scene.KindShape {
+		kind, ok = shapeTypes[n.Shape]
+	}
+	if !ok {
+		c.writeOutline(n, box, angle)
+		return
+	}
+	v := new(element)
+	*v = c.newElement(e.ID, kind, box, angle, n.Cluster)
+	style(v, e.Style)
+	switch {
+	case n.Kind == scene.KindShape && n.Shape == fig.ShapeWithTextTypeRoundedRectangle,
+		n.Kind == scene.KindCodeBlock, n.Kind == scene.KindLinkPreview,
+		n.Kind == scene.KindRectangle && scene.CornerRadii(e.Node) != [4]float64{}:
+		v.Roundness = &roundness{Type: roundnessAdaptive}
+	}
+	if e.Link != "" {
+		link := e.Link
+		v.Link = &link
+	}
+	c.add(v, v)
+	if n.Label != "" {
+		c.writeLabel(v, n, box, angle)
+	}
+}
+
+// writeLabel writes the text of n bound to the element drawing it, which
+// Excalidraw keeps centered within it.
+func (c *Compiler) writeLabel(container *element, n *graph.Node, box scene.Rect, angle float64) {
+	e := n.Element
+	t := c.newText(e.ID+":text", n.Label, e.Node, box, angle, n.Cluster)
+	t.ContainerID = &container.ID
+	t.StrokeColor = "#1e1e1e"
+	if e.Style.Fill.IsDark() {
+		t.StrokeColor = "#ffffff"
+	}
+	switch n.Kind {
+	case scene.KindSticky, scene.KindTable, scene.KindCodeBlock:
+		t.TextAlign, t.VerticalAlign = "left", "top"
+		t.Y = container.Y
+	}
+	if n.Kind == scene.KindCodeBlock {
+		t.FontFamily = fontCascadia
+	}
+	container.BoundElements = append(container.BoundElements, boundElement{ID: t.ID, Type: "text"})
+	c.add(&t.element, t)
+}
+
+// newText returns a text element centered within box, sized after the
+// number of lines of text.
+func (c *Compiler) newText(id, text string, n *fig.NodeChange, box scene.Rect, angle float64, cluster *graph.Cluster) *textElement {
+	size := n.FontSize
+	if size == 0 {
+		size = defaultFontSize
+	}
+	height := float64(strings.Count(text, "\n")+1) * size * lineHeight
+	center := box.Center()
+	box = scene.Rect{
+		Min: scene.Point{X: box.Min.X, Y: center.Y - height/2},
+		Max: scene.Point{X: box.Max.X, Y: center.Y + height/2},
+	}
+	return &textElement{
+		element:       c.newElement(id, "text", box, angle, cluster),
+		Text:          text,
+		OriginalText:  text,
+		FontSize:      size,
+		FontFamily:    fontHelvetica,
+		TextAlign:     "center",
+		VerticalAlign: "middle",
+		LineHeight:    lineHeight,
+	}
+}
+
+// writeImage writes image nodes, falling back to an empty rectangle for
+// images in unsupported formats.
+func (c *Compiler) writeImage(n *graph.Node, box scene.Rect, angle float64) {
+	img := n.Element.Images[0].Image
+	mime := ""
+	if img != nil {
+		mime = img.MIMEType()
+	}
+	if mime == "" {
+		v := new(element)
+		*v = c.newElement(n.ID, "rectangle", box, angle, n.Cluster)
+		c.add(v, v)
+		return
+	}
+	if _, ok := c.files[img.Hash]; !ok {
+		c.files[img.Hash] = fileData{
+			ID:       img.Hash,
+			MimeType: mime,
+			DataURL:  "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(img.Data),
+		}
+	}
+	v := &imageElement{
+		element: c.newElement(n.ID, "image", box, angle, n.Cluster),
+		FileID:  img.Hash,
+		Status:  "saved",
+		Scale:   [2]float64{1, 1},
+	}
+	// Images are mirrored by negative scales.
+	if _, flipH, flipV := n.Element.Transform.Orientation(); flipH {
+		v.Scale[0] = -1
+	} else if flipV {
+		v.Scale[1] = -1
+	}
+	v.StrokeColor = "transparent"
+	c.add(&v.element, v)
+}
+
+// flatten returns the vertices of the first subpath of path, approximating
+// curves with line segments.
+func flatten(path []scene.PathCommand) []scene.Point {
+	var res []scene.Point
+	var cur scene.Point
+	for _, cmd := range path {
+		switch cmd.Kind {
+		case scene.PathMoveTo:
+			if len(res) > 0 {
+				return res
+			}
+			res = append(res, cmd.Points[0])
+		case scene.PathLineTo:
+			res = append(res, cmd.Points[0])
+		case scene.PathQuadTo:
+			p1, p2 := cmd.Points[0], cmd.Points[1]
+			for i := 1; i <= curveSteps; i++ {
+				t := float64(i) / curveSteps
+				res = append(res, cur.Mul((1-t)*(1-t)).Add(p1.Mul(2*(1-t)*t)).Add(p2.Mul(t*t)))
+			}
+		case scene.PathCubicTo:
+			p1, p2, p3 := cmd.Points[0], cmd.Points[1], cmd.Points[2]
+			for i := 1; i <= curveSteps; i++ {
+				t := float64(i) / curveSteps
+				u := 1 - t
+				res = append(res, cur.Mul(u*u*u).Add(p1.Mul(3*u*u*t)).Add(p2.Mul(3*u*t*t)).Add(p3.Mul(t*t*t)))
+			}
+		case scene.PathClose:
+			if len(res) > 0 {
+				res = append(res, res[0])
+			}
+			return res
+		}
+		if len(res) > 0 {
+			cur = res[len(res)-1]
+		}
+	}
+	return res
+}
+
+// writeOutline writes shapes Excalidraw has no element for as lines
+// following their outline. Their text is grouped with them, as lines
+// cannot hold text.
+func (c *Compiler) writeOutline(n *graph.Node, box scene.Rect, angle float64) {
+	e := n.Element
+	points := flatten(scene.Outline(e))
+	if len(points) < 2 {
+		return
+	}
+	v := &linearElement{element: c.newElement(e.ID, "line", box, angle, n.Cluster)}
+	style(&v.element, e.Style)
+	_, flipH, flipV := e.Transform.Orientation()
+	v.Points = make([][2]float64, len(points))
+	for i, p := range points {
+		if flipH {
+			p.X = e.Size.X - p.X
+		}
+		if flipV {
+			p.Y = e.Size.Y - p.Y
+		}
+		v.Points[i] = [2]float64{round(p.X), round(p.Y)}
+	}
+	v.Polygon = len(points) > 2 && points[0] == points[len(points)-1]
+	if !v.Polygon {
+		v.BackgroundColor = "transparent"
+		if !e.Style.Stroked() {
+			v.StrokeColor = color(e.Style.Fill, "#1e1e1e")
+		}
+	}
+	if n.Label == "" {
+		c.add(&v.element, v)
+		return
+	}
+	v.GroupIDs = append([]string{e.ID + ":group"}, v.GroupIDs...)
+	c.add(&v.element, v)
+	t := c.newText(e.ID+":text", n.Label, e.Node, box, angle, n.Cluster)
+	t.GroupIDs = v.GroupIDs
+	t.StrokeColor = "#1e1e1e"
+	if e.Style.Fill.IsDark() {
+		t.StrokeColor = "#ffffff"
+	}
+	c.add(&t.element, t)
+}
+
+// bindable reports whether arrows can be bound to the element identified
+// by id.
+func (c *Compiler) bindable(id string) bool {
+	e, ok := c.elements[id]
+	if !ok {
+		return false
+	}
+	switch e.Type {
+	case "rectangle", "ellipse", "diamond", "text", "image", "frame":
+		return true
+	}
+	return false
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	conn := e.Element.Connector
+	origin := conn.Points[0]
+	bounds := scene.EmptyRect()
+	for _, p := range conn.Points {
+		bounds = bounds.Extend(p)
+	}
+
+	v := &linearElement{element: c.newElement(e.ID, "arrow", bounds, 0, nil)}
+	v.X, v.Y = round(origin.X), round(origin.Y)
+	v.StrokeColor = color(e.Color, "#1e1e1e")
+	if w := e.Element.Style.StrokeWidth; w > 0 {
+		v.StrokeWidth = round(w)
+	}
+	if e.Dashed {
+		v.StrokeStyle = "dashed"
+	}
+	if e.LineStyle == fig.ConnectorLineStyleCurved {
+		v.Roundness = &roundness{Type: roundnessProportional}
+	}
+	v.Points = make([][2]float64, len(conn.Points))
+	for i, p := range conn.Points {
+		d := p.Sub(origin)
+		v.Points[i] = [2]float64{round(d.X), round(d.Y)}
+	}
+	if head, ok := arrowheads[e.StartCap]; ok {
+		v.StartArrowhead = &head
+	}
+	if head, ok := arrowheads[e.EndCap]; ok {
+		v.EndArrowhead = &head
+	}
+
+	for _, end := range []struct {
+		endpoint graph.Endpoint
+		binding  **binding
+	}{{e.Source, &v.StartBinding}, {e.Target, &v.EndBinding}} {
+		id := end.endpoint.ID
+		if end.endpoint.IsDangling() || !c.bindable(id) {
+			continue
+		}
+		*end.binding = &binding{ElementID: id, Gap: bindingGap}
+		target := c.elements[id]
+		target.BoundElements = append(target.BoundElements, boundElement{ID: e.ID, Type: "arrow"})
+	}
+	c.add(&v.element, v)
+
+	if e.Label != "" {
+		p := conn.LabelPoint()
+		t := c.newText(e.ID+":text", e.Label, e.Element.Node, scene.Rect{Min: p, Max: p}, 0, nil)
+		width := 0
+		for _, line := range strings.Split(e.Label, "\n") {
+			width = max(width, len([]rune(line)))
+		}
+		// Labels are sized after their longest line, as Excalidraw does not
+		// wrap them.
+		t.Width = round(float64(width) * t.FontSize * 0.55)
+		t.X = round(p.X - t.Width/2)
+		t.ContainerID = &v.ID
+		v.BoundElements = append(v.BoundElements, boundElement{ID: t.ID, Type: "text"})
+		c.add(&t.element, t)
+	}
+}
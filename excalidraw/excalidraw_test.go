@@ -0,0 +1,91 @@
+package excalidraw
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"testing"
+)
+
+// decoded is the part of an Excalidraw scene the tests look at.
+type decoded struct {
+	Elements []struct {
+		ID          string       `json:"id"`
+		Type        string       `json:"type"`
+		Angle       float64      `json:"angle"`
+		FrameID     *string      `json:"frameId"`
+		ContainerID *string      `json:"containerId"`
+		Points      [][2]float64 `json:"points"`
+		StartBind   *binding     `json:"startBinding"`
+		EndBind     *binding     `json:"endBinding"`
+	} `json:"elements"`
+}
+
+func render(t *testing.T, s *scene.Scene) ([]byte, decoded) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var res decoded
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), res
+}
+
+func TestRender(t *testing.T) {
+	data, out := render(t, scenetest.Scene(t))
+	scenetest.Golden(t, "board.excalidraw", data)
+
+	index := map[string]int{}
+	for i, e := range out.Elements {
+		index[e.ID] = i
+	}
+	frame, ok := index["1:10"]
+	if !ok || out.Elements[frame].Type != "frame" {
+		t.Fatalf("expected the section to be a frame")
+	}
+	for _, id := range []string{"1:11", "1:12"} {
+		e := out.Elements[index[id]]
+		if index[id] < frame {
+			t.Errorf("expected %s to follow its frame", id)
+		}
+		if e.FrameID == nil || *e.FrameID != "1:10" {
+			t.Errorf("expected %s to be placed in the frame, got %v", id, e.FrameID)
+		}
+	}
+	arrow := out.Elements[index["1:30"]]
+	if arrow.StartBind == nil || arrow.StartBind.ElementID != "1:20" || arrow.EndBind == nil || arrow.EndBind.ElementID != "1:11" {
+		t.Errorf("expected the arrow to be bound to the diamond and the sticky, got %+v", arrow)
+	}
+	if label := out.Elements[index["1:30:text"]]; label.ContainerID == nil || *label.ContainerID != "1:30" {
+		t.Errorf("expected the arrow label to be bound to it")
+	}
+}
+
+func TestFlippedOutline(t *testing.T) {
+	page := &fig.NodeChange{Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{{
+		Guid: &fig.GUID{SessionId: 1, LocalId: 1}, Type: fig.NodeTypeShapeWithText, Visible: true, Opacity: 1,
+		Transform: &fig.Matrix{M00: 1, M11: -1, M12: 100}, Size: &fig.Vector{X: 100, Y: 100},
+		ShapeWithTextType: fig.ShapeWithTextTypeTriangleUp,
+	}}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, out := render(t, s)
+	if len(out.Elements) != 1 || out.Elements[0].Type != "line" {
+		t.Fatalf("expected the triangle to be drawn as a line, got %+v", out.Elements)
+	}
+	e := out.Elements[0]
+	if e.Angle != 0 {
+		t.Errorf("expected a vertical flip to need no rotation, got %v", e.Angle)
+	}
+	// The apex of the triangle points down once mirrored.
+	if p := e.Points[0]; p != [2]float64{50, 100} {
+		t.Errorf("expected the outline to start at the mirrored apex, got %v", e.Points)
+	}
+}
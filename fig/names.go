@@ -0,0 +1,1870 @@
+// Code generated by script/enumnames. DO NOT EDIT.
+
+package fig
+
+var messageTypeNames = map[MessageType]string{
+	MessageTypeJoinStart:                   "MessageTypeJoinStart",
+	MessageTypeNodeChanges:                 "MessageTypeNodeChanges",
+	MessageTypeUserChanges:                 "MessageTypeUserChanges",
+	MessageTypeJoinEnd:                     "MessageTypeJoinEnd",
+	MessageTypeSignal:                      "MessageTypeSignal",
+	MessageTypeStyle:                       "MessageTypeStyle",
+	MessageTypeStyleSet:                    "MessageTypeStyleSet",
+	MessageTypeJoinStartSkipReload:         "MessageTypeJoinStartSkipReload",
+	MessageTypeNotifyShouldUpgrade:         "MessageTypeNotifyShouldUpgrade",
+	MessageTypeUpgradeDone:                 "MessageTypeUpgradeDone",
+	MessageTypeUpgradeRefresh:              "MessageTypeUpgradeRefresh",
+	MessageTypeSceneGraphQuery:             "MessageTypeSceneGraphQuery",
+	MessageTypeSceneGraphReply:             "MessageTypeSceneGraphReply",
+	MessageTypeDiff:                        "MessageTypeDiff",
+	MessageTypeClientBroadcast:             "MessageTypeClientBroadcast",
+	MessageTypeJoinStartJournaled:          "MessageTypeJoinStartJournaled",
+	MessageTypeStreamStart:                 "MessageTypeStreamStart",
+	MessageTypeStreamEnd:                   "MessageTypeStreamEnd",
+	MessageTypeInteractiveSlideChange:      "MessageTypeInteractiveSlideChange",
+	MessageTypeReconnectSceneGraphQuery:    "MessageTypeReconnectSceneGraphQuery",
+	MessageTypeReconnectSceneGraphReply:    "MessageTypeReconnectSceneGraphReply",
+	MessageTypeJoinEndIncrementalReconnect: "MessageTypeJoinEndIncrementalReconnect",
+	MessageTypeNodeStatusChange:            "MessageTypeNodeStatusChange",
+}
+
+var axisNames = map[Axis]string{
+	AxisX: "AxisX",
+	AxisY: "AxisY",
+}
+
+var accessNames = map[Access]string{
+	AccessReadOnly:  "AccessReadOnly",
+	AccessReadWrite: "AccessReadWrite",
+}
+
+var nodePhaseNames = map[NodePhase]string{
+	NodePhaseCreated: "NodePhaseCreated",
+	NodePhaseRemoved: "NodePhaseRemoved",
+}
+
+var windingRuleNames = map[WindingRule]string{
+	WindingRuleNonzero: "WindingRuleNonzero",
+	WindingRuleOdd:     "WindingRuleOdd",
+}
+
+var nodeTypeNames = map[NodeType]string{
+	NodeTypeNone:                    "NodeTypeNone",
+	NodeTypeDocument:                "NodeTypeDocument",
+	NodeTypeCanvas:                  "NodeTypeCanvas",
+	NodeTypeGroup:                   "NodeTypeGroup",
+	NodeTypeFrame:                   "NodeTypeFrame",
+	NodeTypeBooleanOperation:        "NodeTypeBooleanOperation",
+	NodeTypeVector:                  "NodeTypeVector",
+	NodeTypeStar:                    "NodeTypeStar",
+	NodeTypeLine:                    "NodeTypeLine",
+	NodeTypeEllipse:                 "NodeTypeEllipse",
+	NodeTypeRectangle:               "NodeTypeRectangle",
+	NodeTypeRegularPolygon:          "NodeTypeRegularPolygon",
+	NodeTypeRoundedRectangle:        "NodeTypeRoundedRectangle",
+	NodeTypeText:                    "NodeTypeText",
+	NodeTypeSlice:                   "NodeTypeSlice",
+	NodeTypeSymbol:                  "NodeTypeSymbol",
+	NodeTypeInstance:                "NodeTypeInstance",
+	NodeTypeSticky:                  "NodeTypeSticky",
+	NodeTypeShapeWithText:           "NodeTypeShapeWithText",
+	NodeTypeConnector:               "NodeTypeConnector",
+	NodeTypeCodeBlock:               "NodeTypeCodeBlock",
+	NodeTypeWidget:                  "NodeTypeWidget",
+	NodeTypeStamp:                   "NodeTypeStamp",
+	NodeTypeMedia:                   "NodeTypeMedia",
+	NodeTypeHighlight:               "NodeTypeHighlight",
+	NodeTypeSection:                 "NodeTypeSection",
+	NodeTypeSectionOverlay:          "NodeTypeSectionOverlay",
+	NodeTypeWashiTape:               "NodeTypeWashiTape",
+	NodeTypeVariable:                "NodeTypeVariable",
+	NodeTypeTable:                   "NodeTypeTable",
+	NodeTypeTableCell:               "NodeTypeTableCell",
+	NodeTypeVariableSet:             "NodeTypeVariableSet",
+	NodeTypeSlide:                   "NodeTypeSlide",
+	NodeTypeAssistedLayout:          "NodeTypeAssistedLayout",
+	NodeTypeInteractiveSlideElement: "NodeTypeInteractiveSlideElement",
+	NodeTypeVariableOverride:        "NodeTypeVariableOverride",
+	NodeTypeModule:                  "NodeTypeModule",
+	NodeTypeSlideGrid:               "NodeTypeSlideGrid",
+	NodeTypeSlideRow:                "NodeTypeSlideRow",
+	NodeTypeResponsiveSet:           "NodeTypeResponsiveSet",
+}
+
+var shapeWithTextTypeNames = map[ShapeWithTextType]string{
+	ShapeWithTextTypeSquare:             "ShapeWithTextTypeSquare",
+	ShapeWithTextTypeEllipse:            "ShapeWithTextTypeEllipse",
+	ShapeWithTextTypeDiamond:            "ShapeWithTextTypeDiamond",
+	ShapeWithTextTypeTriangleUp:         "ShapeWithTextTypeTriangleUp",
+	ShapeWithTextTypeTriangleDown:       "ShapeWithTextTypeTriangleDown",
+	ShapeWithTextTypeRoundedRectangle:   "ShapeWithTextTypeRoundedRectangle",
+	ShapeWithTextTypeParallelogramRight: "ShapeWithTextTypeParallelogramRight",
+	ShapeWithTextTypeParallelogramLeft:  "ShapeWithTextTypeParallelogramLeft",
+	ShapeWithTextTypeEngDatabase:        "ShapeWithTextTypeEngDatabase",
+	ShapeWithTextTypeEngQueue:           "ShapeWithTextTypeEngQueue",
+	ShapeWithTextTypeEngFile:            "ShapeWithTextTypeEngFile",
+	ShapeWithTextTypeEngFolder:          "ShapeWithTextTypeEngFolder",
+	ShapeWithTextTypeTrapezoid:          "ShapeWithTextTypeTrapezoid",
+	ShapeWithTextTypePredefinedProcess:  "ShapeWithTextTypePredefinedProcess",
+	ShapeWithTextTypeShield:             "ShapeWithTextTypeShield",
+	ShapeWithTextTypeDocumentSingle:     "ShapeWithTextTypeDocumentSingle",
+	ShapeWithTextTypeDocumentMultiple:   "ShapeWithTextTypeDocumentMultiple",
+	ShapeWithTextTypeManualInput:        "ShapeWithTextTypeManualInput",
+	ShapeWithTextTypeHexagon:            "ShapeWithTextTypeHexagon",
+	ShapeWithTextTypeChevron:            "ShapeWithTextTypeChevron",
+	ShapeWithTextTypePentagon:           "ShapeWithTextTypePentagon",
+	ShapeWithTextTypeOctagon:            "ShapeWithTextTypeOctagon",
+	ShapeWithTextTypeStar:               "ShapeWithTextTypeStar",
+	ShapeWithTextTypePlus:               "ShapeWithTextTypePlus",
+	ShapeWithTextTypeArrowLeft:          "ShapeWithTextTypeArrowLeft",
+	ShapeWithTextTypeArrowRight:         "ShapeWithTextTypeArrowRight",
+	ShapeWithTextTypeSummingJunction:    "ShapeWithTextTypeSummingJunction",
+	ShapeWithTextTypeOr:                 "ShapeWithTextTypeOr",
+	ShapeWithTextTypeSpeechBubble:       "ShapeWithTextTypeSpeechBubble",
+	ShapeWithTextTypeInternalStorage:    "ShapeWithTextTypeInternalStorage",
+}
+
+var blendModeNames = map[BlendMode]string{
+	BlendModePassThrough: "BlendModePassThrough",
+	BlendModeNormal:      "BlendModeNormal",
+	BlendModeDarken:      "BlendModeDarken",
+	BlendModeMultiply:    "BlendModeMultiply",
+	BlendModeLinearBurn:  "BlendModeLinearBurn",
+	BlendModeColorBurn:   "BlendModeColorBurn",
+	BlendModeLighten:     "BlendModeLighten",
+	BlendModeScreen:      "BlendModeScreen",
+	BlendModeLinearDodge: "BlendModeLinearDodge",
+	BlendModeColorDodge:  "BlendModeColorDodge",
+	BlendModeOverlay:     "BlendModeOverlay",
+	BlendModeSoftLight:   "BlendModeSoftLight",
+	BlendModeHardLight:   "BlendModeHardLight",
+	BlendModeDifference:  "BlendModeDifference",
+	BlendModeExclusion:   "BlendModeExclusion",
+	BlendModeHue:         "BlendModeHue",
+	BlendModeSaturation:  "BlendModeSaturation",
+	BlendModeColor:       "BlendModeColor",
+	BlendModeLuminosity:  "BlendModeLuminosity",
+}
+
+var paintTypeNames = map[PaintType]string{
+	PaintTypeSolid:           "PaintTypeSolid",
+	PaintTypeGradientLinear:  "PaintTypeGradientLinear",
+	PaintTypeGradientRadial:  "PaintTypeGradientRadial",
+	PaintTypeGradientAngular: "PaintTypeGradientAngular",
+	PaintTypeGradientDiamond: "PaintTypeGradientDiamond",
+	PaintTypeImage:           "PaintTypeImage",
+	PaintTypeEmoji:           "PaintTypeEmoji",
+	PaintTypeVideo:           "PaintTypeVideo",
+}
+
+var imageScaleModeNames = map[ImageScaleMode]string{
+	ImageScaleModeStretch: "ImageScaleModeStretch",
+	ImageScaleModeFit:     "ImageScaleModeFit",
+	ImageScaleModeFill:    "ImageScaleModeFill",
+	ImageScaleModeTile:    "ImageScaleModeTile",
+}
+
+var effectTypeNames = map[EffectType]string{
+	EffectTypeInnerShadow:    "EffectTypeInnerShadow",
+	EffectTypeDropShadow:     "EffectTypeDropShadow",
+	EffectTypeForegroundBlur: "EffectTypeForegroundBlur",
+	EffectTypeBackgroundBlur: "EffectTypeBackgroundBlur",
+}
+
+var textCaseNames = map[TextCase]string{
+	TextCaseOriginal:        "TextCaseOriginal",
+	TextCaseUpper:           "TextCaseUpper",
+	TextCaseLower:           "TextCaseLower",
+	TextCaseTitle:           "TextCaseTitle",
+	TextCaseSmallCaps:       "TextCaseSmallCaps",
+	TextCaseSmallCapsForced: "TextCaseSmallCapsForced",
+}
+
+var textDecorationNames = map[TextDecoration]string{
+	TextDecorationNone:          "TextDecorationNone",
+	TextDecorationUnderline:     "TextDecorationUnderline",
+	TextDecorationStrikethrough: "TextDecorationStrikethrough",
+}
+
+var leadingTrimNames = map[LeadingTrim]string{
+	LeadingTrimNone:      "LeadingTrimNone",
+	LeadingTrimCapHeight: "LeadingTrimCapHeight",
+}
+
+var numberUnitsNames = map[NumberUnits]string{
+	NumberUnitsRaw:     "NumberUnitsRaw",
+	NumberUnitsPixels:  "NumberUnitsPixels",
+	NumberUnitsPercent: "NumberUnitsPercent",
+}
+
+var constraintTypeNames = map[ConstraintType]string{
+	ConstraintTypeMin:      "ConstraintTypeMin",
+	ConstraintTypeCenter:   "ConstraintTypeCenter",
+	ConstraintTypeMax:      "ConstraintTypeMax",
+	ConstraintTypeStretch:  "ConstraintTypeStretch",
+	ConstraintTypeScale:    "ConstraintTypeScale",
+	ConstraintTypeFixedMin: "ConstraintTypeFixedMin",
+	ConstraintTypeFixedMax: "ConstraintTypeFixedMax",
+}
+
+var strokeAlignNames = map[StrokeAlign]string{
+	StrokeAlignCenter:  "StrokeAlignCenter",
+	StrokeAlignInside:  "StrokeAlignInside",
+	StrokeAlignOutside: "StrokeAlignOutside",
+}
+
+var strokeCapNames = map[StrokeCap]string{
+	StrokeCapNone:             "StrokeCapNone",
+	StrokeCapRound:            "StrokeCapRound",
+	StrokeCapSquare:           "StrokeCapSquare",
+	StrokeCapArrowLines:       "StrokeCapArrowLines",
+	StrokeCapArrowEquilateral: "StrokeCapArrowEquilateral",
+	StrokeCapDiamondFilled:    "StrokeCapDiamondFilled",
+	StrokeCapTriangleFilled:   "StrokeCapTriangleFilled",
+	StrokeCapHighlight:        "StrokeCapHighlight",
+	StrokeCapWashiTape1:       "StrokeCapWashiTape1",
+	StrokeCapWashiTape2:       "StrokeCapWashiTape2",
+	StrokeCapWashiTape3:       "StrokeCapWashiTape3",
+	StrokeCapWashiTape4:       "StrokeCapWashiTape4",
+	StrokeCapWashiTape5:       "StrokeCapWashiTape5",
+	StrokeCapWashiTape6:       "StrokeCapWashiTape6",
+	StrokeCapCircleFilled:     "StrokeCapCircleFilled",
+}
+
+var strokeJoinNames = map[StrokeJoin]string{
+	StrokeJoinMiter: "StrokeJoinMiter",
+	StrokeJoinBevel: "StrokeJoinBevel",
+	StrokeJoinRound: "StrokeJoinRound",
+}
+
+var booleanOperationNames = map[BooleanOperation]string{
+	BooleanOperationUnion:     "BooleanOperationUnion",
+	BooleanOperationIntersect: "BooleanOperationIntersect",
+	BooleanOperationSubtract:  "BooleanOperationSubtract",
+	BooleanOperationXor:       "BooleanOperationXor",
+}
+
+var textAlignHorizontalNames = map[TextAlignHorizontal]string{
+	TextAlignHorizontalLeft:      "TextAlignHorizontalLeft",
+	TextAlignHorizontalCenter:    "TextAlignHorizontalCenter",
+	TextAlignHorizontalRight:     "TextAlignHorizontalRight",
+	TextAlignHorizontalJustified: "TextAlignHorizontalJustified",
+}
+
+var textAlignVerticalNames = map[TextAlignVertical]string{
+	TextAlignVerticalTop:    "TextAlignVerticalTop",
+	TextAlignVerticalCenter: "TextAlignVerticalCenter",
+	TextAlignVerticalBottom: "TextAlignVerticalBottom",
+}
+
+var mouseCursorNames = map[MouseCursor]string{
+	MouseCursorDefault:     "MouseCursorDefault",
+	MouseCursorCrosshair:   "MouseCursorCrosshair",
+	MouseCursorEyedropper:  "MouseCursorEyedropper",
+	MouseCursorHand:        "MouseCursorHand",
+	MouseCursorPaintBucket: "MouseCursorPaintBucket",
+	MouseCursorPen:         "MouseCursorPen",
+	MouseCursorPencil:      "MouseCursorPencil",
+	MouseCursorMarker:      "MouseCursorMarker",
+	MouseCursorEraser:      "MouseCursorEraser",
+	MouseCursorHighlighter: "MouseCursorHighlighter",
+	MouseCursorLasso:       "MouseCursorLasso",
+}
+
+var vectorMirrorNames = map[VectorMirror]string{
+	VectorMirrorNone:           "VectorMirrorNone",
+	VectorMirrorAngle:          "VectorMirrorAngle",
+	VectorMirrorAngleAndLength: "VectorMirrorAngleAndLength",
+}
+
+var dashModeNames = map[DashMode]string{
+	DashModeClip:    "DashModeClip",
+	DashModeStretch: "DashModeStretch",
+}
+
+var imageTypeNames = map[ImageType]string{
+	ImageTypePng:  "ImageTypePng",
+	ImageTypeJpeg: "ImageTypeJpeg",
+	ImageTypeSvg:  "ImageTypeSvg",
+	ImageTypePdf:  "ImageTypePdf",
+}
+
+var exportConstraintTypeNames = map[ExportConstraintType]string{
+	ExportConstraintTypeContentScale:  "ExportConstraintTypeContentScale",
+	ExportConstraintTypeContentWidth:  "ExportConstraintTypeContentWidth",
+	ExportConstraintTypeContentHeight: "ExportConstraintTypeContentHeight",
+}
+
+var layoutGridTypeNames = map[LayoutGridType]string{
+	LayoutGridTypeMin:     "LayoutGridTypeMin",
+	LayoutGridTypeCenter:  "LayoutGridTypeCenter",
+	LayoutGridTypeStretch: "LayoutGridTypeStretch",
+	LayoutGridTypeMax:     "LayoutGridTypeMax",
+}
+
+var layoutGridPatternNames = map[LayoutGridPattern]string{
+	LayoutGridPatternStripes: "LayoutGridPatternStripes",
+	LayoutGridPatternGrid:    "LayoutGridPatternGrid",
+}
+
+var textAutoResizeNames = map[TextAutoResize]string{
+	TextAutoResizeNone:           "TextAutoResizeNone",
+	TextAutoResizeWidthAndHeight: "TextAutoResizeWidthAndHeight",
+	TextAutoResizeHeight:         "TextAutoResizeHeight",
+}
+
+var textTruncationNames = map[TextTruncation]string{
+	TextTruncationDisabled: "TextTruncationDisabled",
+	TextTruncationEnding:   "TextTruncationEnding",
+}
+
+var styleSetTypeNames = map[StyleSetType]string{
+	StyleSetTypePersonal:  "StyleSetTypePersonal",
+	StyleSetTypeTeam:      "StyleSetTypeTeam",
+	StyleSetTypeCustom:    "StyleSetTypeCustom",
+	StyleSetTypeFrequency: "StyleSetTypeFrequency",
+	StyleSetTypeTemporary: "StyleSetTypeTemporary",
+}
+
+var styleSetContentTypeNames = map[StyleSetContentType]string{
+	StyleSetContentTypeSolid:    "StyleSetContentTypeSolid",
+	StyleSetContentTypeGradient: "StyleSetContentTypeGradient",
+	StyleSetContentTypeImage:    "StyleSetContentTypeImage",
+}
+
+var stackModeNames = map[StackMode]string{
+	StackModeNone:       "StackModeNone",
+	StackModeHorizontal: "StackModeHorizontal",
+	StackModeVertical:   "StackModeVertical",
+}
+
+var stackAlignNames = map[StackAlign]string{
+	StackAlignMin:      "StackAlignMin",
+	StackAlignCenter:   "StackAlignCenter",
+	StackAlignMax:      "StackAlignMax",
+	StackAlignBaseline: "StackAlignBaseline",
+}
+
+var stackCounterAlignNames = map[StackCounterAlign]string{
+	StackCounterAlignMin:      "StackCounterAlignMin",
+	StackCounterAlignCenter:   "StackCounterAlignCenter",
+	StackCounterAlignMax:      "StackCounterAlignMax",
+	StackCounterAlignStretch:  "StackCounterAlignStretch",
+	StackCounterAlignAuto:     "StackCounterAlignAuto",
+	StackCounterAlignBaseline: "StackCounterAlignBaseline",
+}
+
+var stackJustifyNames = map[StackJustify]string{
+	StackJustifyMin:          "StackJustifyMin",
+	StackJustifyCenter:       "StackJustifyCenter",
+	StackJustifyMax:          "StackJustifyMax",
+	StackJustifySpaceEvenly:  "StackJustifySpaceEvenly",
+	StackJustifySpaceBetween: "StackJustifySpaceBetween",
+}
+
+var stackSizeNames = map[StackSize]string{
+	StackSizeFixed:                       "StackSizeFixed",
+	StackSizeResizeToFit:                 "StackSizeResizeToFit",
+	StackSizeResizeToFitWithImplicitSize: "StackSizeResizeToFitWithImplicitSize",
+}
+
+var stackPositioningNames = map[StackPositioning]string{
+	StackPositioningAuto:     "StackPositioningAuto",
+	StackPositioningAbsolute: "StackPositioningAbsolute",
+}
+
+var stackWrapNames = map[StackWrap]string{
+	StackWrapNoWrap: "StackWrapNoWrap",
+	StackWrapWrap:   "StackWrapWrap",
+}
+
+var stackCounterAlignContentNames = map[StackCounterAlignContent]string{
+	StackCounterAlignContentAuto:         "StackCounterAlignContentAuto",
+	StackCounterAlignContentSpaceBetween: "StackCounterAlignContentSpaceBetween",
+}
+
+var connectionTypeNames = map[ConnectionType]string{
+	ConnectionTypeNone:               "ConnectionTypeNone",
+	ConnectionTypeInternalNode:       "ConnectionTypeInternalNode",
+	ConnectionTypeUrl:                "ConnectionTypeUrl",
+	ConnectionTypeBack:               "ConnectionTypeBack",
+	ConnectionTypeClose:              "ConnectionTypeClose",
+	ConnectionTypeSetVariable:        "ConnectionTypeSetVariable",
+	ConnectionTypeUpdateMediaRuntime: "ConnectionTypeUpdateMediaRuntime",
+	ConnectionTypeConditional:        "ConnectionTypeConditional",
+	ConnectionTypeSetVariableMode:    "ConnectionTypeSetVariableMode",
+}
+
+var interactionTypeNames = map[InteractionType]string{
+	InteractionTypeOnClick:      "InteractionTypeOnClick",
+	InteractionTypeAfterTimeout: "InteractionTypeAfterTimeout",
+	InteractionTypeMouseIn:      "InteractionTypeMouseIn",
+	InteractionTypeMouseOut:     "InteractionTypeMouseOut",
+	InteractionTypeOnHover:      "InteractionTypeOnHover",
+	InteractionTypeMouseDown:    "InteractionTypeMouseDown",
+	InteractionTypeMouseUp:      "InteractionTypeMouseUp",
+	InteractionTypeOnPress:      "InteractionTypeOnPress",
+	InteractionTypeNone:         "InteractionTypeNone",
+	InteractionTypeDrag:         "InteractionTypeDrag",
+	InteractionTypeOnKeyDown:    "InteractionTypeOnKeyDown",
+	InteractionTypeOnVoice:      "InteractionTypeOnVoice",
+	InteractionTypeOnMediaHit:   "InteractionTypeOnMediaHit",
+	InteractionTypeOnMediaEnd:   "InteractionTypeOnMediaEnd",
+	InteractionTypeMouseEnter:   "InteractionTypeMouseEnter",
+	InteractionTypeMouseLeave:   "InteractionTypeMouseLeave",
+}
+
+var transitionTypeNames = map[TransitionType]string{
+	TransitionTypeInstantTransition: "TransitionTypeInstantTransition",
+	TransitionTypeDissolve:          "TransitionTypeDissolve",
+	TransitionTypeFade:              "TransitionTypeFade",
+	TransitionTypeSlideFromLeft:     "TransitionTypeSlideFromLeft",
+	TransitionTypeSlideFromRight:    "TransitionTypeSlideFromRight",
+	TransitionTypeSlideFromTop:      "TransitionTypeSlideFromTop",
+	TransitionTypeSlideFromBottom:   "TransitionTypeSlideFromBottom",
+	TransitionTypePushFromLeft:      "TransitionTypePushFromLeft",
+	TransitionTypePushFromRight:     "TransitionTypePushFromRight",
+	TransitionTypePushFromTop:       "TransitionTypePushFromTop",
+	TransitionTypePushFromBottom:    "TransitionTypePushFromBottom",
+	TransitionTypeMoveFromLeft:      "TransitionTypeMoveFromLeft",
+	TransitionTypeMoveFromRight:     "TransitionTypeMoveFromRight",
+	TransitionTypeMoveFromTop:       "TransitionTypeMoveFromTop",
+	TransitionTypeMoveFromBottom:    "TransitionTypeMoveFromBottom",
+	TransitionTypeSlideOutToLeft:    "TransitionTypeSlideOutToLeft",
+	TransitionTypeSlideOutToRight:   "TransitionTypeSlideOutToRight",
+	TransitionTypeSlideOutToTop:     "TransitionTypeSlideOutToTop",
+	TransitionTypeSlideOutToBottom:  "TransitionTypeSlideOutToBottom",
+	TransitionTypeMoveOutToLeft:     "TransitionTypeMoveOutToLeft",
+	TransitionTypeMoveOutToRight:    "TransitionTypeMoveOutToRight",
+	TransitionTypeMoveOutToTop:      "TransitionTypeMoveOutToTop",
+	TransitionTypeMoveOutToBottom:   "TransitionTypeMoveOutToBottom",
+	TransitionTypeMagicMove:         "TransitionTypeMagicMove",
+	TransitionTypeSmartAnimate:      "TransitionTypeSmartAnimate",
+	TransitionTypeScrollAnimate:     "TransitionTypeScrollAnimate",
+}
+
+var easingTypeNames = map[EasingType]string{
+	EasingTypeInCubic:           "EasingTypeInCubic",
+	EasingTypeOutCubic:          "EasingTypeOutCubic",
+	EasingTypeInoutCubic:        "EasingTypeInoutCubic",
+	EasingTypeLinear:            "EasingTypeLinear",
+	EasingTypeInBackCubic:       "EasingTypeInBackCubic",
+	EasingTypeOutBackCubic:      "EasingTypeOutBackCubic",
+	EasingTypeInoutBackCubic:    "EasingTypeInoutBackCubic",
+	EasingTypeCustomCubic:       "EasingTypeCustomCubic",
+	EasingTypeSpring:            "EasingTypeSpring",
+	EasingTypeGentleSpring:      "EasingTypeGentleSpring",
+	EasingTypeCustomSpring:      "EasingTypeCustomSpring",
+	EasingTypeSpringPresetOne:   "EasingTypeSpringPresetOne",
+	EasingTypeSpringPresetTwo:   "EasingTypeSpringPresetTwo",
+	EasingTypeSpringPresetThree: "EasingTypeSpringPresetThree",
+}
+
+var scrollDirectionNames = map[ScrollDirection]string{
+	ScrollDirectionNone:       "ScrollDirectionNone",
+	ScrollDirectionHorizontal: "ScrollDirectionHorizontal",
+	ScrollDirectionVertical:   "ScrollDirectionVertical",
+	ScrollDirectionBoth:       "ScrollDirectionBoth",
+}
+
+var scrollContractedStateNames = map[ScrollContractedState]string{
+	ScrollContractedStateExpanded:   "ScrollContractedStateExpanded",
+	ScrollContractedStateContracted: "ScrollContractedStateContracted",
+}
+
+var fontVariantNumericFigureNames = map[FontVariantNumericFigure]string{
+	FontVariantNumericFigureNormal:   "FontVariantNumericFigureNormal",
+	FontVariantNumericFigureLining:   "FontVariantNumericFigureLining",
+	FontVariantNumericFigureOldstyle: "FontVariantNumericFigureOldstyle",
+}
+
+var fontVariantNumericSpacingNames = map[FontVariantNumericSpacing]string{
+	FontVariantNumericSpacingNormal:       "FontVariantNumericSpacingNormal",
+	FontVariantNumericSpacingProportional: "FontVariantNumericSpacingProportional",
+	FontVariantNumericSpacingTabular:      "FontVariantNumericSpacingTabular",
+}
+
+var fontVariantNumericFractionNames = map[FontVariantNumericFraction]string{
+	FontVariantNumericFractionNormal:   "FontVariantNumericFractionNormal",
+	FontVariantNumericFractionDiagonal: "FontVariantNumericFractionDiagonal",
+	FontVariantNumericFractionStacked:  "FontVariantNumericFractionStacked",
+}
+
+var fontVariantCapsNames = map[FontVariantCaps]string{
+	FontVariantCapsNormal:    "FontVariantCapsNormal",
+	FontVariantCapsSmall:     "FontVariantCapsSmall",
+	FontVariantCapsAllSmall:  "FontVariantCapsAllSmall",
+	FontVariantCapsPetite:    "FontVariantCapsPetite",
+	FontVariantCapsAllPetite: "FontVariantCapsAllPetite",
+	FontVariantCapsUnicase:   "FontVariantCapsUnicase",
+	FontVariantCapsTitling:   "FontVariantCapsTitling",
+}
+
+var fontVariantPositionNames = map[FontVariantPosition]string{
+	FontVariantPositionNormal: "FontVariantPositionNormal",
+	FontVariantPositionSub:    "FontVariantPositionSub",
+	FontVariantPositionSuper:  "FontVariantPositionSuper",
+}
+
+var fontStyleNames = map[FontStyle]string{
+	FontStyleNormal: "FontStyleNormal",
+	FontStyleItalic: "FontStyleItalic",
+}
+
+var semanticWeightNames = map[SemanticWeight]string{
+	SemanticWeightNormal: "SemanticWeightNormal",
+	SemanticWeightBold:   "SemanticWeightBold",
+}
+
+var semanticItalicNames = map[SemanticItalic]string{
+	SemanticItalicNormal: "SemanticItalicNormal",
+	SemanticItalicItalic: "SemanticItalicItalic",
+}
+
+var openTypeFeatureNames = map[OpenTypeFeature]string{
+	OpenTypeFeaturePcap: "OpenTypeFeaturePcap",
+	OpenTypeFeatureC2pc: "OpenTypeFeatureC2pc",
+	OpenTypeFeatureCase: "OpenTypeFeatureCase",
+	OpenTypeFeatureCpsp: "OpenTypeFeatureCpsp",
+	OpenTypeFeatureTitl: "OpenTypeFeatureTitl",
+	OpenTypeFeatureUnic: "OpenTypeFeatureUnic",
+	OpenTypeFeatureZero: "OpenTypeFeatureZero",
+	OpenTypeFeatureSinf: "OpenTypeFeatureSinf",
+	OpenTypeFeatureOrdn: "OpenTypeFeatureOrdn",
+	OpenTypeFeatureAfrc: "OpenTypeFeatureAfrc",
+	OpenTypeFeatureDnom: "OpenTypeFeatureDnom",
+	OpenTypeFeatureNumr: "OpenTypeFeatureNumr",
+	OpenTypeFeatureLiga: "OpenTypeFeatureLiga",
+	OpenTypeFeatureClig: "OpenTypeFeatureClig",
+	OpenTypeFeatureDlig: "OpenTypeFeatureDlig",
+	OpenTypeFeatureHlig: "OpenTypeFeatureHlig",
+	OpenTypeFeatureRlig: "OpenTypeFeatureRlig",
+	OpenTypeFeatureAalt: "OpenTypeFeatureAalt",
+	OpenTypeFeatureCalt: "OpenTypeFeatureCalt",
+	OpenTypeFeatureRclt: "OpenTypeFeatureRclt",
+	OpenTypeFeatureSalt: "OpenTypeFeatureSalt",
+	OpenTypeFeatureRvrn: "OpenTypeFeatureRvrn",
+	OpenTypeFeatureVert: "OpenTypeFeatureVert",
+	OpenTypeFeatureSwsh: "OpenTypeFeatureSwsh",
+	OpenTypeFeatureCswh: "OpenTypeFeatureCswh",
+	OpenTypeFeatureNalt: "OpenTypeFeatureNalt",
+	OpenTypeFeatureCcmp: "OpenTypeFeatureCcmp",
+	OpenTypeFeatureStch: "OpenTypeFeatureStch",
+	OpenTypeFeatureHist: "OpenTypeFeatureHist",
+	OpenTypeFeatureSize: "OpenTypeFeatureSize",
+	OpenTypeFeatureOrnm: "OpenTypeFeatureOrnm",
+	OpenTypeFeatureItal: "OpenTypeFeatureItal",
+	OpenTypeFeatureRand: "OpenTypeFeatureRand",
+	OpenTypeFeatureDtls: "OpenTypeFeatureDtls",
+	OpenTypeFeatureFlac: "OpenTypeFeatureFlac",
+	OpenTypeFeatureMgrk: "OpenTypeFeatureMgrk",
+	OpenTypeFeatureSsty: "OpenTypeFeatureSsty",
+	OpenTypeFeatureKern: "OpenTypeFeatureKern",
+	OpenTypeFeatureFwid: "OpenTypeFeatureFwid",
+	OpenTypeFeatureHwid: "OpenTypeFeatureHwid",
+	OpenTypeFeatureHalt: "OpenTypeFeatureHalt",
+	OpenTypeFeatureTwid: "OpenTypeFeatureTwid",
+	OpenTypeFeatureQwid: "OpenTypeFeatureQwid",
+	OpenTypeFeaturePwid: "OpenTypeFeaturePwid",
+	OpenTypeFeatureJust: "OpenTypeFeatureJust",
+	OpenTypeFeatureLfbd: "OpenTypeFeatureLfbd",
+	OpenTypeFeatureOpbd: "OpenTypeFeatureOpbd",
+	OpenTypeFeatureRtbd: "OpenTypeFeatureRtbd",
+	OpenTypeFeaturePalt: "OpenTypeFeaturePalt",
+	OpenTypeFeaturePkna: "OpenTypeFeaturePkna",
+	OpenTypeFeatureLtra: "OpenTypeFeatureLtra",
+	OpenTypeFeatureLtrm: "OpenTypeFeatureLtrm",
+	OpenTypeFeatureRtla: "OpenTypeFeatureRtla",
+	OpenTypeFeatureRtlm: "OpenTypeFeatureRtlm",
+	OpenTypeFeatureAbrv: "OpenTypeFeatureAbrv",
+	OpenTypeFeatureAbvm: "OpenTypeFeatureAbvm",
+	OpenTypeFeatureAbvs: "OpenTypeFeatureAbvs",
+	OpenTypeFeatureValt: "OpenTypeFeatureValt",
+	OpenTypeFeatureVhal: "OpenTypeFeatureVhal",
+	OpenTypeFeatureBlwf: "OpenTypeFeatureBlwf",
+	OpenTypeFeatureBlwm: "OpenTypeFeatureBlwm",
+	OpenTypeFeatureBlws: "OpenTypeFeatureBlws",
+	OpenTypeFeatureAkhn: "OpenTypeFeatureAkhn",
+	OpenTypeFeatureCjct: "OpenTypeFeatureCjct",
+	OpenTypeFeatureCfar: "OpenTypeFeatureCfar",
+	OpenTypeFeatureCpct: "OpenTypeFeatureCpct",
+	OpenTypeFeatureCurs: "OpenTypeFeatureCurs",
+	OpenTypeFeatureDist: "OpenTypeFeatureDist",
+	OpenTypeFeatureExpt: "OpenTypeFeatureExpt",
+	OpenTypeFeatureFalt: "OpenTypeFeatureFalt",
+	OpenTypeFeatureFina: "OpenTypeFeatureFina",
+	OpenTypeFeatureFin2: "OpenTypeFeatureFin2",
+	OpenTypeFeatureFin3: "OpenTypeFeatureFin3",
+	OpenTypeFeatureHalf: "OpenTypeFeatureHalf",
+	OpenTypeFeatureHaln: "OpenTypeFeatureHaln",
+	OpenTypeFeatureHkna: "OpenTypeFeatureHkna",
+	OpenTypeFeatureHngl: "OpenTypeFeatureHngl",
+	OpenTypeFeatureHojo: "OpenTypeFeatureHojo",
+	OpenTypeFeatureInit: "OpenTypeFeatureInit",
+	OpenTypeFeatureIsol: "OpenTypeFeatureIsol",
+	OpenTypeFeatureJp78: "OpenTypeFeatureJp78",
+	OpenTypeFeatureJp83: "OpenTypeFeatureJp83",
+	OpenTypeFeatureJp90: "OpenTypeFeatureJp90",
+	OpenTypeFeatureJp04: "OpenTypeFeatureJp04",
+	OpenTypeFeatureLjmo: "OpenTypeFeatureLjmo",
+	OpenTypeFeatureLocl: "OpenTypeFeatureLocl",
+	OpenTypeFeatureMark: "OpenTypeFeatureMark",
+	OpenTypeFeatureMedi: "OpenTypeFeatureMedi",
+	OpenTypeFeatureMed2: "OpenTypeFeatureMed2",
+	OpenTypeFeatureMkmk: "OpenTypeFeatureMkmk",
+	OpenTypeFeatureNlck: "OpenTypeFeatureNlck",
+	OpenTypeFeatureNukt: "OpenTypeFeatureNukt",
+	OpenTypeFeaturePref: "OpenTypeFeaturePref",
+	OpenTypeFeaturePres: "OpenTypeFeaturePres",
+	OpenTypeFeatureVpal: "OpenTypeFeatureVpal",
+	OpenTypeFeaturePstf: "OpenTypeFeaturePstf",
+	OpenTypeFeaturePsts: "OpenTypeFeaturePsts",
+	OpenTypeFeatureRkrf: "OpenTypeFeatureRkrf",
+	OpenTypeFeatureRphf: "OpenTypeFeatureRphf",
+	OpenTypeFeatureRuby: "OpenTypeFeatureRuby",
+	OpenTypeFeatureSmpl: "OpenTypeFeatureSmpl",
+	OpenTypeFeatureTjmo: "OpenTypeFeatureTjmo",
+	OpenTypeFeatureTnam: "OpenTypeFeatureTnam",
+	OpenTypeFeatureTrad: "OpenTypeFeatureTrad",
+	OpenTypeFeatureVatu: "OpenTypeFeatureVatu",
+	OpenTypeFeatureVjmo: "OpenTypeFeatureVjmo",
+	OpenTypeFeatureVkna: "OpenTypeFeatureVkna",
+	OpenTypeFeatureVkrn: "OpenTypeFeatureVkrn",
+	OpenTypeFeatureVrtr: "OpenTypeFeatureVrtr",
+	OpenTypeFeatureVrt2: "OpenTypeFeatureVrt2",
+	OpenTypeFeatureSs01: "OpenTypeFeatureSs01",
+	OpenTypeFeatureSs02: "OpenTypeFeatureSs02",
+	OpenTypeFeatureSs03: "OpenTypeFeatureSs03",
+	OpenTypeFeatureSs04: "OpenTypeFeatureSs04",
+	OpenTypeFeatureSs05: "OpenTypeFeatureSs05",
+	OpenTypeFeatureSs06: "OpenTypeFeatureSs06",
+	OpenTypeFeatureSs07: "OpenTypeFeatureSs07",
+	OpenTypeFeatureSs08: "OpenTypeFeatureSs08",
+	OpenTypeFeatureSs09: "OpenTypeFeatureSs09",
+	OpenTypeFeatureSs10: "OpenTypeFeatureSs10",
+	OpenTypeFeatureSs11: "OpenTypeFeatureSs11",
+	OpenTypeFeatureSs12: "OpenTypeFeatureSs12",
+	OpenTypeFeatureSs13: "OpenTypeFeatureSs13",
+	OpenTypeFeatureSs14: "OpenTypeFeatureSs14",
+	OpenTypeFeatureSs15: "OpenTypeFeatureSs15",
+	OpenTypeFeatureSs16: "OpenTypeFeatureSs16",
+	OpenTypeFeatureSs17: "OpenTypeFeatureSs17",
+	OpenTypeFeatureSs18: "OpenTypeFeatureSs18",
+	OpenTypeFeatureSs19: "OpenTypeFeatureSs19",
+	OpenTypeFeatureSs20: "OpenTypeFeatureSs20",
+	OpenTypeFeatureCv01: "OpenTypeFeatureCv01",
+	OpenTypeFeatureCv02: "OpenTypeFeatureCv02",
+	OpenTypeFeatureCv03: "OpenTypeFeatureCv03",
+	OpenTypeFeatureCv04: "OpenTypeFeatureCv04",
+	OpenTypeFeatureCv05: "OpenTypeFeatureCv05",
+	OpenTypeFeatureCv06: "OpenTypeFeatureCv06",
+	OpenTypeFeatureCv07: "OpenTypeFeatureCv07",
+	OpenTypeFeatureCv08: "OpenTypeFeatureCv08",
+	OpenTypeFeatureCv09: "OpenTypeFeatureCv09",
+	OpenTypeFeatureCv10: "OpenTypeFeatureCv10",
+	OpenTypeFeatureCv11: "OpenTypeFeatureCv11",
+	OpenTypeFeatureCv12: "OpenTypeFeatureCv12",
+	OpenTypeFeatureCv13: "OpenTypeFeatureCv13",
+	OpenTypeFeatureCv14: "OpenTypeFeatureCv14",
+	OpenTypeFeatureCv15: "OpenTypeFeatureCv15",
+	OpenTypeFeatureCv16: "OpenTypeFeatureCv16",
+	OpenTypeFeatureCv17: "OpenTypeFeatureCv17",
+	OpenTypeFeatureCv18: "OpenTypeFeatureCv18",
+	OpenTypeFeatureCv19: "OpenTypeFeatureCv19",
+	OpenTypeFeatureCv20: "OpenTypeFeatureCv20",
+	OpenTypeFeatureCv21: "OpenTypeFeatureCv21",
+	OpenTypeFeatureCv22: "OpenTypeFeatureCv22",
+	OpenTypeFeatureCv23: "OpenTypeFeatureCv23",
+	OpenTypeFeatureCv24: "OpenTypeFeatureCv24",
+	OpenTypeFeatureCv25: "OpenTypeFeatureCv25",
+	OpenTypeFeatureCv26: "OpenTypeFeatureCv26",
+	OpenTypeFeatureCv27: "OpenTypeFeatureCv27",
+	OpenTypeFeatureCv28: "OpenTypeFeatureCv28",
+	OpenTypeFeatureCv29: "OpenTypeFeatureCv29",
+	OpenTypeFeatureCv30: "OpenTypeFeatureCv30",
+	OpenTypeFeatureCv31: "OpenTypeFeatureCv31",
+	OpenTypeFeatureCv32: "OpenTypeFeatureCv32",
+	OpenTypeFeatureCv33: "OpenTypeFeatureCv33",
+	OpenTypeFeatureCv34: "OpenTypeFeatureCv34",
+	OpenTypeFeatureCv35: "OpenTypeFeatureCv35",
+	OpenTypeFeatureCv36: "OpenTypeFeatureCv36",
+	OpenTypeFeatureCv37: "OpenTypeFeatureCv37",
+	OpenTypeFeatureCv38: "OpenTypeFeatureCv38",
+	OpenTypeFeatureCv39: "OpenTypeFeatureCv39",
+	OpenTypeFeatureCv40: "OpenTypeFeatureCv40",
+	OpenTypeFeatureCv41: "OpenTypeFeatureCv41",
+	OpenTypeFeatureCv42: "OpenTypeFeatureCv42",
+	OpenTypeFeatureCv43: "OpenTypeFeatureCv43",
+	OpenTypeFeatureCv44: "OpenTypeFeatureCv44",
+	OpenTypeFeatureCv45: "OpenTypeFeatureCv45",
+	OpenTypeFeatureCv46: "OpenTypeFeatureCv46",
+	OpenTypeFeatureCv47: "OpenTypeFeatureCv47",
+	OpenTypeFeatureCv48: "OpenTypeFeatureCv48",
+	OpenTypeFeatureCv49: "OpenTypeFeatureCv49",
+	OpenTypeFeatureCv50: "OpenTypeFeatureCv50",
+	OpenTypeFeatureCv51: "OpenTypeFeatureCv51",
+	OpenTypeFeatureCv52: "OpenTypeFeatureCv52",
+	OpenTypeFeatureCv53: "OpenTypeFeatureCv53",
+	OpenTypeFeatureCv54: "OpenTypeFeatureCv54",
+	OpenTypeFeatureCv55: "OpenTypeFeatureCv55",
+	OpenTypeFeatureCv56: "OpenTypeFeatureCv56",
+	OpenTypeFeatureCv57: "OpenTypeFeatureCv57",
+	OpenTypeFeatureCv58: "OpenTypeFeatureCv58",
+	OpenTypeFeatureCv59: "OpenTypeFeatureCv59",
+	OpenTypeFeatureCv60: "OpenTypeFeatureCv60",
+	OpenTypeFeatureCv61: "OpenTypeFeatureCv61",
+	OpenTypeFeatureCv62: "OpenTypeFeatureCv62",
+	OpenTypeFeatureCv63: "OpenTypeFeatureCv63",
+	OpenTypeFeatureCv64: "OpenTypeFeatureCv64",
+	OpenTypeFeatureCv65: "OpenTypeFeatureCv65",
+	OpenTypeFeatureCv66: "OpenTypeFeatureCv66",
+	OpenTypeFeatureCv67: "OpenTypeFeatureCv67",
+	OpenTypeFeatureCv68: "OpenTypeFeatureCv68",
+	OpenTypeFeatureCv69: "OpenTypeFeatureCv69",
+	OpenTypeFeatureCv70: "OpenTypeFeatureCv70",
+	OpenTypeFeatureCv71: "OpenTypeFeatureCv71",
+	OpenTypeFeatureCv72: "OpenTypeFeatureCv72",
+	OpenTypeFeatureCv73: "OpenTypeFeatureCv73",
+	OpenTypeFeatureCv74: "OpenTypeFeatureCv74",
+	OpenTypeFeatureCv75: "OpenTypeFeatureCv75",
+	OpenTypeFeatureCv76: "OpenTypeFeatureCv76",
+	OpenTypeFeatureCv77: "OpenTypeFeatureCv77",
+	OpenTypeFeatureCv78: "OpenTypeFeatureCv78",
+	OpenTypeFeatureCv79: "OpenTypeFeatureCv79",
+	OpenTypeFeatureCv80: "OpenTypeFeatureCv80",
+	OpenTypeFeatureCv81: "OpenTypeFeatureCv81",
+	OpenTypeFeatureCv82: "OpenTypeFeatureCv82",
+	OpenTypeFeatureCv83: "OpenTypeFeatureCv83",
+	OpenTypeFeatureCv84: "OpenTypeFeatureCv84",
+	OpenTypeFeatureCv85: "OpenTypeFeatureCv85",
+	OpenTypeFeatureCv86: "OpenTypeFeatureCv86",
+	OpenTypeFeatureCv87: "OpenTypeFeatureCv87",
+	OpenTypeFeatureCv88: "OpenTypeFeatureCv88",
+	OpenTypeFeatureCv89: "OpenTypeFeatureCv89",
+	OpenTypeFeatureCv90: "OpenTypeFeatureCv90",
+	OpenTypeFeatureCv91: "OpenTypeFeatureCv91",
+	OpenTypeFeatureCv92: "OpenTypeFeatureCv92",
+	OpenTypeFeatureCv93: "OpenTypeFeatureCv93",
+	OpenTypeFeatureCv94: "OpenTypeFeatureCv94",
+	OpenTypeFeatureCv95: "OpenTypeFeatureCv95",
+	OpenTypeFeatureCv96: "OpenTypeFeatureCv96",
+	OpenTypeFeatureCv97: "OpenTypeFeatureCv97",
+	OpenTypeFeatureCv98: "OpenTypeFeatureCv98",
+	OpenTypeFeatureCv99: "OpenTypeFeatureCv99",
+}
+
+var flappTypeNames = map[FlappType]string{
+	FlappTypePoll:      "FlappTypePoll",
+	FlappTypeEmbed:     "FlappTypeEmbed",
+	FlappTypeFacepile:  "FlappTypeFacepile",
+	FlappTypeAlignment: "FlappTypeAlignment",
+	FlappTypeYoutube:   "FlappTypeYoutube",
+}
+
+var prototypeDeviceTypeNames = map[PrototypeDeviceType]string{
+	PrototypeDeviceTypeNone:         "PrototypeDeviceTypeNone",
+	PrototypeDeviceTypePreset:       "PrototypeDeviceTypePreset",
+	PrototypeDeviceTypeCustom:       "PrototypeDeviceTypeCustom",
+	PrototypeDeviceTypePresentation: "PrototypeDeviceTypePresentation",
+}
+
+var deviceRotationNames = map[DeviceRotation]string{
+	DeviceRotationNone:  "DeviceRotationNone",
+	DeviceRotationCcw90: "DeviceRotationCcw90",
+}
+
+var overlayPositionTypeNames = map[OverlayPositionType]string{
+	OverlayPositionTypeCenter:       "OverlayPositionTypeCenter",
+	OverlayPositionTypeTopLeft:      "OverlayPositionTypeTopLeft",
+	OverlayPositionTypeTopCenter:    "OverlayPositionTypeTopCenter",
+	OverlayPositionTypeTopRight:     "OverlayPositionTypeTopRight",
+	OverlayPositionTypeBottomLeft:   "OverlayPositionTypeBottomLeft",
+	OverlayPositionTypeBottomCenter: "OverlayPositionTypeBottomCenter",
+	OverlayPositionTypeBottomRight:  "OverlayPositionTypeBottomRight",
+	OverlayPositionTypeManual:       "OverlayPositionTypeManual",
+}
+
+var overlayBackgroundInteractionNames = map[OverlayBackgroundInteraction]string{
+	OverlayBackgroundInteractionNone:                "OverlayBackgroundInteractionNone",
+	OverlayBackgroundInteractionCloseOnClickOutside: "OverlayBackgroundInteractionCloseOnClickOutside",
+}
+
+var overlayBackgroundTypeNames = map[OverlayBackgroundType]string{
+	OverlayBackgroundTypeNone:       "OverlayBackgroundTypeNone",
+	OverlayBackgroundTypeSolidColor: "OverlayBackgroundTypeSolidColor",
+}
+
+var navigationTypeNames = map[NavigationType]string{
+	NavigationTypeNavigate:  "NavigationTypeNavigate",
+	NavigationTypeOverlay:   "NavigationTypeOverlay",
+	NavigationTypeSwap:      "NavigationTypeSwap",
+	NavigationTypeSwapState: "NavigationTypeSwapState",
+	NavigationTypeScrollTo:  "NavigationTypeScrollTo",
+}
+
+var exportColorProfileNames = map[ExportColorProfile]string{
+	ExportColorProfileDocument:    "ExportColorProfileDocument",
+	ExportColorProfileSrgb:        "ExportColorProfileSrgb",
+	ExportColorProfileDisplayP3V4: "ExportColorProfileDisplayP3V4",
+}
+
+var exportSVGIDModeNames = map[ExportSVGIDMode]string{
+	ExportSVGIDModeIfNeeded: "ExportSVGIDModeIfNeeded",
+	ExportSVGIDModeAlways:   "ExportSVGIDModeAlways",
+}
+
+var styleTypeNames = map[StyleType]string{
+	StyleTypeNone:   "StyleTypeNone",
+	StyleTypeFill:   "StyleTypeFill",
+	StyleTypeStroke: "StyleTypeStroke",
+	StyleTypeText:   "StyleTypeText",
+	StyleTypeEffect: "StyleTypeEffect",
+	StyleTypeExport: "StyleTypeExport",
+	StyleTypeGrid:   "StyleTypeGrid",
+}
+
+var scrollBehaviorNames = map[ScrollBehavior]string{
+	ScrollBehaviorScrolls:                        "ScrollBehaviorScrolls",
+	ScrollBehaviorFixedWhenChildOfScrollingFrame: "ScrollBehaviorFixedWhenChildOfScrollingFrame",
+	ScrollBehaviorStickyScrolls:                  "ScrollBehaviorStickyScrolls",
+}
+
+var connectorMagnetNames = map[ConnectorMagnet]string{
+	ConnectorMagnetNone:           "ConnectorMagnetNone",
+	ConnectorMagnetAuto:           "ConnectorMagnetAuto",
+	ConnectorMagnetTop:            "ConnectorMagnetTop",
+	ConnectorMagnetLeft:           "ConnectorMagnetLeft",
+	ConnectorMagnetBottom:         "ConnectorMagnetBottom",
+	ConnectorMagnetRight:          "ConnectorMagnetRight",
+	ConnectorMagnetCenter:         "ConnectorMagnetCenter",
+	ConnectorMagnetAutoHorizontal: "ConnectorMagnetAutoHorizontal",
+}
+
+var connectorTextSectionNames = map[ConnectorTextSection]string{
+	ConnectorTextSectionMiddleToStart: "ConnectorTextSectionMiddleToStart",
+	ConnectorTextSectionMiddleToEnd:   "ConnectorTextSectionMiddleToEnd",
+}
+
+var connectorOffAxisOffsetNames = map[ConnectorOffAxisOffset]string{
+	ConnectorOffAxisOffsetNone:  "ConnectorOffAxisOffsetNone",
+	ConnectorOffAxisOffsetAbove: "ConnectorOffAxisOffsetAbove",
+	ConnectorOffAxisOffsetBelow: "ConnectorOffAxisOffsetBelow",
+}
+
+var connectorLineStyleNames = map[ConnectorLineStyle]string{
+	ConnectorLineStyleElbowed:  "ConnectorLineStyleElbowed",
+	ConnectorLineStyleStraight: "ConnectorLineStyleStraight",
+	ConnectorLineStyleCurved:   "ConnectorLineStyleCurved",
+}
+
+var connectorTypeNames = map[ConnectorType]string{
+	ConnectorTypeManual:  "ConnectorTypeManual",
+	ConnectorTypeDiagram: "ConnectorTypeDiagram",
+}
+
+var annotationPropertyTypeNames = map[AnnotationPropertyType]string{
+	AnnotationPropertyTypeFill:                "AnnotationPropertyTypeFill",
+	AnnotationPropertyTypeStroke:              "AnnotationPropertyTypeStroke",
+	AnnotationPropertyTypeWidth:               "AnnotationPropertyTypeWidth",
+	AnnotationPropertyTypeHeight:              "AnnotationPropertyTypeHeight",
+	AnnotationPropertyTypeMinWidth:            "AnnotationPropertyTypeMinWidth",
+	AnnotationPropertyTypeMinHeight:           "AnnotationPropertyTypeMinHeight",
+	AnnotationPropertyTypeMaxWidth:            "AnnotationPropertyTypeMaxWidth",
+	AnnotationPropertyTypeMaxHeight:           "AnnotationPropertyTypeMaxHeight",
+	AnnotationPropertyTypeStrokeWidth:         "AnnotationPropertyTypeStrokeWidth",
+	AnnotationPropertyTypeCornerRadius:        "AnnotationPropertyTypeCornerRadius",
+	AnnotationPropertyTypeEffect:              "AnnotationPropertyTypeEffect",
+	AnnotationPropertyTypeTextStyle:           "AnnotationPropertyTypeTextStyle",
+	AnnotationPropertyTypeTextAlignHorizontal: "AnnotationPropertyTypeTextAlignHorizontal",
+	AnnotationPropertyTypeFontFamily:          "AnnotationPropertyTypeFontFamily",
+	AnnotationPropertyTypeFontSize:            "AnnotationPropertyTypeFontSize",
+	AnnotationPropertyTypeFontWeight:          "AnnotationPropertyTypeFontWeight",
+	AnnotationPropertyTypeLineHeight:          "AnnotationPropertyTypeLineHeight",
+	AnnotationPropertyTypeLetterSpacing:       "AnnotationPropertyTypeLetterSpacing",
+	AnnotationPropertyTypeStackSpacing:        "AnnotationPropertyTypeStackSpacing",
+	AnnotationPropertyTypeStackPadding:        "AnnotationPropertyTypeStackPadding",
+	AnnotationPropertyTypeStackMode:           "AnnotationPropertyTypeStackMode",
+	AnnotationPropertyTypeStackAlignment:      "AnnotationPropertyTypeStackAlignment",
+	AnnotationPropertyTypeOpacity:             "AnnotationPropertyTypeOpacity",
+	AnnotationPropertyTypeComponent:           "AnnotationPropertyTypeComponent",
+	AnnotationPropertyTypeFontStyle:           "AnnotationPropertyTypeFontStyle",
+}
+
+var annotationMeasurementNodeSideNames = map[AnnotationMeasurementNodeSide]string{
+	AnnotationMeasurementNodeSideTop:    "AnnotationMeasurementNodeSideTop",
+	AnnotationMeasurementNodeSideBottom: "AnnotationMeasurementNodeSideBottom",
+	AnnotationMeasurementNodeSideLeft:   "AnnotationMeasurementNodeSideLeft",
+	AnnotationMeasurementNodeSideRight:  "AnnotationMeasurementNodeSideRight",
+}
+
+var editorTypeNames = map[EditorType]string{
+	EditorTypeDesign:     "EditorTypeDesign",
+	EditorTypeWhiteboard: "EditorTypeWhiteboard",
+	EditorTypeSlides:     "EditorTypeSlides",
+	EditorTypeDevHandoff: "EditorTypeDevHandoff",
+	EditorTypeSites:      "EditorTypeSites",
+}
+
+var maskTypeNames = map[MaskType]string{
+	MaskTypeAlpha:     "MaskTypeAlpha",
+	MaskTypeOutline:   "MaskTypeOutline",
+	MaskTypeLuminance: "MaskTypeLuminance",
+}
+
+var moduleTypeNames = map[ModuleType]string{
+	ModuleTypeNone:       "ModuleTypeNone",
+	ModuleTypeSingleNode: "ModuleTypeSingleNode",
+	ModuleTypeMultiNode:  "ModuleTypeMultiNode",
+}
+
+var sectionStatusNames = map[SectionStatus]string{
+	SectionStatusNone:      "SectionStatusNone",
+	SectionStatusBuild:     "SectionStatusBuild",
+	SectionStatusCompleted: "SectionStatusCompleted",
+}
+
+var responsiveScalingModeNames = map[ResponsiveScalingMode]string{
+	ResponsiveScalingModeReflow: "ResponsiveScalingModeReflow",
+	ResponsiveScalingModeScale:  "ResponsiveScalingModeScale",
+}
+
+var mediaActionNames = map[MediaAction]string{
+	MediaActionPlay:             "MediaActionPlay",
+	MediaActionPause:            "MediaActionPause",
+	MediaActionTogglePlayPause:  "MediaActionTogglePlayPause",
+	MediaActionMute:             "MediaActionMute",
+	MediaActionUnmute:           "MediaActionUnmute",
+	MediaActionToggleMuteUnmute: "MediaActionToggleMuteUnmute",
+	MediaActionSkipForward:      "MediaActionSkipForward",
+	MediaActionSkipBackward:     "MediaActionSkipBackward",
+	MediaActionSkipTo:           "MediaActionSkipTo",
+}
+
+var variableFieldNames = map[VariableField]string{
+	VariableFieldMissing:                          "VariableFieldMissing",
+	VariableFieldCornerRadius:                     "VariableFieldCornerRadius",
+	VariableFieldParagraphSpacing:                 "VariableFieldParagraphSpacing",
+	VariableFieldParagraphIndent:                  "VariableFieldParagraphIndent",
+	VariableFieldStrokeWeight:                     "VariableFieldStrokeWeight",
+	VariableFieldStackSpacing:                     "VariableFieldStackSpacing",
+	VariableFieldStackPaddingLeft:                 "VariableFieldStackPaddingLeft",
+	VariableFieldStackPaddingTop:                  "VariableFieldStackPaddingTop",
+	VariableFieldStackPaddingRight:                "VariableFieldStackPaddingRight",
+	VariableFieldStackPaddingBottom:               "VariableFieldStackPaddingBottom",
+	VariableFieldVisible:                          "VariableFieldVisible",
+	VariableFieldTextData:                         "VariableFieldTextData",
+	VariableFieldWidth:                            "VariableFieldWidth",
+	VariableFieldHeight:                           "VariableFieldHeight",
+	VariableFieldRectangleTopLeftCornerRadius:     "VariableFieldRectangleTopLeftCornerRadius",
+	VariableFieldRectangleTopRightCornerRadius:    "VariableFieldRectangleTopRightCornerRadius",
+	VariableFieldRectangleBottomLeftCornerRadius:  "VariableFieldRectangleBottomLeftCornerRadius",
+	VariableFieldRectangleBottomRightCornerRadius: "VariableFieldRectangleBottomRightCornerRadius",
+	VariableFieldBorderTopWeight:                  "VariableFieldBorderTopWeight",
+	VariableFieldBorderBottomWeight:               "VariableFieldBorderBottomWeight",
+	VariableFieldBorderLeftWeight:                 "VariableFieldBorderLeftWeight",
+	VariableFieldBorderRightWeight:                "VariableFieldBorderRightWeight",
+	VariableFieldVariantProperties:                "VariableFieldVariantProperties",
+	VariableFieldStackCounterSpacing:              "VariableFieldStackCounterSpacing",
+	VariableFieldMinWidth:                         "VariableFieldMinWidth",
+	VariableFieldMaxWidth:                         "VariableFieldMaxWidth",
+	VariableFieldMinHeight:                        "VariableFieldMinHeight",
+	VariableFieldMaxHeight:                        "VariableFieldMaxHeight",
+	VariableFieldFontFamily:                       "VariableFieldFontFamily",
+	VariableFieldFontStyle:                        "VariableFieldFontStyle",
+	VariableFieldFontVariations:                   "VariableFieldFontVariations",
+	VariableFieldOpacity:                          "VariableFieldOpacity",
+	VariableFieldFontSize:                         "VariableFieldFontSize",
+	VariableFieldLetterSpacing:                    "VariableFieldLetterSpacing",
+	VariableFieldLineHeight:                       "VariableFieldLineHeight",
+}
+
+var agendaItemTypeNames = map[AgendaItemType]string{
+	AgendaItemTypeNode:  "AgendaItemTypeNode",
+	AgendaItemTypeBlock: "AgendaItemTypeBlock",
+}
+
+var diagramLayoutRuleTypeNames = map[DiagramLayoutRuleType]string{
+	DiagramLayoutRuleTypeNone: "DiagramLayoutRuleTypeNone",
+	DiagramLayoutRuleTypeTree: "DiagramLayoutRuleTypeTree",
+}
+
+var diagramLayoutPausedNames = map[DiagramLayoutPaused]string{
+	DiagramLayoutPausedNo:  "DiagramLayoutPausedNo",
+	DiagramLayoutPausedYes: "DiagramLayoutPausedYes",
+}
+
+var componentPropNodeFieldNames = map[ComponentPropNodeField]string{
+	ComponentPropNodeFieldVisible:            "ComponentPropNodeFieldVisible",
+	ComponentPropNodeFieldTextData:           "ComponentPropNodeFieldTextData",
+	ComponentPropNodeFieldOverriddenSymbolId: "ComponentPropNodeFieldOverriddenSymbolId",
+	ComponentPropNodeFieldInheritFillStyleId: "ComponentPropNodeFieldInheritFillStyleId",
+}
+
+var componentPropTypeNames = map[ComponentPropType]string{
+	ComponentPropTypeBool:         "ComponentPropTypeBool",
+	ComponentPropTypeText:         "ComponentPropTypeText",
+	ComponentPropTypeColor:        "ComponentPropTypeColor",
+	ComponentPropTypeInstanceSwap: "ComponentPropTypeInstanceSwap",
+}
+
+var instanceSwapPreferredValueTypeNames = map[InstanceSwapPreferredValueType]string{
+	InstanceSwapPreferredValueTypeComponent:  "InstanceSwapPreferredValueTypeComponent",
+	InstanceSwapPreferredValueTypeStateGroup: "InstanceSwapPreferredValueTypeStateGroup",
+}
+
+var widgetEventNames = map[WidgetEvent]string{
+	WidgetEventMouseDown:                 "WidgetEventMouseDown",
+	WidgetEventClick:                     "WidgetEventClick",
+	WidgetEventTextEditEnd:               "WidgetEventTextEditEnd",
+	WidgetEventAttachedStickablesChanged: "WidgetEventAttachedStickablesChanged",
+	WidgetEventStuckStatusChanged:        "WidgetEventStuckStatusChanged",
+}
+
+var widgetInputBehaviorNames = map[WidgetInputBehavior]string{
+	WidgetInputBehaviorWrap:      "WidgetInputBehaviorWrap",
+	WidgetInputBehaviorTruncate:  "WidgetInputBehaviorTruncate",
+	WidgetInputBehaviorMultiline: "WidgetInputBehaviorMultiline",
+}
+
+var widgetPropertyMenuItemTypeNames = map[WidgetPropertyMenuItemType]string{
+	WidgetPropertyMenuItemTypeAction:        "WidgetPropertyMenuItemTypeAction",
+	WidgetPropertyMenuItemTypeSeparator:     "WidgetPropertyMenuItemTypeSeparator",
+	WidgetPropertyMenuItemTypeColor:         "WidgetPropertyMenuItemTypeColor",
+	WidgetPropertyMenuItemTypeDropdown:      "WidgetPropertyMenuItemTypeDropdown",
+	WidgetPropertyMenuItemTypeColorSelector: "WidgetPropertyMenuItemTypeColorSelector",
+	WidgetPropertyMenuItemTypeToggle:        "WidgetPropertyMenuItemTypeToggle",
+	WidgetPropertyMenuItemTypeLink:          "WidgetPropertyMenuItemTypeLink",
+}
+
+var widgetInputTextNodeTypeNames = map[WidgetInputTextNodeType]string{
+	WidgetInputTextNodeTypeWidgetControlled: "WidgetInputTextNodeTypeWidgetControlled",
+	WidgetInputTextNodeTypeRichText:         "WidgetInputTextNodeTypeRichText",
+}
+
+var codeBlockLanguageNames = map[CodeBlockLanguage]string{
+	CodeBlockLanguageTypescript: "CodeBlockLanguageTypescript",
+	CodeBlockLanguageCpp:        "CodeBlockLanguageCpp",
+	CodeBlockLanguageRuby:       "CodeBlockLanguageRuby",
+	CodeBlockLanguageCss:        "CodeBlockLanguageCss",
+	CodeBlockLanguageJavascript: "CodeBlockLanguageJavascript",
+	CodeBlockLanguageHtml:       "CodeBlockLanguageHtml",
+	CodeBlockLanguageJson:       "CodeBlockLanguageJson",
+	CodeBlockLanguageGraphql:    "CodeBlockLanguageGraphql",
+	CodeBlockLanguagePython:     "CodeBlockLanguagePython",
+	CodeBlockLanguageGo:         "CodeBlockLanguageGo",
+	CodeBlockLanguageSql:        "CodeBlockLanguageSql",
+	CodeBlockLanguageSwift:      "CodeBlockLanguageSwift",
+	CodeBlockLanguageKotlin:     "CodeBlockLanguageKotlin",
+	CodeBlockLanguageRust:       "CodeBlockLanguageRust",
+	CodeBlockLanguageBash:       "CodeBlockLanguageBash",
+	CodeBlockLanguagePlaintext:  "CodeBlockLanguagePlaintext",
+}
+
+var internalEnumForTestNames = map[InternalEnumForTest]string{
+	InternalEnumForTestOld: "InternalEnumForTestOld",
+}
+
+var bulletTypeNames = map[BulletType]string{
+	BulletTypeOrdered:   "BulletTypeOrdered",
+	BulletTypeUnordered: "BulletTypeUnordered",
+	BulletTypeIndent:    "BulletTypeIndent",
+	BulletTypeNoList:    "BulletTypeNoList",
+}
+
+var lineTypeNames = map[LineType]string{
+	LineTypePlain:         "LineTypePlain",
+	LineTypeOrderedList:   "LineTypeOrderedList",
+	LineTypeUnorderedList: "LineTypeUnorderedList",
+	LineTypeBlockquote:    "LineTypeBlockquote",
+	LineTypeHeader:        "LineTypeHeader",
+}
+
+var sourceDirectionalityNames = map[SourceDirectionality]string{
+	SourceDirectionalityAuto: "SourceDirectionalityAuto",
+	SourceDirectionalityLtr:  "SourceDirectionalityLtr",
+	SourceDirectionalityRtl:  "SourceDirectionalityRtl",
+}
+
+var directionalityNames = map[Directionality]string{
+	DirectionalityLtr: "DirectionalityLtr",
+	DirectionalityRtl: "DirectionalityRtl",
+}
+
+var directionalityIntentNames = map[DirectionalityIntent]string{
+	DirectionalityIntentImplicit: "DirectionalityIntentImplicit",
+	DirectionalityIntentExplicit: "DirectionalityIntentExplicit",
+}
+
+var triggerDeviceNames = map[TriggerDevice]string{
+	TriggerDeviceKeyboard:          "TriggerDeviceKeyboard",
+	TriggerDeviceUnknownController: "TriggerDeviceUnknownController",
+	TriggerDeviceXboxOne:           "TriggerDeviceXboxOne",
+	TriggerDevicePs4:               "TriggerDevicePs4",
+	TriggerDeviceSwitchPro:         "TriggerDeviceSwitchPro",
+}
+
+var mentionSourceNames = map[MentionSource]string{
+	MentionSourceDefault:       "MentionSourceDefault",
+	MentionSourceCopyDuplicate: "MentionSourceCopyDuplicate",
+}
+
+var transitionDirectionNames = map[TransitionDirection]string{
+	TransitionDirectionForward: "TransitionDirectionForward",
+	TransitionDirectionReverse: "TransitionDirectionReverse",
+}
+
+var playbackChangePhaseNames = map[PlaybackChangePhase]string{
+	PlaybackChangePhaseInitiated: "PlaybackChangePhaseInitiated",
+	PlaybackChangePhaseAborted:   "PlaybackChangePhaseAborted",
+	PlaybackChangePhaseCommitted: "PlaybackChangePhaseCommitted",
+}
+
+var heartbeatNames = map[Heartbeat]string{
+	HeartbeatForeground: "HeartbeatForeground",
+	HeartbeatBackground: "HeartbeatBackground",
+}
+
+var sceneGraphQueryBehaviorNames = map[SceneGraphQueryBehavior]string{
+	SceneGraphQueryBehaviorDefault:        "SceneGraphQueryBehaviorDefault",
+	SceneGraphQueryBehaviorContainingPage: "SceneGraphQueryBehaviorContainingPage",
+	SceneGraphQueryBehaviorPlugin:         "SceneGraphQueryBehaviorPlugin",
+}
+
+var richMediaTypeNames = map[RichMediaType]string{
+	RichMediaTypeAnimatedImage: "RichMediaTypeAnimatedImage",
+	RichMediaTypeVideo:         "RichMediaTypeVideo",
+}
+
+var variableDataTypeNames = map[VariableDataType]string{
+	VariableDataTypeBoolean:        "VariableDataTypeBoolean",
+	VariableDataTypeFloat:          "VariableDataTypeFloat",
+	VariableDataTypeString:         "VariableDataTypeString",
+	VariableDataTypeAlias:          "VariableDataTypeAlias",
+	VariableDataTypeColor:          "VariableDataTypeColor",
+	VariableDataTypeExpression:     "VariableDataTypeExpression",
+	VariableDataTypeMap:            "VariableDataTypeMap",
+	VariableDataTypeSymbolId:       "VariableDataTypeSymbolId",
+	VariableDataTypeFontStyle:      "VariableDataTypeFontStyle",
+	VariableDataTypeTextData:       "VariableDataTypeTextData",
+	VariableDataTypeInvalid:        "VariableDataTypeInvalid",
+	VariableDataTypeNodeFieldAlias: "VariableDataTypeNodeFieldAlias",
+}
+
+var variableResolvedDataTypeNames = map[VariableResolvedDataType]string{
+	VariableResolvedDataTypeBoolean:   "VariableResolvedDataTypeBoolean",
+	VariableResolvedDataTypeFloat:     "VariableResolvedDataTypeFloat",
+	VariableResolvedDataTypeString:    "VariableResolvedDataTypeString",
+	VariableResolvedDataTypeColor:     "VariableResolvedDataTypeColor",
+	VariableResolvedDataTypeMap:       "VariableResolvedDataTypeMap",
+	VariableResolvedDataTypeSymbolId:  "VariableResolvedDataTypeSymbolId",
+	VariableResolvedDataTypeFontStyle: "VariableResolvedDataTypeFontStyle",
+	VariableResolvedDataTypeTextData:  "VariableResolvedDataTypeTextData",
+}
+
+var expressionFunctionNames = map[ExpressionFunction]string{
+	ExpressionFunctionAddition:           "ExpressionFunctionAddition",
+	ExpressionFunctionSubtraction:        "ExpressionFunctionSubtraction",
+	ExpressionFunctionResolveVariant:     "ExpressionFunctionResolveVariant",
+	ExpressionFunctionMultiply:           "ExpressionFunctionMultiply",
+	ExpressionFunctionDivide:             "ExpressionFunctionDivide",
+	ExpressionFunctionEquals:             "ExpressionFunctionEquals",
+	ExpressionFunctionNotEqual:           "ExpressionFunctionNotEqual",
+	ExpressionFunctionLessThan:           "ExpressionFunctionLessThan",
+	ExpressionFunctionLessThanOrEqual:    "ExpressionFunctionLessThanOrEqual",
+	ExpressionFunctionGreaterThan:        "ExpressionFunctionGreaterThan",
+	ExpressionFunctionGreaterThanOrEqual: "ExpressionFunctionGreaterThanOrEqual",
+	ExpressionFunctionAnd:                "ExpressionFunctionAnd",
+	ExpressionFunctionOr:                 "ExpressionFunctionOr",
+	ExpressionFunctionNot:                "ExpressionFunctionNot",
+	ExpressionFunctionStringify:          "ExpressionFunctionStringify",
+	ExpressionFunctionTernary:            "ExpressionFunctionTernary",
+	ExpressionFunctionVarModeLookup:      "ExpressionFunctionVarModeLookup",
+	ExpressionFunctionNegate:             "ExpressionFunctionNegate",
+	ExpressionFunctionIsTruthy:           "ExpressionFunctionIsTruthy",
+}
+
+var nodeFieldAliasTypeNames = map[NodeFieldAliasType]string{
+	NodeFieldAliasTypeMissing:                  "NodeFieldAliasTypeMissing",
+	NodeFieldAliasTypeComponentPropAssignments: "NodeFieldAliasTypeComponentPropAssignments",
+}
+
+var variableScopeNames = map[VariableScope]string{
+	VariableScopeAllScopes:        "VariableScopeAllScopes",
+	VariableScopeTextContent:      "VariableScopeTextContent",
+	VariableScopeCornerRadius:     "VariableScopeCornerRadius",
+	VariableScopeWidthHeight:      "VariableScopeWidthHeight",
+	VariableScopeGap:              "VariableScopeGap",
+	VariableScopeAllFills:         "VariableScopeAllFills",
+	VariableScopeFrameFill:        "VariableScopeFrameFill",
+	VariableScopeShapeFill:        "VariableScopeShapeFill",
+	VariableScopeTextFill:         "VariableScopeTextFill",
+	VariableScopeStroke:           "VariableScopeStroke",
+	VariableScopeStrokeFloat:      "VariableScopeStrokeFloat",
+	VariableScopeEffectFloat:      "VariableScopeEffectFloat",
+	VariableScopeEffectColor:      "VariableScopeEffectColor",
+	VariableScopeOpacity:          "VariableScopeOpacity",
+	VariableScopeFontStyle:        "VariableScopeFontStyle",
+	VariableScopeFontFamily:       "VariableScopeFontFamily",
+	VariableScopeFontSize:         "VariableScopeFontSize",
+	VariableScopeLineHeight:       "VariableScopeLineHeight",
+	VariableScopeLetterSpacing:    "VariableScopeLetterSpacing",
+	VariableScopeParagraphSpacing: "VariableScopeParagraphSpacing",
+	VariableScopeParagraphIndent:  "VariableScopeParagraphIndent",
+	VariableScopeFontVariations:   "VariableScopeFontVariations",
+}
+
+var codeSyntaxPlatformNames = map[CodeSyntaxPlatform]string{
+	CodeSyntaxPlatformWeb:     "CodeSyntaxPlatformWeb",
+	CodeSyntaxPlatformAndroid: "CodeSyntaxPlatformAndroid",
+	CodeSyntaxPlatformIOs:     "CodeSyntaxPlatformIOs",
+}
+
+var hTMLTagNames = map[HTMLTag]string{
+	HTMLTagAuto:       "HTMLTagAuto",
+	HTMLTagArticle:    "HTMLTagArticle",
+	HTMLTagSection:    "HTMLTagSection",
+	HTMLTagNav:        "HTMLTagNav",
+	HTMLTagAside:      "HTMLTagAside",
+	HTMLTagH1:         "HTMLTagH1",
+	HTMLTagH2:         "HTMLTagH2",
+	HTMLTagH3:         "HTMLTagH3",
+	HTMLTagH4:         "HTMLTagH4",
+	HTMLTagH5:         "HTMLTagH5",
+	HTMLTagH6:         "HTMLTagH6",
+	HTMLTagHgroup:     "HTMLTagHgroup",
+	HTMLTagHeader:     "HTMLTagHeader",
+	HTMLTagFooter:     "HTMLTagFooter",
+	HTMLTagAddress:    "HTMLTagAddress",
+	HTMLTagP:          "HTMLTagP",
+	HTMLTagHr:         "HTMLTagHr",
+	HTMLTagPre:        "HTMLTagPre",
+	HTMLTagBlockquote: "HTMLTagBlockquote",
+	HTMLTagOl:         "HTMLTagOl",
+	HTMLTagUl:         "HTMLTagUl",
+	HTMLTagMenu:       "HTMLTagMenu",
+	HTMLTagLi:         "HTMLTagLi",
+	HTMLTagDl:         "HTMLTagDl",
+	HTMLTagDt:         "HTMLTagDt",
+	HTMLTagDd:         "HTMLTagDd",
+	HTMLTagFigure:     "HTMLTagFigure",
+	HTMLTagFigcaption: "HTMLTagFigcaption",
+	HTMLTagMain:       "HTMLTagMain",
+	HTMLTagDiv:        "HTMLTagDiv",
+	HTMLTagA:          "HTMLTagA",
+	HTMLTagEm:         "HTMLTagEm",
+	HTMLTagStrong:     "HTMLTagStrong",
+	HTMLTagSmall:      "HTMLTagSmall",
+	HTMLTagS:          "HTMLTagS",
+	HTMLTagCite:       "HTMLTagCite",
+	HTMLTagQ:          "HTMLTagQ",
+	HTMLTagDfn:        "HTMLTagDfn",
+	HTMLTagAbbr:       "HTMLTagAbbr",
+	HTMLTagRuby:       "HTMLTagRuby",
+	HTMLTagRt:         "HTMLTagRt",
+	HTMLTagRp:         "HTMLTagRp",
+	HTMLTagData:       "HTMLTagData",
+	HTMLTagTime:       "HTMLTagTime",
+	HTMLTagCode:       "HTMLTagCode",
+	HTMLTagVar:        "HTMLTagVar",
+	HTMLTagSamp:       "HTMLTagSamp",
+	HTMLTagKbd:        "HTMLTagKbd",
+	HTMLTagSub:        "HTMLTagSub",
+	HTMLTagSup:        "HTMLTagSup",
+	HTMLTagI:          "HTMLTagI",
+	HTMLTagB:          "HTMLTagB",
+	HTMLTagU:          "HTMLTagU",
+	HTMLTagMark:       "HTMLTagMark",
+	HTMLTagBdi:        "HTMLTagBdi",
+	HTMLTagBdo:        "HTMLTagBdo",
+	HTMLTagSpan:       "HTMLTagSpan",
+	HTMLTagBr:         "HTMLTagBr",
+	HTMLTagWbr:        "HTMLTagWbr",
+	HTMLTagPicture:    "HTMLTagPicture",
+	HTMLTagSource:     "HTMLTagSource",
+	HTMLTagImg:        "HTMLTagImg",
+	HTMLTagForm:       "HTMLTagForm",
+	HTMLTagLabel:      "HTMLTagLabel",
+	HTMLTagInput:      "HTMLTagInput",
+	HTMLTagButton:     "HTMLTagButton",
+	HTMLTagSelect:     "HTMLTagSelect",
+	HTMLTagDatalist:   "HTMLTagDatalist",
+	HTMLTagOptgroup:   "HTMLTagOptgroup",
+	HTMLTagOption:     "HTMLTagOption",
+	HTMLTagTextarea:   "HTMLTagTextarea",
+	HTMLTagOutput:     "HTMLTagOutput",
+	HTMLTagProgress:   "HTMLTagProgress",
+	HTMLTagMeter:      "HTMLTagMeter",
+	HTMLTagFieldset:   "HTMLTagFieldset",
+	HTMLTagLegend:     "HTMLTagLegend",
+	HTMLTagVideo:      "HTMLTagVideo",
+}
+
+var aRIARoleNames = map[ARIARole]string{
+	ARIARoleAuto:             "ARIARoleAuto",
+	ARIARoleNone:             "ARIARoleNone",
+	ARIARoleApplication:      "ARIARoleApplication",
+	ARIARoleBanner:           "ARIARoleBanner",
+	ARIARoleComplementary:    "ARIARoleComplementary",
+	ARIARoleContentinfo:      "ARIARoleContentinfo",
+	ARIARoleForm:             "ARIARoleForm",
+	ARIARoleMain:             "ARIARoleMain",
+	ARIARoleNavigation:       "ARIARoleNavigation",
+	ARIARoleRegion:           "ARIARoleRegion",
+	ARIARoleSearch:           "ARIARoleSearch",
+	ARIARoleSeparator:        "ARIARoleSeparator",
+	ARIARoleArticle:          "ARIARoleArticle",
+	ARIARoleColumnheader:     "ARIARoleColumnheader",
+	ARIARoleDefinition:       "ARIARoleDefinition",
+	ARIARoleDirectory:        "ARIARoleDirectory",
+	ARIARoleDocument:         "ARIARoleDocument",
+	ARIARoleGroup:            "ARIARoleGroup",
+	ARIARoleHeading:          "ARIARoleHeading",
+	ARIARoleImg:              "ARIARoleImg",
+	ARIARoleList:             "ARIARoleList",
+	ARIARoleListitem:         "ARIARoleListitem",
+	ARIARoleMath:             "ARIARoleMath",
+	ARIARoleNote:             "ARIARoleNote",
+	ARIARolePresentation:     "ARIARolePresentation",
+	ARIARoleRow:              "ARIARoleRow",
+	ARIARoleRowgroup:         "ARIARoleRowgroup",
+	ARIARoleRowheader:        "ARIARoleRowheader",
+	ARIARoleTable:            "ARIARoleTable",
+	ARIARoleToolbar:          "ARIARoleToolbar",
+	ARIARoleButton:           "ARIARoleButton",
+	ARIARoleCheckbox:         "ARIARoleCheckbox",
+	ARIARoleGridcell:         "ARIARoleGridcell",
+	ARIARoleLink:             "ARIARoleLink",
+	ARIARoleMenuitem:         "ARIARoleMenuitem",
+	ARIARoleMenuitemcheckbox: "ARIARoleMenuitemcheckbox",
+	ARIARoleMenuitemradio:    "ARIARoleMenuitemradio",
+	ARIARoleOption:           "ARIARoleOption",
+	ARIARoleProgressbar:      "ARIARoleProgressbar",
+	ARIARoleRadio:            "ARIARoleRadio",
+	ARIARoleScrollbar:        "ARIARoleScrollbar",
+	ARIARoleSlider:           "ARIARoleSlider",
+	ARIARoleSpinbutton:       "ARIARoleSpinbutton",
+	ARIARoleTab:              "ARIARoleTab",
+	ARIARoleTabpanel:         "ARIARoleTabpanel",
+	ARIARoleTextbox:          "ARIARoleTextbox",
+	ARIARoleTreeitem:         "ARIARoleTreeitem",
+	ARIARoleCombobox:         "ARIARoleCombobox",
+	ARIARoleGrid:             "ARIARoleGrid",
+	ARIARoleListbox:          "ARIARoleListbox",
+	ARIARoleMenu:             "ARIARoleMenu",
+	ARIARoleMenubar:          "ARIARoleMenubar",
+	ARIARoleRadiogroup:       "ARIARoleRadiogroup",
+	ARIARoleTablist:          "ARIARoleTablist",
+	ARIARoleTree:             "ARIARoleTree",
+	ARIARoleTreegrid:         "ARIARoleTreegrid",
+	ARIARoleTooltip:          "ARIARoleTooltip",
+	ARIARoleAlert:            "ARIARoleAlert",
+	ARIARoleLog:              "ARIARoleLog",
+	ARIARoleMarquee:          "ARIARoleMarquee",
+	ARIARoleStatus:           "ARIARoleStatus",
+	ARIARoleTimer:            "ARIARoleTimer",
+	ARIARoleAlertdialog:      "ARIARoleAlertdialog",
+	ARIARoleDialog:           "ARIARoleDialog",
+	ARIARoleSearchbox:        "ARIARoleSearchbox",
+	ARIARoleSwitch:           "ARIARoleSwitch",
+	ARIARoleBlockquote:       "ARIARoleBlockquote",
+	ARIARoleCaption:          "ARIARoleCaption",
+	ARIARoleCell:             "ARIARoleCell",
+	ARIARoleDeletion:         "ARIARoleDeletion",
+	ARIARoleEmphasis:         "ARIARoleEmphasis",
+	ARIARoleFeed:             "ARIARoleFeed",
+	ARIARoleFigure:           "ARIARoleFigure",
+	ARIARoleGeneric:          "ARIARoleGeneric",
+	ARIARoleInsertion:        "ARIARoleInsertion",
+	ARIARoleMeter:            "ARIARoleMeter",
+	ARIARoleParagraph:        "ARIARoleParagraph",
+	ARIARoleStrong:           "ARIARoleStrong",
+	ARIARoleSubscript:        "ARIARoleSubscript",
+	ARIARoleSuperscript:      "ARIARoleSuperscript",
+	ARIARoleTerm:             "ARIARoleTerm",
+	ARIARoleTime:             "ARIARoleTime",
+	ARIARoleImage:            "ARIARoleImage",
+	ARIARoleHeading1:         "ARIARoleHeading1",
+	ARIARoleHeading2:         "ARIARoleHeading2",
+	ARIARoleHeading3:         "ARIARoleHeading3",
+	ARIARoleHeading4:         "ARIARoleHeading4",
+	ARIARoleHeading5:         "ARIARoleHeading5",
+	ARIARoleHeading6:         "ARIARoleHeading6",
+	ARIARoleHeader:           "ARIARoleHeader",
+	ARIARoleFooter:           "ARIARoleFooter",
+	ARIARoleSidebar:          "ARIARoleSidebar",
+	ARIARoleSection:          "ARIARoleSection",
+	ARIARoleMaincontent:      "ARIARoleMaincontent",
+	ARIARoleTableCell:        "ARIARoleTableCell",
+	ARIARoleWidget:           "ARIARoleWidget",
+}
+
+var colorProfileNames = map[ColorProfile]string{
+	ColorProfileSrgb:      "ColorProfileSrgb",
+	ColorProfileDisplayP3: "ColorProfileDisplayP3",
+}
+
+var documentColorProfileNames = map[DocumentColorProfile]string{
+	DocumentColorProfileLegacy:    "DocumentColorProfileLegacy",
+	DocumentColorProfileSrgb:      "DocumentColorProfileSrgb",
+	DocumentColorProfileDisplayP3: "DocumentColorProfileDisplayP3",
+}
+
+var childReadingDirectionNames = map[ChildReadingDirection]string{
+	ChildReadingDirectionNone:        "ChildReadingDirectionNone",
+	ChildReadingDirectionLeftToRight: "ChildReadingDirectionLeftToRight",
+	ChildReadingDirectionRightToLeft: "ChildReadingDirectionRightToLeft",
+}
+
+var aRIAAttributeDataTypeNames = map[ARIAAttributeDataType]string{
+	ARIAAttributeDataTypeBoolean:    "ARIAAttributeDataTypeBoolean",
+	ARIAAttributeDataTypeString:     "ARIAAttributeDataTypeString",
+	ARIAAttributeDataTypeFloat:      "ARIAAttributeDataTypeFloat",
+	ARIAAttributeDataTypeInt:        "ARIAAttributeDataTypeInt",
+	ARIAAttributeDataTypeStringList: "ARIAAttributeDataTypeStringList",
+}
+
+var editScopeTypeNames = map[EditScopeType]string{
+	EditScopeTypeInvalid:    "EditScopeTypeInvalid",
+	EditScopeTypeTestSetup:  "EditScopeTypeTestSetup",
+	EditScopeTypeUser:       "EditScopeTypeUser",
+	EditScopeTypePlugin:     "EditScopeTypePlugin",
+	EditScopeTypeSystem:     "EditScopeTypeSystem",
+	EditScopeTypeRestApi:    "EditScopeTypeRestApi",
+	EditScopeTypeOnboarding: "EditScopeTypeOnboarding",
+	EditScopeTypeAutosave:   "EditScopeTypeAutosave",
+	EditScopeTypeAi:         "EditScopeTypeAi",
+}
+
+var sectionPresetStateNames = map[SectionPresetState]string{
+	SectionPresetStateInserted:   "SectionPresetStateInserted",
+	SectionPresetStateUserEdited: "SectionPresetStateUserEdited",
+}
+
+var emojiImageSetNames = map[EmojiImageSet]string{
+	EmojiImageSetApple: "EmojiImageSetApple",
+	EmojiImageSetNoto:  "EmojiImageSetNoto",
+}
+
+var firstDraftKitTypeNames = map[FirstDraftKitType]string{
+	FirstDraftKitTypeLocal:   "FirstDraftKitTypeLocal",
+	FirstDraftKitTypeLibrary: "FirstDraftKitTypeLibrary",
+	FirstDraftKitTypeNone:    "FirstDraftKitTypeNone",
+}
+
+var platformShapePropertyNames = map[PlatformShapeProperty]string{
+	PlatformShapePropertyFill:   "PlatformShapePropertyFill",
+	PlatformShapePropertyStroke: "PlatformShapePropertyStroke",
+	PlatformShapePropertyText:   "PlatformShapePropertyText",
+}
+
+var appearBehaviorTriggerNames = map[AppearBehaviorTrigger]string{
+	AppearBehaviorTriggerPageLoad:         "AppearBehaviorTriggerPageLoad",
+	AppearBehaviorTriggerThisLayerInView:  "AppearBehaviorTriggerThisLayerInView",
+	AppearBehaviorTriggerOtherLayerInView: "AppearBehaviorTriggerOtherLayerInView",
+	AppearBehaviorTriggerScrollDirection:  "AppearBehaviorTriggerScrollDirection",
+}
+
+var relativeDirectionNames = map[RelativeDirection]string{
+	RelativeDirectionUp:    "RelativeDirectionUp",
+	RelativeDirectionDown:  "RelativeDirectionDown",
+	RelativeDirectionLeft:  "RelativeDirectionLeft",
+	RelativeDirectionRight: "RelativeDirectionRight",
+}
+
+var linkBehaviorTypeNames = map[LinkBehaviorType]string{
+	LinkBehaviorTypeUrl:  "LinkBehaviorTypeUrl",
+	LinkBehaviorTypePage: "LinkBehaviorTypePage",
+}
+
+var scrollTransformBehaviorTriggerNames = map[ScrollTransformBehaviorTrigger]string{
+	ScrollTransformBehaviorTriggerPageHeight:       "ScrollTransformBehaviorTriggerPageHeight",
+	ScrollTransformBehaviorTriggerThisLayerInView:  "ScrollTransformBehaviorTriggerThisLayerInView",
+	ScrollTransformBehaviorTriggerOtherLayerInView: "ScrollTransformBehaviorTriggerOtherLayerInView",
+}
+
+// EnumName returns the name of the constant v holds, such as
+// "ShapeWithTextTypeDiamond". It reports false when v is not a value of
+// an enumeration, or has no constant.
+func EnumName(v any) (string, bool) {
+	switch v := v.(type) {
+	case MessageType:
+		name, ok := messageTypeNames[v]
+		return name, ok
+	case Axis:
+		name, ok := axisNames[v]
+		return name, ok
+	case Access:
+		name, ok := accessNames[v]
+		return name, ok
+	case NodePhase:
+		name, ok := nodePhaseNames[v]
+		return name, ok
+	case WindingRule:
+		name, ok := windingRuleNames[v]
+		return name, ok
+	case NodeType:
+		name, ok := nodeTypeNames[v]
+		return name, ok
+	case ShapeWithTextType:
+		name, ok := shapeWithTextTypeNames[v]
+		return name, ok
+	case BlendMode:
+		name, ok := blendModeNames[v]
+		return name, ok
+	case PaintType:
+		name, ok := paintTypeNames[v]
+		return name, ok
+	case ImageScaleMode:
+		name, ok := imageScaleModeNames[v]
+		return name, ok
+	case EffectType:
+		name, ok := effectTypeNames[v]
+		return name, ok
+	case TextCase:
+		name, ok := textCaseNames[v]
+		return name, ok
+	case TextDecoration:
+		name, ok := textDecorationNames[v]
+		return name, ok
+	case LeadingTrim:
+		name, ok := leadingTrimNames[v]
+		return name, ok
+	case NumberUnits:
+		name, ok := numberUnitsNames[v]
+		return name, ok
+	case ConstraintType:
+		name, ok := constraintTypeNames[v]
+		return name, ok
+	case StrokeAlign:
+		name, ok := strokeAlignNames[v]
+		return name, ok
+	case StrokeCap:
+		name, ok := strokeCapNames[v]
+		return name, ok
+	case StrokeJoin:
+		name, ok := strokeJoinNames[v]
+		return name, ok
+	case BooleanOperation:
+		name, ok := booleanOperationNames[v]
+		return name, ok
+	case TextAlignHorizontal:
+		name, ok := textAlignHorizontalNames[v]
+		return name, ok
+	case TextAlignVertical:
+		name, ok := textAlignVerticalNames[v]
+		return name, ok
+	case MouseCursor:
+		name, ok := mouseCursorNames[v]
+		return name, ok
+	case VectorMirror:
+		name, ok := vectorMirrorNames[v]
+		return name, ok
+	case DashMode:
+		name, ok := dashModeNames[v]
+		return name, ok
+	case ImageType:
+		name, ok := imageTypeNames[v]
+		return name, ok
+	case ExportConstraintType:
+		name, ok := exportConstraintTypeNames[v]
+		return name, ok
+	case LayoutGridType:
+		name, ok := layoutGridTypeNames[v]
+		return name, ok
+	case LayoutGridPattern:
+		name, ok := layoutGridPatternNames[v]
+		return name, ok
+	case TextAutoResize:
+		name, ok := textAutoResizeNames[v]
+		return name, ok
+	case TextTruncation:
+		name, ok := textTruncationNames[v]
+		return name, ok
+	case StyleSetType:
+		name, ok := styleSetTypeNames[v]
+		return name, ok
+	case StyleSetContentType:
+		name, ok := styleSetContentTypeNames[v]
+		return name, ok
+	case StackMode:
+		name, ok := stackModeNames[v]
+		return name, ok
+	case StackAlign:
+		name, ok := stackAlignNames[v]
+		return name, ok
+	case StackCounterAlign:
+		name, ok := stackCounterAlignNames[v]
+		return name, ok
+	case StackJustify:
+		name, ok := stackJustifyNames[v]
+		return name, ok
+	case StackSize:
+		name, ok := stackSizeNames[v]
+		return name, ok
+	case StackPositioning:
+		name, ok := stackPositioningNames[v]
+		return name, ok
+	case StackWrap:
+		name, ok := stackWrapNames[v]
+		return name, ok
+	case StackCounterAlignContent:
+		name, ok := stackCounterAlignContentNames[v]
+		return name, ok
+	case ConnectionType:
+		name, ok := connectionTypeNames[v]
+		return name, ok
+	case InteractionType:
+		name, ok := interactionTypeNames[v]
+		return name, ok
+	case TransitionType:
+		name, ok := transitionTypeNames[v]
+		return name, ok
+	case EasingType:
+		name, ok := easingTypeNames[v]
+		return name, ok
+	case ScrollDirection:
+		name, ok := scrollDirectionNames[v]
+		return name, ok
+	case ScrollContractedState:
+		name, ok := scrollContractedStateNames[v]
+		return name, ok
+	case FontVariantNumericFigure:
+		name, ok := fontVariantNumericFigureNames[v]
+		return name, ok
+	case FontVariantNumericSpacing:
+		name, ok := fontVariantNumericSpacingNames[v]
+		return name, ok
+	case FontVariantNumericFraction:
+		name, ok := fontVariantNumericFractionNames[v]
+		return name, ok
+	case FontVariantCaps:
+		name, ok := fontVariantCapsNames[v]
+		return name, ok
+	case FontVariantPosition:
+		name, ok := fontVariantPositionNames[v]
+		return name, ok
+	case FontStyle:
+		name, ok := fontStyleNames[v]
+		return name, ok
+	case SemanticWeight:
+		name, ok := semanticWeightNames[v]
+		return name, ok
+	case SemanticItalic:
+		name, ok := semanticItalicNames[v]
+		return name, ok
+	case OpenTypeFeature:
+		name, ok := openTypeFeatureNames[v]
+		return name, ok
+	case FlappType:
+		name, ok := flappTypeNames[v]
+		return name, ok
+	case PrototypeDeviceType:
+		name, ok := prototypeDeviceTypeNames[v]
+		return name, ok
+	case DeviceRotation:
+		name, ok := deviceRotationNames[v]
+		return name, ok
+	case OverlayPositionType:
+		name, ok := overlayPositionTypeNames[v]
+		return name, ok
+	case OverlayBackgroundInteraction:
+		name, ok := overlayBackgroundInteractionNames[v]
+		return name, ok
+	case OverlayBackgroundType:
+		name, ok := overlayBackgroundTypeNames[v]
+		return name, ok
+	case NavigationType:
+		name, ok := navigationTypeNames[v]
+		return name, ok
+	case ExportColorProfile:
+		name, ok := exportColorProfileNames[v]
+		return name, ok
+	case ExportSVGIDMode:
+		name, ok := exportSVGIDModeNames[v]
+		return name, ok
+	case StyleType:
+		name, ok := styleTypeNames[v]
+		return name, ok
+	case ScrollBehavior:
+		name, ok := scrollBehaviorNames[v]
+		return name, ok
+	case ConnectorMagnet:
+		name, ok := connectorMagnetNames[v]
+		return name, ok
+	case ConnectorTextSection:
+		name, ok := connectorTextSectionNames[v]
+		return name, ok
+	case ConnectorOffAxisOffset:
+		name, ok := connectorOffAxisOffsetNames[v]
+		return name, ok
+	case ConnectorLineStyle:
+		name, ok := connectorLineStyleNames[v]
+		return name, ok
+	case ConnectorType:
+		name, ok := connectorTypeNames[v]
+		return name, ok
+	case AnnotationPropertyType:
+		name, ok := annotationPropertyTypeNames[v]
+		return name, ok
+	case AnnotationMeasurementNodeSide:
+		name, ok := annotationMeasurementNodeSideNames[v]
+		return name, ok
+	case EditorType:
+		name, ok := editorTypeNames[v]
+		return name, ok
+	case MaskType:
+		name, ok := maskTypeNames[v]
+		return name, ok
+	case ModuleType:
+		name, ok := moduleTypeNames[v]
+		return name, ok
+	case SectionStatus:
+		name, ok := sectionStatusNames[v]
+		return name, ok
+	case ResponsiveScalingMode:
+		name, ok := responsiveScalingModeNames[v]
+		return name, ok
+	case MediaAction:
+		name, ok := mediaActionNames[v]
+		return name, ok
+	case VariableField:
+		name, ok := variableFieldNames[v]
+		return name, ok
+	case AgendaItemType:
+		name, ok := agendaItemTypeNames[v]
+		return name, ok
+	case DiagramLayoutRuleType:
+		name, ok := diagramLayoutRuleTypeNames[v]
+		return name, ok
+	case DiagramLayoutPaused:
+		name, ok := diagramLayoutPausedNames[v]
+		return name, ok
+	case ComponentPropNodeField:
+		name, ok := componentPropNodeFieldNames[v]
+		return name, ok
+	case ComponentPropType:
+		name, ok := componentPropTypeNames[v]
+		return name, ok
+	case InstanceSwapPreferredValueType:
+		name, ok := instanceSwapPreferredValueTypeNames[v]
+		return name, ok
+	case WidgetEvent:
+		name, ok := widgetEventNames[v]
+		return name, ok
+	case WidgetInputBehavior:
+		name, ok := widgetInputBehaviorNames[v]
+		return name, ok
+	case WidgetPropertyMenuItemType:
+		name, ok := widgetPropertyMenuItemTypeNames[v]
+		return name, ok
+	case WidgetInputTextNodeType:
+		name, ok := widgetInputTextNodeTypeNames[v]
+		return name, ok
+	case CodeBlockLanguage:
+		name, ok := codeBlockLanguageNames[v]
+		return name, ok
+	case InternalEnumForTest:
+		name, ok := internalEnumForTestNames[v]
+		return name, ok
+	case BulletType:
+		name, ok := bulletTypeNames[v]
+		return name, ok
+	case LineType:
+		name, ok := lineTypeNames[v]
+		return name, ok
+	case SourceDirectionality:
+		name, ok := sourceDirectionalityNames[v]
+		return name, ok
+	case Directionality:
+		name, ok := directionalityNames[v]
+		return name, ok
+	case DirectionalityIntent:
+		name, ok := directionalityIntentNames[v]
+		return name, ok
+	case TriggerDevice:
+		name, ok := triggerDeviceNames[v]
+		return name, ok
+	case MentionSource:
+		name, ok := mentionSourceNames[v]
+		return name, ok
+	case TransitionDirection:
+		name, ok := transitionDirectionNames[v]
+		return name, ok
+	case PlaybackChangePhase:
+		name, ok := playbackChangePhaseNames[v]
+		return name, ok
+	case Heartbeat:
+		name, ok := heartbeatNames[v]
+		return name, ok
+	case SceneGraphQueryBehavior:
+		name, ok := sceneGraphQueryBehaviorNames[v]
+		return name, ok
+	case RichMediaType:
+		name, ok := richMediaTypeNames[v]
+		return name, ok
+	case VariableDataType:
+		name, ok := variableDataTypeNames[v]
+		return name, ok
+	case VariableResolvedDataType:
+		name, ok := variableResolvedDataTypeNames[v]
+		return name, ok
+	case ExpressionFunction:
+		name, ok := expressionFunctionNames[v]
+		return name, ok
+	case NodeFieldAliasType:
+		name, ok := nodeFieldAliasTypeNames[v]
+		return name, ok
+	case VariableScope:
+		name, ok := variableScopeNames[v]
+		return name, ok
+	case CodeSyntaxPlatform:
+		name, ok := codeSyntaxPlatformNames[v]
+		return name, ok
+	case HTMLTag:
+		name, ok := hTMLTagNames[v]
+		return name, ok
+	case ARIARole:
+		name, ok := aRIARoleNames[v]
+		return name, ok
+	case ColorProfile:
+		name, ok := colorProfileNames[v]
+		return name, ok
+	case DocumentColorProfile:
+		name, ok := documentColorProfileNames[v]
+		return name, ok
+	case ChildReadingDirection:
+		name, ok := childReadingDirectionNames[v]
+		return name, ok
+	case ARIAAttributeDataType:
+		name, ok := aRIAAttributeDataTypeNames[v]
+		return name, ok
+	case EditScopeType:
+		name, ok := editScopeTypeNames[v]
+		return name, ok
+	case SectionPresetState:
+		name, ok := sectionPresetStateNames[v]
+		return name, ok
+	case EmojiImageSet:
+		name, ok := emojiImageSetNames[v]
+		return name, ok
+	case FirstDraftKitType:
+		name, ok := firstDraftKitTypeNames[v]
+		return name, ok
+	case PlatformShapeProperty:
+		name, ok := platformShapePropertyNames[v]
+		return name, ok
+	case AppearBehaviorTrigger:
+		name, ok := appearBehaviorTriggerNames[v]
+		return name, ok
+	case RelativeDirection:
+		name, ok := relativeDirectionNames[v]
+		return name, ok
+	case LinkBehaviorType:
+		name, ok := linkBehaviorTypeNames[v]
+		return name, ok
+	case ScrollTransformBehaviorTrigger:
+		name, ok := scrollTransformBehaviorTriggerNames[v]
+		return name, ok
+	}
+	return "", false
+}
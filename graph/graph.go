@@ -0,0 +1,275 @@
+package graph
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"strings"
+)
+
+// Graph is the diagram described by a scene: the nodes drawn on it, the
+// connectors between them, and the containers holding them.
+type Graph struct {
+	Scene *scene.Scene
+
+	// Nodes and Edges hold every node and edge of the graph, in drawing
+	// order. Clusters holds the containers at the top level, and Roots the
+	// nodes outside of any container.
+	Nodes    []*Node
+	Edges    []*Edge
+	Clusters []*Cluster
+	Roots    []*Node
+
+	nodes    map[string]*Node
+	clusters map[string]*Cluster
+}
+
+// Node is an element of the scene connectors can be attached to.
+type Node struct {
+	ID      string
+	Element *scene.Element
+	Kind    scene.Kind
+	// Shape is the type of shapes with text, and is only meaningful for
+	// nodes of KindShape.
+	Shape  fig.ShapeWithTextType
+	Label  string
+	Fill   *scene.Color
+	Stroke *scene.Color
+	// Bounds is the bounding box of the node, in the scene's coordinates.
+	Bounds  scene.Rect
+	Cluster *Cluster
+}
+
+// Cluster is a section, frame or group, holding nodes and other clusters.
+type Cluster struct {
+	ID       string
+	Element  *scene.Element
+	Kind     scene.Kind
+	Label    string
+	Fill     *scene.Color
+	Bounds   scene.Rect
+	Parent   *Cluster
+	Clusters []*Cluster
+	Nodes    []*Node
+}
+
+// Endpoint is an end of an edge. Node is set when it is attached to a node
+// of the graph, Cluster when it is attached to a container, and neither
+// when it is detached or attached to an element outside of the graph.
+type Endpoint struct {
+	ID      string
+	Node    *Node
+	Cluster *Cluster
+	Magnet  fig.ConnectorMagnet
+	// Point is where the edge meets its node, in the scene's coordinates.
+	Point scene.Point
+}
+
+// IsDangling reports whether the endpoint is not attached to the graph.
+func (e Endpoint) IsDangling() bool {
+	return e.Node == nil && e.Cluster == nil
+}
+
+// Edge is a connector between two endpoints.
+type Edge struct {
+	ID        string
+	Element   *scene.Element
+	Source    Endpoint
+	Target    Endpoint
+	Label     string
+	StartCap  fig.StrokeCap
+	EndCap    fig.StrokeCap
+	LineStyle fig.ConnectorLineStyle
+	Dashed    bool
+	Color     *scene.Color
+}
+
+// Node returns the node identified by id, if any.
+func (g *Graph) Node(id string) *Node {
+	return g.nodes[id]
+}
+
+// Cluster returns the cluster identified by id, if any.
+func (g *Graph) Cluster(id string) *Cluster {
+	return g.clusters[id]
+}
+
+// isNode reports whether e is a node of the graph.
+func isNode(e *scene.Element) bool {
+	switch e.Kind {
+	case scene.KindShape, scene.KindSticky, scene.KindText, scene.KindTable,
+		scene.KindCodeBlock, scene.KindLinkPreview:
+		return true
+	case scene.KindOther:
+		return len(e.Images) > 0
+	}
+	return e.Kind.IsPrimitive()
+}
+
+// Label returns the text of e as a single string. Table rows are placed in
+// separate lines, with their cells separated by vertical bars.
+func Label(e *scene.Element) string {
+	switch {
+	case e.Kind.IsContainer():
+		return e.Node.Name
+	case e.Kind == scene.KindLinkPreview:
+		if data := e.Node.LinkPreviewData; data.Title != "" {
+			return data.Title
+		}
+		return e.Node.LinkPreviewData.Url
+	case e.Kind == scene.KindTable && e.Table != nil:
+		rows := make([]string, len(e.Table.Cells))
+		for i, row := range e.Table.Cells {
+			cells := make([]string, len(row))
+			for j, cell := range row {
+				if cell != nil {
+					cells[j] = cell.Text.String()
+				}
+			}
+			rows[i] = strings.Join(cells, " | ")
+		}
+		return strings.Join(rows, "\n")
+	}
+	return strings.TrimRight(e.Text.String(), "\n")
+}
+
+// Build extracts the graph described by s.
+func Build(s *scene.Scene) *Graph {
+	g := &Graph{
+		Scene:    s,
+		nodes:    map[string]*Node{},
+		clusters: map[string]*Cluster{},
+	}
+	var connectors []*scene.Element
+	var add func(elements []*scene.Element, parent *Cluster)
+	add = func(elements []*scene.Element, parent *Cluster) {
+		for _, e := range elements {
+			switch {
+			case e.Kind == scene.KindConnector:
+				connectors = append(connectors, e)
+			case e.Kind.IsContainer():
+				c := &Cluster{
+					ID:      e.ID,
+					Element: e,
+					Kind:    e.Kind,
+					Label:   Label(e),
+					Fill:    e.Style.Fill,
+					Bounds:  e.Bounds,
+					Parent:  parent,
+				}
+				g.clusters[e.ID] = c
+				if parent == nil {
+					g.Clusters = append(g.Clusters, c)
+				} else {
+					parent.Clusters = append(parent.Clusters, c)
+				}
+				add(e.Children, c)
+			case isNode(e):
+				n := &Node{
+					ID:      e.ID,
+					Element: e,
+					Kind:    e.Kind,
+					Shape:   e.Node.ShapeWithTextType,
+					Label:   Label(e),
+					Fill:    e.Style.Fill,
+					Stroke:  e.Style.Stroke,
+					Bounds:  e.Bounds,
+					Cluster: parent,
+				}
+				g.Nodes = append(g.Nodes, n)
+				g.nodes[e.ID] = n
+				if parent == nil {
+					g.Roots = append(g.Roots, n)
+				} else {
+					parent.Nodes = append(parent.Nodes, n)
+				}
+			}
+		}
+	}
+	add(s.Elements, nil)
+
+	for _, e := range connectors {
+		conn := e.Connector
+		edge := &Edge{
+			ID:        e.ID,
+			Element:   e,
+			Source:    g.endpoint(conn.Start),
+			Target:    g.endpoint(conn.End),
+			Label:     strings.TrimRight(conn.Label.String(), "\n"),
+			StartCap:  conn.StartCap,
+			EndCap:    conn.EndCap,
+			LineStyle: conn.LineStyle,
+			Dashed:    len(e.Style.Dash) > 0,
+			Color:     e.Style.Stroke,
+		}
+		g.Edges = append(g.Edges, edge)
+	}
+	return g
+}
+
+func (g *Graph) endpoint(e scene.Endpoint) Endpoint {
+	return Endpoint{
+		ID:      e.ID,
+		Node:    g.nodes[e.ID],
+		Cluster: g.clusters[e.ID],
+		Magnet:  e.Magnet,
+		Point:   e.Point,
+	}
+}
+
+// Flow returns the side most edges head towards: along the axis most of
+// them run, the direction most of those follow. Graphs without edges flow
+// downwards.
+func (g *Graph) Flow() scene.Side {
+	right, left, down, up := 0, 0, 0, 0
+	for _, e := range g.Edges {
+		d := e.Target.Point.Sub(e.Source.Point)
+		switch {
+		case math.Abs(d.X) > math.Abs(d.Y) && d.X > 0:
+			right++
+		case math.Abs(d.X) > math.Abs(d.Y):
+			left++
+		case d.Y > 0:
+			down++
+		case d.Y < 0:
+			up++
+		}
+	}
+	switch {
+	case right+left > down+up && left > right:
+		return scene.SideLeft
+	case right+left > down+up:
+		return scene.SideRight
+	case up > down:
+		return scene.SideTop
+	}
+	return scene.SideBottom
+}
+
+// FirstNode returns the first node within c or its descendants, or nil
+// when it holds none.
+func (c *Cluster) FirstNode() *Node {
+	if len(c.Nodes) > 0 {
+		return c.Nodes[0]
+	}
+	for _, v := range c.Clusters {
+		if n := v.FirstNode(); n != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// SafeID returns id, formatted as "SESSION:LOCAL", as an identifier made
+// of letters, digits and underscores, which diagram languages accept
+// without quoting.
+func SafeID(id string) string {
+	return "n" + strings.ReplaceAll(id, ":", "_")
+}
+
+// Warning formats a note about content a diagram cannot represent, as a
+// single line to be written as a comment.
+func Warning(format string, args ...any) string {
+	return "Warning: " + strings.ReplaceAll(fmt.Sprintf(format, args...), "\n", " ")
+}
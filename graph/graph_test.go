@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	g := Build(scenetest.Scene(t))
+
+	section := g.Cluster("1:10")
+	if section == nil || section.Label != "Ideas" || len(section.Nodes) != 2 {
+		t.Fatalf("expected the section to hold both stickies, got %+v", section)
+	}
+	if n := g.Node("1:11"); n == nil || n.Cluster != section || n.Label != "Ship it" {
+		t.Errorf("expected the sticky within the section, got %+v", n)
+	}
+	if section.FirstNode() != g.Node("1:11") {
+		t.Errorf("expected the first sticky to be the section's first node")
+	}
+	// Stamps are decorations rather than nodes.
+	if g.Node("1:13") != nil {
+		t.Error("expected stamps to be left out of the graph")
+	}
+	if len(g.Roots) != 3 {
+		t.Errorf("expected the text, diamond and code block at the top level, got %d nodes", len(g.Roots))
+	}
+
+	if len(g.Edges) != 1 {
+		t.Fatalf("expected a single edge, got %d", len(g.Edges))
+	}
+	e := g.Edges[0]
+	if e.Source.Node != g.Node("1:20") || e.Target.Node != g.Node("1:11") || e.Label != "yes" {
+		t.Errorf("expected an edge labelled yes from the diamond to the sticky, got %+v", e)
+	}
+	if e.Source.IsDangling() || e.Target.IsDangling() {
+		t.Error("expected both ends to be attached")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	e := &scene.Element{Kind: scene.KindText, Text: scene.PlainText("Hello\n")}
+	if got := Label(e); got != "Hello" {
+		t.Errorf("expected trailing newlines to be trimmed, got %q", got)
+	}
+
+	table := &scene.Element{Kind: scene.KindTable, Table: &scene.Table{Cells: [][]*scene.Cell{
+		{{Text: scene.PlainText("Name")}, {Text: scene.PlainText("Status")}},
+		{nil, {Text: scene.PlainText("Done")}},
+	}}}
+	if got, want := Label(table), "Name | Status\n | Done"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestFlow(t *testing.T) {
+	edge := func(dx, dy float64) *Edge {
+		return &Edge{Target: Endpoint{Point: scene.Point{X: dx, Y: dy}}}
+	}
+	for _, tc := range []struct {
+		name  string
+		edges []*Edge
+		want  scene.Side
+	}{
+		{"empty", nil, scene.SideBottom},
+		{"right", []*Edge{edge(10, 0), edge(10, 5)}, scene.SideRight},
+		{"left", []*Edge{edge(-10, 0), edge(-10, 5), edge(10, 0)}, scene.SideLeft},
+		{"up", []*Edge{edge(0, -10), edge(10, 0), edge(0, -10)}, scene.SideTop},
+		// Vertical edges outnumber horizontal ones, even though most of
+		// them run right.
+		{"down", []*Edge{edge(10, 0), edge(10, 0), edge(0, 10), edge(0, 10), edge(0, -10)}, scene.SideBottom},
+	} {
+		if got := (&Graph{Edges: tc.edges}).Flow(); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSafeID(t *testing.T) {
+	if got := SafeID("12:345"); got != "n12_345" {
+		t.Errorf("expected n12_345, got %s", got)
+	}
+	if got := Warning("a\nb %d", 1); got != "Warning: a b 1" {
+		t.Errorf("expected a single line warning, got %q", got)
+	}
+}
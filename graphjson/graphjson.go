@@ -0,0 +1,126 @@
+package graphjson
+
+import (
+	"encoding/json"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"math"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the graph described by scenes as JSON documents.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	doc := Build(graph.Build(s))
+	doc.Source = opts.FilePath
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// round keeps coordinates from carrying floating point noise into
+// documents.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+func bounds(r scene.Rect) Bounds {
+	size := r.Size()
+	return Bounds{X: round(r.Min.X), Y: round(r.Min.Y), Width: round(size.X), Height: round(size.Y)}
+}
+
+func hex(c *scene.Color) string {
+	if c == nil {
+		return ""
+	}
+	return c.Hex()
+}
+
+// newNode returns the node describing e.
+func newNode(e *scene.Element, parent *graph.Cluster) Node {
+	n := Node{
+		ID:       e.ID,
+		Type:     nodeTypes[e.Node.Type],
+		Kind:     e.Kind.String(),
+		Name:     e.Node.Name,
+		Bounds:   bounds(e.Bounds),
+		Rotation: round(e.Transform.Rotation()),
+		Fill:     hex(e.Style.Fill),
+		Stroke:   hex(e.Style.Stroke),
+	}
+	if e.Kind == scene.KindShape {
+		n.ShapeType = shapeTypes[e.Node.ShapeWithTextType]
+	}
+	if parent != nil {
+		n.Parent = parent.ID
+	}
+	return n
+}
+
+func endpoint(end graph.Endpoint) Endpoint {
+	res := Endpoint{Magnet: magnets[end.Magnet], X: round(end.Point.X), Y: round(end.Point.Y)}
+	switch {
+	case end.Node != nil:
+		res.Node = end.Node.ID
+	case end.Cluster != nil:
+		res.Node = end.Cluster.ID
+	}
+	return res
+}
+
+// Build returns the document describing g.
+func Build(g *graph.Graph) *Document {
+	s := g.Scene
+	doc := &Document{
+		SchemaVersion: SchemaVersion,
+		Page:          Page{ID: scene.GUIDKey(s.Page.Guid), Name: s.Page.Name},
+		Nodes:         []Node{},
+		Edges:         []Edge{},
+	}
+	if s.Root != s.Page {
+		doc.Page.Root = scene.GUIDKey(s.Root.Guid)
+	}
+
+	var addCluster func(v *graph.Cluster)
+	addCluster = func(v *graph.Cluster) {
+		doc.Nodes = append(doc.Nodes, newNode(v.Element, v.Parent))
+		for _, child := range v.Clusters {
+			addCluster(child)
+		}
+	}
+	for _, v := range g.Clusters {
+		addCluster(v)
+	}
+	for _, v := range g.Nodes {
+		n := newNode(v.Element, v.Cluster)
+		n.Text = v.Label
+		doc.Nodes = append(doc.Nodes, n)
+	}
+
+	for _, e := range g.Edges {
+		doc.Edges = append(doc.Edges, Edge{
+			ID:        e.ID,
+			Source:    endpoint(e.Source),
+			Target:    endpoint(e.Target),
+			Label:     e.Label,
+			StartCap:  strokeCaps[e.StartCap],
+			EndCap:    strokeCaps[e.EndCap],
+			LineStyle: lineStyles[e.LineStyle],
+			Dashed:    e.Dashed,
+			Color:     hex(e.Color),
+		})
+	}
+	return doc
+}
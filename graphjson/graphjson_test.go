@@ -0,0 +1,149 @@
+package graphjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func render(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	r := &Renderer{Opts: &Options{FilePath: "board.jam"}}
+	if err := r.Render(scenetest.Scene(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRender(t *testing.T) {
+	scenetest.Golden(t, "board.json", render(t))
+}
+
+func TestRoundTrip(t *testing.T) {
+	var got Document
+	if err := json.Unmarshal(render(t), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Build(graph.Build(scenetest.Scene(t)))
+	want.Source = "board.jam"
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("decoded document differs:\ngot:  %+v\nwant: %+v", got, *want)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	doc := Build(graph.Build(scenetest.Scene(t)))
+	if doc.Page != (Page{ID: "0:1", Name: "Board"}) {
+		t.Errorf("unexpected page %+v", doc.Page)
+	}
+	nodes := map[string]Node{}
+	for i, n := range doc.Nodes {
+		nodes[n.ID] = n
+		if n.Parent != "" {
+			if _, ok := nodes[n.Parent]; !ok {
+				t.Errorf("expected %s to follow its container %s (index %d)", n.ID, n.Parent, i)
+			}
+		}
+	}
+	if n := nodes["1:11"]; n.Kind != "sticky" || n.Parent != "1:10" || n.Text != "Ship it" || n.Fill != "#ffd966" {
+		t.Errorf("unexpected sticky %+v", n)
+	}
+	if n := nodes["1:20"]; n.ShapeType != "DIAMOND" || n.Bounds != (Bounds{X: 500, Y: 60, Width: 120, Height: 80}) {
+		t.Errorf("unexpected diamond %+v", n)
+	}
+	if _, ok := nodes["1:13"]; ok {
+		t.Errorf("expected stamps to be left out")
+	}
+
+	if len(doc.Edges) != 1 {
+		t.Fatalf("expected a single edge, got %d", len(doc.Edges))
+	}
+	e := doc.Edges[0]
+	if e.Source.Node != "1:20" || e.Source.Magnet != "LEFT" || e.Target.Node != "1:11" || e.Target.Magnet != "TOP" {
+		t.Errorf("unexpected ends %+v -> %+v", e.Source, e.Target)
+	}
+	if e.Label != "yes" || e.EndCap != "ARROW_LINES" || e.StartCap != "NONE" {
+		t.Errorf("unexpected edge %+v", e)
+	}
+}
+
+// TestSchemaEnums checks that enumerated values written to documents are
+// listed by the schema.
+func TestSchemaEnums(t *testing.T) {
+	var schema struct {
+		Defs map[string]struct {
+			Enum       []string `json:"enum"`
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatal(err)
+	}
+	enum := func(def, property string) []string {
+		d, ok := schema.Defs[def]
+		if !ok {
+			t.Fatalf("schema has no definition %s", def)
+		}
+		if property == "" {
+			return d.Enum
+		}
+		return d.Properties[property].Enum
+	}
+	check := func(def, property, value string) {
+		t.Helper()
+		if values := enum(def, property); !slices.Contains(values, value) {
+			t.Errorf("%s.%s value %q is not listed by the schema", def, property, value)
+		}
+	}
+
+	var doc Document
+	if err := json.Unmarshal(render(t), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Nodes) == 0 || len(doc.Edges) == 0 {
+		t.Fatal("document has no nodes or edges")
+	}
+	for _, n := range doc.Nodes {
+		check("node", "type", n.Type)
+		check("node", "kind", n.Kind)
+		if n.ShapeType != "" {
+			check("node", "shapeType", n.ShapeType)
+		}
+	}
+	for _, e := range doc.Edges {
+		check("endpoint", "magnet", e.Source.Magnet)
+		check("endpoint", "magnet", e.Target.Magnet)
+		check("edge", "lineStyle", e.LineStyle)
+		check("strokeCap", "", e.StartCap)
+		check("strokeCap", "", e.EndCap)
+	}
+
+	// Every name the package writes must be listed, not only those used
+	// by the board.
+	for _, v := range nodeTypes {
+		check("node", "type", v)
+	}
+	for k := scene.KindOther; k <= scene.KindGroup; k++ {
+		check("node", "kind", k.String())
+	}
+	for _, v := range shapeTypes {
+		check("node", "shapeType", v)
+	}
+	for _, v := range magnets {
+		check("endpoint", "magnet", v)
+	}
+	for _, v := range lineStyles {
+		check("edge", "lineStyle", v)
+	}
+	for _, v := range strokeCaps {
+		check("strokeCap", "", v)
+	}
+}
@@ -0,0 +1,118 @@
+package graphjson
+
+import "github.com/heyvito/figz/fig"
+
+// The tables below name the enumerations used by the schema after their
+// definitions in the Kiwi schema of .fig files, so that their values do not
+// depend on the numbering of the decoder.
+
+var nodeTypes = map[fig.NodeType]string{
+	fig.NodeTypeNone:                    "NONE",
+	fig.NodeTypeDocument:                "DOCUMENT",
+	fig.NodeTypeCanvas:                  "CANVAS",
+	fig.NodeTypeGroup:                   "GROUP",
+	fig.NodeTypeFrame:                   "FRAME",
+	fig.NodeTypeBooleanOperation:        "BOOLEAN_OPERATION",
+	fig.NodeTypeVector:                  "VECTOR",
+	fig.NodeTypeStar:                    "STAR",
+	fig.NodeTypeLine:                    "LINE",
+	fig.NodeTypeEllipse:                 "ELLIPSE",
+	fig.NodeTypeRectangle:               "RECTANGLE",
+	fig.NodeTypeRegularPolygon:          "REGULAR_POLYGON",
+	fig.NodeTypeRoundedRectangle:        "ROUNDED_RECTANGLE",
+	fig.NodeTypeText:                    "TEXT",
+	fig.NodeTypeSlice:                   "SLICE",
+	fig.NodeTypeSymbol:                  "SYMBOL",
+	fig.NodeTypeInstance:                "INSTANCE",
+	fig.NodeTypeSticky:                  "STICKY",
+	fig.NodeTypeShapeWithText:           "SHAPE_WITH_TEXT",
+	fig.NodeTypeConnector:               "CONNECTOR",
+	fig.NodeTypeCodeBlock:               "CODE_BLOCK",
+	fig.NodeTypeWidget:                  "WIDGET",
+	fig.NodeTypeStamp:                   "STAMP",
+	fig.NodeTypeMedia:                   "MEDIA",
+	fig.NodeTypeHighlight:               "HIGHLIGHT",
+	fig.NodeTypeSection:                 "SECTION",
+	fig.NodeTypeSectionOverlay:          "SECTION_OVERLAY",
+	fig.NodeTypeWashiTape:               "WASHI_TAPE",
+	fig.NodeTypeVariable:                "VARIABLE",
+	fig.NodeTypeTable:                   "TABLE",
+	fig.NodeTypeTableCell:               "TABLE_CELL",
+	fig.NodeTypeVariableSet:             "VARIABLE_SET",
+	fig.NodeTypeSlide:                   "SLIDE",
+	fig.NodeTypeAssistedLayout:          "ASSISTED_LAYOUT",
+	fig.NodeTypeInteractiveSlideElement: "INTERACTIVE_SLIDE_ELEMENT",
+	fig.NodeTypeVariableOverride:        "VARIABLE_OVERRIDE",
+	fig.NodeTypeModule:                  "MODULE",
+	fig.NodeTypeSlideGrid:               "SLIDE_GRID",
+	fig.NodeTypeSlideRow:                "SLIDE_ROW",
+	fig.NodeTypeResponsiveSet:           "RESPONSIVE_SET",
+}
+
+var shapeTypes = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:             "SQUARE",
+	fig.ShapeWithTextTypeEllipse:            "ELLIPSE",
+	fig.ShapeWithTextTypeDiamond:            "DIAMOND",
+	fig.ShapeWithTextTypeTriangleUp:         "TRIANGLE_UP",
+	fig.ShapeWithTextTypeTriangleDown:       "TRIANGLE_DOWN",
+	fig.ShapeWithTextTypeRoundedRectangle:   "ROUNDED_RECTANGLE",
+	fig.ShapeWithTextTypeParallelogramRight: "PARALLELOGRAM_RIGHT",
+	fig.ShapeWithTextTypeParallelogramLeft:  "PARALLELOGRAM_LEFT",
+	fig.ShapeWithTextTypeEngDatabase:        "ENG_DATABASE",
+	fig.ShapeWithTextTypeEngQueue:           "ENG_QUEUE",
+	fig.ShapeWithTextTypeEngFile:            "ENG_FILE",
+	fig.ShapeWithTextTypeEngFolder:          "ENG_FOLDER",
+	fig.ShapeWithTextTypeTrapezoid:          "TRAPEZOID",
+	fig.ShapeWithTextTypePredefinedProcess:  "PREDEFINED_PROCESS",
+	fig.ShapeWithTextTypeShield:             "SHIELD",
+	fig.ShapeWithTextTypeDocumentSingle:     "DOCUMENT_SINGLE",
+	fig.ShapeWithTextTypeDocumentMultiple:   "DOCUMENT_MULTIPLE",
+	fig.ShapeWithTextTypeManualInput:        "MANUAL_INPUT",
+	fig.ShapeWithTextTypeHexagon:            "HEXAGON",
+	fig.ShapeWithTextTypeChevron:            "CHEVRON",
+	fig.ShapeWithTextTypePentagon:           "PENTAGON",
+	fig.ShapeWithTextTypeOctagon:            "OCTAGON",
+	fig.ShapeWithTextTypeStar:               "STAR",
+	fig.ShapeWithTextTypePlus:               "PLUS",
+	fig.ShapeWithTextTypeArrowLeft:          "ARROW_LEFT",
+	fig.ShapeWithTextTypeArrowRight:         "ARROW_RIGHT",
+	fig.ShapeWithTextTypeSummingJunction:    "SUMMING_JUNCTION",
+	fig.ShapeWithTextTypeOr:                 "OR",
+	fig.ShapeWithTextTypeSpeechBubble:       "SPEECH_BUBBLE",
+	fig.ShapeWithTextTypeInternalStorage:    "INTERNAL_STORAGE",
+}
+
+var strokeCaps = map[fig.StrokeCap]string{
+	fig.StrokeCapNone:             "NONE",
+	fig.StrokeCapRound:            "ROUND",
+	fig.StrokeCapSquare:           "SQUARE",
+	fig.StrokeCapArrowLines:       "ARROW_LINES",
+	fig.StrokeCapArrowEquilateral: "ARROW_EQUILATERAL",
+	fig.StrokeCapDiamondFilled:    "DIAMOND_FILLED",
+	fig.StrokeCapTriangleFilled:   "TRIANGLE_FILLED",
+	fig.StrokeCapHighlight:        "HIGHLIGHT",
+	fig.StrokeCapWashiTape1:       "WASHI_TAPE_1",
+	fig.StrokeCapWashiTape2:       "WASHI_TAPE_2",
+	fig.StrokeCapWashiTape3:       "WASHI_TAPE_3",
+	fig.StrokeCapWashiTape4:       "WASHI_TAPE_4",
+	fig.StrokeCapWashiTape5:       "WASHI_TAPE_5",
+	fig.StrokeCapWashiTape6:       "WASHI_TAPE_6",
+	fig.StrokeCapCircleFilled:     "CIRCLE_FILLED",
+}
+
+var magnets = map[fig.ConnectorMagnet]string{
+	fig.ConnectorMagnetNone:           "NONE",
+	fig.ConnectorMagnetAuto:           "AUTO",
+	fig.ConnectorMagnetTop:            "TOP",
+	fig.ConnectorMagnetLeft:           "LEFT",
+	fig.ConnectorMagnetBottom:         "BOTTOM",
+	fig.ConnectorMagnetRight:          "RIGHT",
+	fig.ConnectorMagnetCenter:         "CENTER",
+	fig.ConnectorMagnetAutoHorizontal: "AUTO_HORIZONTAL",
+}
+
+var lineStyles = map[fig.ConnectorLineStyle]string{
+	fig.ConnectorLineStyleElbowed:  "ELBOWED",
+	fig.ConnectorLineStyleStraight: "STRAIGHT",
+	fig.ConnectorLineStyleCurved:   "CURVED",
+}
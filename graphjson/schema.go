@@ -0,0 +1,93 @@
+package graphjson
+
+import _ "embed"
+
+// SchemaVersion is the version of the schema documents are written with. It
+// changes whenever a field is removed or changes meaning; fields may be
+// added without changing it.
+const SchemaVersion = 1
+
+// Schema is the JSON Schema describing documents, also available as
+// schema.json in this package's directory.
+//
+//go:embed schema.json
+var Schema []byte
+
+// Document is the diagram drawn on a page, or on a selection within it.
+// Positions and sizes are in canvas units, with the y axis pointing down.
+type Document struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Source        string `json:"source,omitempty"`
+	Page          Page   `json:"page"`
+	// Nodes holds containers before the nodes they hold, followed by the
+	// remaining nodes in drawing order.
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Page identifies the page a document was built from. Root is set when only
+// the children of one of its nodes were selected.
+type Page struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Root string `json:"root,omitempty"`
+}
+
+// Node is an element connectors can be attached to, or a section, frame or
+// group holding other nodes. IDs are GUIDs formatted as "SESSION:LOCAL".
+type Node struct {
+	ID string `json:"id"`
+	// Type is the NodeType of the node, such as "SHAPE_WITH_TEXT".
+	Type string `json:"type"`
+	// Kind classifies nodes by how they are drawn, such as "sticky" or
+	// "section".
+	Kind string `json:"kind"`
+	// ShapeType is the ShapeWithTextType of shapes, such as "DIAMOND".
+	ShapeType string `json:"shapeType,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Text      string `json:"text,omitempty"`
+	// Bounds is the bounding box of the node, and Rotation the angle in
+	// degrees it is rotated by, clockwise.
+	Bounds   Bounds  `json:"bounds"`
+	Rotation float64 `json:"rotation,omitempty"`
+	// Fill and Stroke are colors formatted as "#rrggbb".
+	Fill   string `json:"fill,omitempty"`
+	Stroke string `json:"stroke,omitempty"`
+	// Parent is the ID of the container holding the node, if any.
+	Parent string `json:"parent,omitempty"`
+}
+
+type Bounds struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Edge is a connector from Source to Target.
+type Edge struct {
+	ID     string   `json:"id"`
+	Source Endpoint `json:"source"`
+	Target Endpoint `json:"target"`
+	Label  string   `json:"label,omitempty"`
+	// StartCap and EndCap are the StrokeCap drawn at each end, such as
+	// "ARROW_LINES", and LineStyle the ConnectorLineStyle, such as
+	// "ELBOWED".
+	StartCap  string `json:"startCap"`
+	EndCap    string `json:"endCap"`
+	LineStyle string `json:"lineStyle"`
+	Dashed    bool   `json:"dashed,omitempty"`
+	Color     string `json:"color,omitempty"`
+}
+
+// Endpoint is an end of an edge. Node is the ID of the node it is attached
+// to, and is empty when the end is detached or attached to an element that
+// is not part of the document.
+type Endpoint struct {
+	Node string `json:"node,omitempty"`
+	// Magnet is the ConnectorMagnet the end is attached with, such as
+	// "LEFT".
+	Magnet string  `json:"magnet"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+}
@@ -0,0 +1,178 @@
+package html
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/outline"
+	"github.com/heyvito/figz/scene"
+	"html"
+	"io"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the text content of scenes as HTML documents.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{doc: outline.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	doc    *outline.Document
+	opts   *Options
+	b      strings.Builder
+	indent int
+}
+
+// Escape returns s escaped as HTML text, with its line breaks kept.
+func Escape(s string) string {
+	return strings.ReplaceAll(html.EscapeString(strings.TrimSpace(s)), "\n", "<br>")
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+func (c *Compiler) comment(format string, args ...any) {
+	c.line("<!-- %s -->", scene.EscapeComment(fmt.Sprintf(format, args...)))
+}
+
+// Compile returns the HTML document.
+func (c *Compiler) Compile() string {
+	s := c.doc.Scene
+	c.line("<!DOCTYPE html>")
+	c.comment("This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.comment("Input file: %s", c.opts.FilePath)
+	if root := s.Root; root != s.Page {
+		c.comment("Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+	}
+	c.line("<html>")
+	c.line("<head>")
+	c.indent++
+	c.line(`<meta charset="utf-8">`)
+	c.line("<title>%s</title>", Escape(c.doc.Root.Title))
+	c.indent--
+	c.line("</head>")
+	c.line("<body>")
+	c.indent++
+	c.writeSection(c.doc.Root)
+	c.indent--
+	c.line("</body>")
+	c.line("</html>")
+	return c.b.String()
+}
+
+// item returns the text of i, linked to its URL and followed by its votes
+// and stamps. URLs with schemes other than http, https and mailto are left
+// out, keeping the text alone.
+func item(i *outline.Item) string {
+	text := Escape(i.Text)
+	if scene.SafeURL(i.URL) {
+		text = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(i.URL), text)
+	}
+	if tally := i.Tally(); tally != "" {
+		text += fmt.Sprintf(` <span class="tally">(%s)</span>`, tally)
+	}
+	return text
+}
+
+func (c *Compiler) writeSection(sec *outline.Section) {
+	level := min(sec.Level, 6)
+	c.line(`<section id="%s">`, html.EscapeString(sec.ID))
+	c.indent++
+	c.line("<h%d>%s</h%d>", level, Escape(sec.Title), level)
+	for _, b := range sec.Blocks {
+		switch b.Kind {
+		case outline.BlockParagraph, outline.BlockLink:
+			c.line("<p>%s</p>", item(b.Items[0]))
+		case outline.BlockStickies:
+			c.line(`<p class="stickies"><strong>%s stickies</strong></p>`, strings.ToUpper(b.Color[:1])+b.Color[1:])
+			c.line(`<ul class="stickies %s">`, b.Color)
+			c.indent++
+			for _, v := range b.Items {
+				c.line("<li>%s</li>", item(v))
+			}
+			c.indent--
+			c.line("</ul>")
+		case outline.BlockTable:
+			c.writeTable(b.Rows)
+		case outline.BlockCode:
+			c.writeCode(b)
+		}
+	}
+	if len(sec.Connections) > 0 {
+		c.line(`<p class="connections"><strong>Connections</strong></p>`)
+		c.line(`<ul class="connections">`)
+		c.indent++
+		for _, v := range sec.Connections {
+			c.line("<li>%s</li>", Escape(v.String()))
+		}
+		c.indent--
+		c.line("</ul>")
+	}
+	for _, v := range sec.Sections {
+		c.writeSection(v)
+	}
+	c.indent--
+	c.line("</section>")
+}
+
+func (c *Compiler) writeTable(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	c.line("<table>")
+	c.indent++
+	for i, row := range rows {
+		tag := "td"
+		switch i {
+		case 0:
+			tag = "th"
+			c.line("<thead>")
+			c.indent++
+		case 1:
+			c.line("<tbody>")
+			c.indent++
+		}
+		cells := make([]string, len(row))
+		for j, v := range row {
+			cells[j] = fmt.Sprintf("<%s>%s</%s>", tag, Escape(v), tag)
+		}
+		c.line("<tr>%s</tr>", strings.Join(cells, ""))
+		if i == 0 {
+			c.indent--
+			c.line("</thead>")
+		}
+	}
+	if len(rows) > 1 {
+		c.indent--
+		c.line("</tbody>")
+	}
+	c.indent--
+	c.line("</table>")
+}
+
+func (c *Compiler) writeCode(b *outline.Block) {
+	class := ""
+	if b.Language != "" {
+		class = fmt.Sprintf(` class="language-%s"`, b.Language)
+	}
+	// Code is written unindented, as it is preformatted.
+	fmt.Fprintf(&c.b, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.TrimRight(b.Items[0].Text, "\n")))
+	if tally := b.Items[0].Tally(); tally != "" {
+		c.line(`<p class="tally">(%s)</p>`, tally)
+	}
+}
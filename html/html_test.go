@@ -0,0 +1,39 @@
+package html
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/outline"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Renderer{Opts: &Options{FilePath: "board.jam"}}
+	if err := r.Render(scenetest.Scene(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	scenetest.Golden(t, "board.html", buf.Bytes())
+
+	for _, want := range []string{
+		"<title>Board</title>",
+		`<section id="1:10">`,
+		"<p>Retro &lt;2024&gt;</p>",
+		`<li>Ship it <span class="tally">(1 vote)</span></li>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestItemLinks(t *testing.T) {
+	if got := item(&outline.Item{Text: "A & B", URL: "https://example.com/?a=1&b=2"}); got != `<a href="https://example.com/?a=1&amp;b=2">A &amp; B</a>` {
+		t.Errorf("unexpected link %q", got)
+	}
+	if got := item(&outline.Item{Text: "Docs", URL: "javascript:alert(1)"}); got != "Docs" {
+		t.Errorf("expected unsafe URLs to be left out, got %q", got)
+	}
+}
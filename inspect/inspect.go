@@ -0,0 +1,302 @@
+package inspect
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/tikz"
+	"slices"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+
+	// OmitDecorations counts highlights, washi tapes, stamps and emoji
+	// reactions as skipped, as they are when compiling without them.
+	OmitDecorations bool
+}
+
+// Report summarizes the contents of a file.
+type Report struct {
+	File      string `json:"file,omitempty"`
+	Container string `json:"container"`
+	Version   uint32 `json:"version"`
+	// Compression is the method the canvas's chunks are compressed with,
+	// and ArchiveCompression the one of the canvas within ZIP containers.
+	Compression        string `json:"compression"`
+	ArchiveCompression string `json:"archiveCompression,omitempty"`
+	// SchemaHash is the hex-encoded SHA-256 of the decompressed schema the
+	// file was encoded with.
+	SchemaHash string `json:"schemaHash"`
+	Nodes      int    `json:"nodes"`
+	Blobs      int    `json:"blobs"`
+
+	Pages []*Page `json:"pages"`
+	// NodeTypes counts nodes by NodeType, and ShapeTypes shapes by
+	// ShapeWithTextType.
+	NodeTypes  []Count `json:"nodeTypes"`
+	ShapeTypes []Count `json:"shapeTypes"`
+	Images     Images  `json:"images"`
+	// Fonts counts the text nodes using each font, formatted as
+	// "Family Style".
+	Fonts []Count `json:"fonts"`
+}
+
+// Count is the amount of items sharing a name. Lists of counts are sorted
+// from the most common name.
+type Count struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Page summarizes a page, and how the TikZ backend compiles it. Internal
+// pages are not compiled.
+type Page struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Internal bool   `json:"internal,omitempty"`
+	Nodes    int    `json:"nodes"`
+
+	// Rendered is the amount of nodes drawn by the TikZ backend, including
+	// those drawn as part of another, such as table cells. Skipped counts
+	// the remaining ones by the reason they were left out for.
+	Rendered int     `json:"rendered"`
+	Skipped  []Count `json:"skipped"`
+
+	DanglingConnectors []Dangling `json:"danglingConnectors"`
+}
+
+// Dangling is an end of a connector that is not attached to a node of the
+// page. Node is the ID of the node it refers to, and is empty for detached
+// ends.
+type Dangling struct {
+	ID   string `json:"id"`
+	End  string `json:"end"`
+	Node string `json:"node,omitempty"`
+}
+
+// Images describes the images referenced by image fills. Archived is the
+// amount of images held by the archive, and Unused the amount of those
+// never referenced.
+type Images struct {
+	Referenced []*Image `json:"referenced"`
+	Missing    int      `json:"missing"`
+	Archived   int      `json:"archived"`
+	Unused     int      `json:"unused"`
+}
+
+// Image is an image referenced by the fills of Uses nodes.
+type Image struct {
+	Hash   string `json:"hash"`
+	Format string `json:"format,omitempty"`
+	Size   int    `json:"size"`
+	Uses   int    `json:"uses"`
+}
+
+// Build summarizes doc, compiling each of its pages without writing any
+// output.
+func Build(doc *decoder.Document, opts *Options) (*Report, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	hash := sha256.Sum256(doc.Schema)
+	r := &Report{
+		File:               opts.FilePath,
+		Container:          string(doc.Container),
+		Version:            doc.Version,
+		Compression:        doc.Compression,
+		ArchiveCompression: doc.ArchiveCompression,
+		SchemaHash:         hex.EncodeToString(hash[:]),
+		Blobs:              len(doc.Blobs),
+		Pages:              []*Page{},
+	}
+
+	nodeTypes, shapeTypes, fonts := map[string]int{}, map[string]int{}, map[string]int{}
+	var nodes []*fig.NodeChange
+	if doc.Message != nil {
+		nodes = doc.Message.NodeChanges
+	}
+	for _, n := range nodes {
+		r.Nodes++
+		nodeTypes[enumName(n.Type, "NodeType")]++
+		if n.Type == fig.NodeTypeShapeWithText {
+			shapeTypes[enumName(n.ShapeWithTextType, "ShapeWithTextType")]++
+		}
+		for _, f := range nodeFonts(n) {
+			fonts[f]++
+		}
+	}
+	r.NodeTypes, r.ShapeTypes, r.Fonts = counts(nodeTypes), counts(shapeTypes), counts(fonts)
+	r.Images = images(doc, nodes)
+
+	for _, p := range doc.Root.Children {
+		if p.Type != fig.NodeTypeCanvas {
+			continue
+		}
+		page, err := inspectPage(doc, p, opts)
+		if err != nil {
+			return nil, err
+		}
+		r.Pages = append(r.Pages, page)
+	}
+	return r, nil
+}
+
+// enumName returns the name of the constant v holds, without the prefix
+// naming its type.
+func enumName(v any, prefix string) string {
+	if name, ok := fig.EnumName(v); ok {
+		return strings.TrimPrefix(name, prefix)
+	}
+	return fmt.Sprint(v)
+}
+
+func counts(m map[string]int) []Count {
+	res := make([]Count, 0, len(m))
+	for k, v := range m {
+		res = append(res, Count{Name: k, Count: v})
+	}
+	slices.SortFunc(res, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return res
+}
+
+// nodeFonts returns the fonts used by the text of n.
+func nodeFonts(n *fig.NodeChange) []string {
+	if n.TextData == nil {
+		return nil
+	}
+	var res []string
+	add := func(f *fig.FontName) {
+		if f == nil || f.Family == "" {
+			return
+		}
+		name := strings.TrimSpace(f.Family + " " + f.Style)
+		if !slices.Contains(res, name) {
+			res = append(res, name)
+		}
+	}
+	add(n.FontName)
+	for _, v := range n.TextData.StyleOverrideTable {
+		if v != nil {
+			add(v.FontName)
+		}
+	}
+	return res
+}
+
+func images(doc *decoder.Document, nodes []*fig.NodeChange) Images {
+	res := Images{Referenced: []*Image{}, Archived: len(doc.Images)}
+	byHash := map[string]*Image{}
+	for _, n := range nodes {
+		for _, p := range n.FillPaints {
+			if p == nil || p.Type != fig.PaintTypeImage || p.Image == nil {
+				continue
+			}
+			img, err := scene.ResolveImage(p.Image, doc.Images, doc.Blobs)
+			if err != nil {
+				res.Missing++
+				continue
+			}
+			v, ok := byHash[img.Hash]
+			if !ok {
+				v = &Image{Hash: img.Hash, Format: img.Format, Size: len(img.Data)}
+				byHash[img.Hash] = v
+				res.Referenced = append(res.Referenced, v)
+			}
+			v.Uses++
+		}
+	}
+	for hash := range doc.Images {
+		if byHash[hash] == nil {
+			res.Unused++
+		}
+	}
+	return res
+}
+
+func inspectPage(doc *decoder.Document, p *fig.NodeChange, opts *Options) (*Page, error) {
+	page := &Page{
+		ID:                 scene.GUIDKey(p.Guid),
+		Name:               p.Name,
+		Internal:           p.InternalOnly,
+		Skipped:            []Count{},
+		DanglingConnectors: []Dangling{},
+	}
+	var nodes []*fig.NodeChange
+	parents := map[*fig.NodeChange]*fig.NodeChange{}
+	var collect func(n *fig.NodeChange)
+	collect = func(n *fig.NodeChange) {
+		for _, v := range n.Children {
+			nodes = append(nodes, v)
+			parents[v] = n
+			collect(v)
+		}
+	}
+	collect(p)
+	page.Nodes = len(nodes)
+	if page.Internal {
+		return page, nil
+	}
+
+	s, err := scene.Build(doc, &scene.Options{Page: p})
+	if err != nil {
+		return nil, fmt.Errorf("unable to build scene for page %s: %w", page.Name, err)
+	}
+	drawn := map[*scene.Element]bool{}
+	for _, e := range tikz.Drawn(s, &tikz.CompilerOpts{OmitDecorations: opts.OmitDecorations, DryRun: true}) {
+		drawn[e] = true
+	}
+
+	element := func(n *fig.NodeChange) *scene.Element {
+		if n == nil || n.Guid == nil {
+			return nil
+		}
+		return s.Element(scene.GUIDKey(n.Guid))
+	}
+	skipped := map[string]int{}
+	for _, n := range nodes {
+		e := element(n)
+		switch {
+		case e == nil:
+			// Nodes held by elements other than containers, such as table
+			// cells, are drawn along with them.
+			parent := element(parents[n])
+			switch {
+			case parent == nil:
+				skipped["not in scene"]++
+			case drawn[parent]:
+				page.Rendered++
+			default:
+				skipped["part of "+parent.Kind.String()]++
+			}
+		case !drawn[e]:
+			skipped[e.Kind.String()]++
+		default:
+			page.Rendered++
+		}
+	}
+	page.Skipped = counts(skipped)
+
+	s.Walk(func(e *scene.Element) bool {
+		if e.Kind != scene.KindConnector || e.Connector == nil {
+			return true
+		}
+		for _, end := range []struct {
+			name string
+			v    scene.Endpoint
+		}{{"start", e.Connector.Start}, {"end", e.Connector.End}} {
+			if end.v.Element == nil {
+				page.DanglingConnectors = append(page.DanglingConnectors, Dangling{ID: e.ID, End: end.name, Node: end.v.ID})
+			}
+		}
+		return true
+	})
+	return page, nil
+}
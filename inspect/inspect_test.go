@@ -0,0 +1,121 @@
+package inspect
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene/scenetest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// document returns a file holding the test board, with a connector whose
+// ends are not attached to the page, a rectangle filled with a missing
+// image, and an internal page.
+func document() *decoder.Document {
+	page := scenetest.Page()
+	page.Children = append(page.Children,
+		&fig.NodeChange{
+			Guid: &fig.GUID{SessionId: 1, LocalId: 31}, Type: fig.NodeTypeConnector, Visible: true, Opacity: 1,
+			Transform:      &fig.Matrix{M00: 1, M11: 1},
+			ConnectorStart: &fig.ConnectorEndpoint{EndpointNodeId: &fig.GUID{SessionId: 1, LocalId: 99}},
+			ConnectorEnd:   &fig.ConnectorEndpoint{Position: &fig.Vector{X: 10}},
+		},
+		&fig.NodeChange{
+			Guid: &fig.GUID{SessionId: 1, LocalId: 60}, Type: fig.NodeTypeRectangle, Visible: true, Opacity: 1,
+			Transform: &fig.Matrix{M00: 1, M11: 1}, Size: &fig.Vector{X: 10, Y: 10},
+			FillPaints: []*fig.Paint{{Type: fig.PaintTypeImage, Visible: true, Opacity: 1, Image: &fig.Image{Hash: []byte{1, 2}}}},
+		})
+	internal := &fig.NodeChange{
+		Guid: &fig.GUID{LocalId: 2}, Type: fig.NodeTypeCanvas, Name: "Internal", InternalOnly: true,
+		Children: []*fig.NodeChange{{Guid: &fig.GUID{SessionId: 1, LocalId: 70}, Type: fig.NodeTypeText}},
+	}
+	root := &fig.NodeChange{Guid: &fig.GUID{}, Type: fig.NodeTypeDocument, Children: []*fig.NodeChange{page, internal}}
+
+	var nodes []*fig.NodeChange
+	var collect func(n *fig.NodeChange)
+	collect = func(n *fig.NodeChange) {
+		nodes = append(nodes, n)
+		for _, child := range n.Children {
+			collect(child)
+		}
+	}
+	collect(root)
+	return &decoder.Document{
+		Version:     48,
+		Container:   decoder.ContainerZip,
+		Root:        root,
+		Images:      map[string][]byte{"abcd": []byte("\x89PNG")},
+		Message:     &fig.Message{NodeChanges: nodes},
+		Compression: "zstd",
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r, err := Build(document(), &Options{FilePath: "board.jam", OmitDecorations: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Nodes != 14 || r.Container != "zip" || r.Compression != "zstd" {
+		t.Errorf("unexpected summary %+v", r)
+	}
+	if len(r.Pages) != 2 {
+		t.Fatalf("expected two pages, got %d", len(r.Pages))
+	}
+
+	page := r.Pages[0]
+	if page.Name != "Board" || page.Nodes != 10 {
+		t.Errorf("unexpected page %+v", page)
+	}
+	skipped := 0
+	for _, v := range page.Skipped {
+		skipped += v.Count
+	}
+	if page.Rendered+skipped != page.Nodes {
+		t.Errorf("expected every node to be rendered or skipped, got %d and %v", page.Rendered, page.Skipped)
+	}
+	if !reflect.DeepEqual(page.Skipped, []Count{{"stamp", 1}}) {
+		t.Errorf("expected the omitted stamp to be skipped, got %v", page.Skipped)
+	}
+	wantDangling := []Dangling{{ID: "1:31", End: "start", Node: "1:99"}, {ID: "1:31", End: "end"}}
+	if !reflect.DeepEqual(page.DanglingConnectors, wantDangling) {
+		t.Errorf("expected dangling ends %v, got %v", wantDangling, page.DanglingConnectors)
+	}
+	if p := r.Pages[1]; !p.Internal || p.Nodes != 1 || p.Rendered != 0 {
+		t.Errorf("expected the internal page to be counted, not compiled, got %+v", p)
+	}
+
+	if r.NodeTypes[0] != (Count{"Canvas", 2}) {
+		t.Errorf("expected the most common node types first, got %v", r.NodeTypes)
+	}
+	if !reflect.DeepEqual(r.ShapeTypes, []Count{{"Diamond", 1}}) {
+		t.Errorf("unexpected shape types %v", r.ShapeTypes)
+	}
+	if img := r.Images; img.Missing != 1 || img.Archived != 1 || img.Unused != 1 || len(img.Referenced) != 0 {
+		t.Errorf("unexpected images %+v", img)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	r, err := Build(document(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Board (0:1):",
+		"Internal (0:2, internal):",
+		"1:31, start attached to missing node 1:99",
+		"1:31, end detached",
+		"1 missing, 1 archived, 1 unused",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteJSON writes r as an indented JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes r as a human-readable report.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	line := func(format string, args ...any) {
+		_, _ = fmt.Fprintf(tw, format+"\n", args...)
+	}
+
+	if r.File != "" {
+		line("File:\t%s", r.File)
+	}
+	container := r.Container
+	if r.ArchiveCompression != "" {
+		container += fmt.Sprintf(" (canvas compressed with %s)", r.ArchiveCompression)
+	}
+	line("Container:\t%s", container)
+	line("Version:\t%d", r.Version)
+	line("Compression:\t%s", r.Compression)
+	line("Schema hash:\t%s", r.SchemaHash)
+	line("Nodes:\t%d, with %d blobs", r.Nodes, r.Blobs)
+
+	line("\nPages")
+	for _, p := range r.Pages {
+		if p.Internal {
+			line("  %s (%s, internal):\t%d nodes", p.Name, p.ID, p.Nodes)
+			continue
+		}
+		line("  %s (%s):\t%d nodes, %d rendered by TikZ, %d skipped", p.Name, p.ID, p.Nodes, p.Rendered, p.Nodes-p.Rendered)
+		if len(p.Skipped) > 0 {
+			parts := make([]string, len(p.Skipped))
+			for i, v := range p.Skipped {
+				parts[i] = fmt.Sprintf("%d %s", v.Count, v.Name)
+			}
+			line("    Skipped:\t%s", strings.Join(parts, ", "))
+		}
+		for _, d := range p.DanglingConnectors {
+			if d.Node == "" {
+				line("    Dangling connector:\t%s, %s detached", d.ID, d.End)
+			} else {
+				line("    Dangling connector:\t%s, %s attached to missing node %s", d.ID, d.End, d.Node)
+			}
+		}
+	}
+
+	writeCounts := func(title string, counts []Count) {
+		if len(counts) == 0 {
+			return
+		}
+		line("\n%s", title)
+		for _, v := range counts {
+			line("  %s\t%d", v.Name, v.Count)
+		}
+	}
+	writeCounts("Node types", r.NodeTypes)
+	writeCounts("Shape types", r.ShapeTypes)
+
+	img := r.Images
+	line("\nImages:\t%d referenced, %d missing, %d archived, %d unused", len(img.Referenced), img.Missing, img.Archived, img.Unused)
+	for _, v := range img.Referenced {
+		format := v.Format
+		if format == "" {
+			format = "unknown format"
+		}
+		uses := fmt.Sprintf("%d uses", v.Uses)
+		if v.Uses == 1 {
+			uses = "1 use"
+		}
+		line("  %s\t%s, %d bytes, %s", v.Hash, format, v.Size, uses)
+	}
+
+	writeCounts("Fonts", r.Fonts)
+	return tw.Flush()
+}
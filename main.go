@@ -1,13 +1,30 @@
 package main
 
 import (
-	"bytes"
+	"encoding/json"
 	"fmt"
+	"github.com/heyvito/figz/d2"
 	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/dot"
+	"github.com/heyvito/figz/drawio"
+	"github.com/heyvito/figz/dump"
+	"github.com/heyvito/figz/excalidraw"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graphjson"
+	"github.com/heyvito/figz/html"
+	"github.com/heyvito/figz/inspect"
+	"github.com/heyvito/figz/markdown"
+	"github.com/heyvito/figz/mermaid"
+	"github.com/heyvito/figz/plantuml"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/svg"
 	"github.com/heyvito/figz/tikz"
+	"github.com/heyvito/figz/typst"
 	"github.com/urfave/cli/v2"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -18,24 +35,201 @@ func expandTilde(path string) string {
 	return strings.Replace(path, "~", os.Getenv("HOME"), 1)
 }
 
+// parseRegion parses a rectangle formatted as x,y,w,h.
+func parseRegion(s string) (*scene.Rect, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("expected x,y,w,h, got %s", s)
+	}
+	values := make([]float64, 4)
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", p)
+		}
+		values[i] = v
+	}
+	if values[2] <= 0 || values[3] <= 0 {
+		return nil, fmt.Errorf("width and height must be positive")
+	}
+	return &scene.Rect{
+		Min: scene.Point{X: values[0], Y: values[1]},
+		Max: scene.Point{X: values[0] + values[2], Y: values[1] + values[3]},
+	}, nil
+}
+
 func main() {
 	app := cli.App{
 		Name:        "figz",
 		HelpName:    "figz",
 		Usage:       "figz [OPTIONS] PATH",
 		Version:     tikz.VERSION,
-		Description: "Converts figjam files into tikz pictures",
+		Description: "Converts figjam files into tikz pictures and other formats",
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:      "output",
-				Usage:     "Path to write the generated tikz picture",
+				Usage:     "Path to write the generated output",
 				Required:  false,
 				Value:     "",
 				Aliases:   []string{"o"},
 				TakesFile: true,
 			},
+			&cli.StringFlag{
+				Name:    "format",
+				Usage:   "Output format: tikz, svg, dot, mermaid, plantuml, d2, drawio, excalidraw, typst, markdown, html or graph-json",
+				Value:   "tikz",
+				Aliases: []string{"f"},
+			},
+			&cli.BoolFlag{
+				Name:  "link-images",
+				Usage: "Write image fills next to SVG output and link them instead of embedding them",
+			},
+			&cli.BoolFlag{
+				Name:  "positions",
+				Usage: "Keep the original node positions in DOT output, for rendering with neato -n",
+			},
+			&cli.StringFlag{
+				Name:  "code-highlighter",
+				Usage: "Package used to typeset code blocks: listings or minted",
+				Value: string(tikz.HighlighterListings),
+			},
+			&cli.StringFlag{
+				Name:      "mentions",
+				Usage:     "Path to a JSON object mapping user IDs to the names used for their mentions",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:  "standalone",
+				Usage: "Emit a complete standalone document instead of a bare tikzpicture or CeTZ canvas",
+			},
+			&cli.StringFlag{
+				Name:  "border",
+				Usage: "Border around the picture in standalone documents",
+				Value: "5pt",
+			},
+			&cli.StringFlag{
+				Name:      "style-file",
+				Usage:     "Path to a file with \\tikzset definitions overriding or extending the generated styles",
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:  "no-decorations",
+				Usage: "Omit highlights, washi tapes, stamps and emoji reactions",
+			},
+			&cli.BoolFlag{
+				Name:  "unicode-emoji",
+				Usage: "Typeset emoji in TikZ pictures, which requires XeLaTeX or LuaLaTeX; otherwise a star is drawn in their place",
+			},
+			&cli.Float64Flag{
+				Name:  "scale",
+				Usage: "Centimetres per canvas unit",
+				Value: float64(tikz.DefaultScale),
+			},
+			&cli.StringFlag{
+				Name:  "width",
+				Usage: "Scale the picture to fit the given width, such as 12cm",
+			},
+			&cli.StringFlag{
+				Name:  "height",
+				Usage: "Scale the picture to fit the given height, such as 8cm",
+			},
+			&cli.IntFlag{
+				Name:  "precision",
+				Usage: "Decimal places used when printing numbers",
+				Value: tikz.DefaultPrecision,
+			},
+			&cli.StringFlag{
+				Name:  "node",
+				Usage: "Compile only the children of the node with the given GUID, such as 1:23",
+			},
+			&cli.StringFlag{
+				Name:  "section",
+				Usage: "Compile only the contents of the section with the given name",
+			},
+			&cli.StringFlag{
+				Name:  "frame",
+				Usage: "Compile only the contents of the frame with the given name",
+			},
+			&cli.BoolFlag{
+				Name:  "clip",
+				Usage: "Clip the picture to the selected node, section, frame or region",
+			},
+			&cli.StringFlag{
+				Name:  "region",
+				Usage: "Compile only the nodes intersecting the x,y,w,h rectangle, in canvas units",
+			},
+			&cli.BoolFlag{
+				Name:  "viewport",
+				Usage: "Compile only the nodes intersecting the viewport saved with the file",
+			},
+			&cli.StringFlag{
+				Name:  "region-connectors",
+				Usage: "How connectors crossing the region's edge are handled: keep, drop or clip",
+				Value: string(scene.ConnectorsKeep),
+			},
 		},
 		Action: run,
+		Commands: []*cli.Command{
+			{
+				Name:      "dump",
+				Usage:     "Write the decoded contents of a file as JSON or YAML",
+				ArgsUsage: "PATH",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:      "output",
+						Usage:     "Path to write the generated output",
+						Aliases:   []string{"o"},
+						TakesFile: true,
+					},
+					&cli.StringFlag{
+						Name:    "format",
+						Usage:   "Output format: json or yaml",
+						Value:   string(dump.FormatJSON),
+						Aliases: []string{"f"},
+					},
+					&cli.StringFlag{
+						Name:  "node",
+						Usage: "Dump only the node with the given GUID, such as 1:23, along with its children",
+					},
+					&cli.IntFlag{
+						Name:  "depth",
+						Usage: "Levels of children included below the dumped node; negative values include all of them",
+						Value: -1,
+					},
+					&cli.StringFlag{
+						Name:  "fields",
+						Usage: "Comma-separated list of the only node fields to include, such as name,type,guid",
+					},
+					&cli.BoolFlag{
+						Name:  "all",
+						Usage: "Include zero-valued fields, and the fields recording which fields were present",
+					},
+				},
+				Action: runDump,
+			},
+			{
+				Name:      "inspect",
+				Usage:     "Summarize the contents of a file, and how it is compiled",
+				ArgsUsage: "PATH",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:      "output",
+						Usage:     "Path to write the report",
+						Aliases:   []string{"o"},
+						TakesFile: true,
+					},
+					&cli.BoolFlag{
+						Name:  "json",
+						Usage: "Write the report as JSON",
+					},
+					&cli.BoolFlag{
+						Name:  "no-decorations",
+						Usage: "Count highlights, washi tapes, stamps and emoji reactions as skipped",
+					},
+				},
+				Action: runInspect,
+			},
+		},
 		Authors: []*cli.Author{
 			{
 				Name:  "Vito Sartori",
@@ -59,6 +253,68 @@ func run(c *cli.Context) error {
 		return cli.ShowAppHelp(c)
 	}
 
+	format := c.String("format")
+	switch format {
+	case "tikz", "svg", "dot", "mermaid", "plantuml", "d2", "drawio", "excalidraw", "typst", "markdown", "html", "graph-json":
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid format %s: expected tikz, svg, dot, mermaid, plantuml, d2, drawio, excalidraw, typst, markdown, html or graph-json\n", format)
+		os.Exit(1)
+	}
+
+	highlighter := tikz.CodeHighlighter(c.String("code-highlighter"))
+	if highlighter != tikz.HighlighterListings && highlighter != tikz.HighlighterMinted {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid code highlighter %s: expected listings or minted\n", highlighter)
+		os.Exit(1)
+	}
+
+	var mentions map[string]string
+	if c.IsSet("mentions") {
+		data, err := os.ReadFile(expandTilde(c.String("mentions")))
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed reading mentions file: %v\n", err)
+			os.Exit(1)
+		}
+		if err = json.Unmarshal(data, &mentions); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed decoding mentions file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var styleOverrides string
+	if c.IsSet("style-file") {
+		data, err := os.ReadFile(expandTilde(c.String("style-file")))
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed reading style file: %v\n", err)
+			os.Exit(1)
+		}
+		styleOverrides = string(data)
+	}
+
+	var width, height float32
+	for _, name := range []string{"width", "height"} {
+		if !c.IsSet(name) {
+			continue
+		}
+		v, err := tikz.ParseLength(c.String(name))
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Invalid %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		if name == "width" {
+			width = v
+		} else {
+			height = v
+		}
+	}
+	if c.Float64("scale") <= 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid scale %f: expected a positive number\n", c.Float64("scale"))
+		os.Exit(1)
+	}
+	if c.Int("precision") < 1 {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid precision %d: expected at least 1\n", c.Int("precision"))
+		os.Exit(1)
+	}
+
 	input := expandTilde(c.Args().Get(0))
 	doc, err := decoder.Decode(input)
 	if err != nil {
@@ -66,9 +322,97 @@ func run(c *cli.Context) error {
 		os.Exit(1)
 	}
 
+	page := doc.Root.Children[1]
+	var root *fig.NodeChange
+	selectors := 0
+	for _, name := range []string{"node", "section", "frame"} {
+		if !c.IsSet(name) {
+			continue
+		}
+		selectors++
+		value := c.String(name)
+		switch name {
+		case "node":
+			root = scene.FindNode(page, value)
+		case "section":
+			root = scene.FindNamed(page, fig.NodeTypeSection, value)
+		case "frame":
+			root = scene.FindNamed(page, fig.NodeTypeFrame, value)
+		}
+		if root == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "No %s %s found\n", name, value)
+			os.Exit(1)
+		}
+	}
+	if selectors > 1 {
+		_, _ = fmt.Fprintf(os.Stderr, "Only one of --node, --section or --frame may be used\n")
+		os.Exit(1)
+	}
+
+	var region *scene.Rect
+	switch {
+	case c.IsSet("region") && c.Bool("viewport"):
+		_, _ = fmt.Fprintf(os.Stderr, "Only one of --region or --viewport may be used\n")
+		os.Exit(1)
+	case c.IsSet("region"):
+		region, err = parseRegion(c.String("region"))
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Invalid region: %v\n", err)
+			os.Exit(1)
+		}
+	case c.Bool("viewport"):
+		if root != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "--viewport cannot be combined with --node, --section or --frame\n")
+			os.Exit(1)
+		}
+		for _, v := range doc.Viewports {
+			if v.CanvasGuid == nil || (v.CanvasGuid.SessionId == page.Guid.SessionId && v.CanvasGuid.LocalId == page.Guid.LocalId) {
+				b := v.CanvasSpaceBounds
+				region = &scene.Rect{
+					Min: scene.Point{X: b.X, Y: b.Y},
+					Max: scene.Point{X: b.X + b.W, Y: b.Y + b.H},
+				}
+				break
+			}
+		}
+		if region == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "No viewport saved for the page\n")
+			os.Exit(1)
+		}
+	}
+
+	connectorMode := scene.ConnectorMode(c.String("region-connectors"))
+	switch connectorMode {
+	case scene.ConnectorsKeep, scene.ConnectorsDrop, scene.ConnectorsClip:
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid region connectors mode %s: expected keep, drop or clip\n", connectorMode)
+		os.Exit(1)
+	}
+
+	if c.Bool("clip") && root == nil && region == nil {
+		_, _ = fmt.Fprintf(os.Stderr, "--clip requires --node, --section, --frame, --region or --viewport\n")
+		os.Exit(1)
+	}
+
+	s, err := scene.Build(doc, &scene.Options{
+		Page:             page,
+		Root:             root,
+		Clip:             c.Bool("clip"),
+		Region:           region,
+		RegionConnectors: connectorMode,
+		Mentions:         mentions,
+	})
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed building scene: %v\n", err)
+		os.Exit(1)
+	}
+
 	var output io.WriteCloser
+	imageDir := "."
 	if c.IsSet("output") {
-		output, err = os.OpenFile(expandTilde(c.String("output")), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		outputPath := expandTilde(c.String("output"))
+		imageDir = filepath.Dir(outputPath)
+		output, err = os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 		if err != nil {
 			_, _ = fmt.Fprintf(os.Stderr, "Failed opening output file: %v\n", err)
 			os.Exit(1)
@@ -77,10 +421,183 @@ func run(c *cli.Context) error {
 		output = os.Stdout
 	}
 
-	str := tikz.NewCompiler(doc.Root.Children[1], &tikz.CompilerOpts{
-		FilePath: input,
+	var renderer scene.Renderer
+	switch format {
+	case "svg":
+		opts := &svg.Options{
+			FilePath:        input,
+			LinkImages:      c.Bool("link-images"),
+			ImageDir:        imageDir,
+			OmitDecorations: c.Bool("no-decorations"),
+		}
+		if c.IsSet("precision") {
+			opts.Precision = c.Int("precision")
+		}
+		renderer = &svg.Renderer{Opts: opts}
+	case "dot":
+		renderer = &dot.Renderer{Opts: &dot.Options{
+			FilePath:  input,
+			Positions: c.Bool("positions"),
+		}}
+	case "mermaid":
+		renderer = &mermaid.Renderer{Opts: &mermaid.Options{FilePath: input}}
+	case "plantuml":
+		renderer = &plantuml.Renderer{Opts: &plantuml.Options{FilePath: input}}
+	case "d2":
+		renderer = &d2.Renderer{Opts: &d2.Options{FilePath: input}}
+	case "drawio":
+		renderer = &drawio.Renderer{Opts: &drawio.Options{FilePath: input}}
+	case "excalidraw":
+		renderer = &excalidraw.Renderer{}
+	case "markdown":
+		renderer = &markdown.Renderer{Opts: &markdown.Options{FilePath: input}}
+	case "html":
+		renderer = &html.Renderer{Opts: &html.Options{FilePath: input}}
+	case "graph-json":
+		renderer = &graphjson.Renderer{Opts: &graphjson.Options{FilePath: input}}
+	case "typst":
+		renderer = &typst.Renderer{Opts: &typst.Options{
+			FilePath:        input,
+			ImageDir:        imageDir,
+			OmitDecorations: c.Bool("no-decorations"),
+			Standalone:      c.Bool("standalone"),
+			Border:          c.String("border"),
+			Scale:           c.Float64("scale"),
+			Width:           float64(width),
+			Height:          float64(height),
+			Precision:       c.Int("precision"),
+		}}
+	default:
+		renderer = &tikz.Renderer{Opts: &tikz.CompilerOpts{
+			FilePath:         input,
+			CodeHighlighter:  highlighter,
+			ImageDir:         imageDir,
+			OmitDecorations:  c.Bool("no-decorations"),
+			UnicodeEmoji:     c.Bool("unicode-emoji"),
+			Standalone:       c.Bool("standalone"),
+			StandaloneBorder: c.String("border"),
+			StyleOverrides:   styleOverrides,
+			Scale:            float32(c.Float64("scale")),
+			Width:            width,
+			Height:           height,
+			Precision:        c.Int("precision"),
+		}}
+	}
+	if r, ok := renderer.(*drawio.Renderer); ok && root == nil && region == nil {
+		// Without a selection, every page is written as a diagram of its
+		// own.
+		var pages []*scene.Scene
+		for _, p := range doc.Root.Children {
+			if p.Type != fig.NodeTypeCanvas || p.InternalOnly {
+				continue
+			}
+			ps, err := scene.Build(doc, &scene.Options{Page: p, Mentions: mentions})
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Failed building scene: %v\n", err)
+				os.Exit(1)
+			}
+			pages = append(pages, ps)
+		}
+		err = r.RenderPages(pages, output)
+	} else {
+		err = renderer.Render(s, output)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed writing output file: %v\n", err)
+	}
+	_ = output.Close()
+	return nil
+}
+
+func runDump(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	format := dump.Format(c.String("format"))
+	if format != dump.FormatJSON && format != dump.FormatYAML {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid format %s: expected json or yaml\n", format)
+		os.Exit(1)
+	}
+
+	input := expandTilde(c.Args().Get(0))
+	doc, err := decoder.Decode(input)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed decoding input file %s: %s\n", input, err)
+		os.Exit(1)
+	}
+
+	opts := &dump.Options{
+		Format: format,
+		Depth:  c.Int("depth"),
+		All:    c.Bool("all"),
+	}
+	if c.IsSet("fields") {
+		opts.Fields = strings.Split(c.String("fields"), ",")
+	}
+	d := dump.New(opts, doc.Blobs)
+
+	var v dump.Value
+	if c.IsSet("node") {
+		n := scene.FindNode(doc.Root, c.String("node"))
+		if n == nil {
+			_, _ = fmt.Fprintf(os.Stderr, "No node %s found\n", c.String("node"))
+			os.Exit(1)
+		}
+		v = d.Node(n)
+	} else {
+		v = d.Message(doc.Message)
+	}
+
+	output := io.WriteCloser(os.Stdout)
+	if c.IsSet("output") {
+		output, err = os.OpenFile(expandTilde(c.String("output")), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed opening output file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err = d.Write(v, output); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed writing output file: %v\n", err)
+	}
+	_ = output.Close()
+	return nil
+}
+
+func runInspect(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	input := expandTilde(c.Args().Get(0))
+	doc, err := decoder.Decode(input)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed decoding input file %s: %s\n", input, err)
+		os.Exit(1)
+	}
+
+	report, err := inspect.Build(doc, &inspect.Options{
+		FilePath:        input,
+		OmitDecorations: c.Bool("no-decorations"),
 	})
-	_, err = io.Copy(output, bytes.NewBufferString(str))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed inspecting input file %s: %s\n", input, err)
+		os.Exit(1)
+	}
+
+	output := io.WriteCloser(os.Stdout)
+	if c.IsSet("output") {
+		output, err = os.OpenFile(expandTilde(c.String("output")), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Failed opening output file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if c.Bool("json") {
+		err = report.WriteJSON(output)
+	} else {
+		err = report.WriteText(output)
+	}
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Failed writing output file: %v\n", err)
 	}
@@ -0,0 +1,159 @@
+package markdown
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/outline"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the text content of scenes as Markdown documents.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{doc: outline.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	doc  *outline.Document
+	opts *Options
+	b    strings.Builder
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+	`>`, `\>`,
+	`#`, `\#`,
+	`|`, `\|`,
+	"\r", "",
+)
+
+// Escape returns s with the characters Markdown would interpret escaped.
+// Line breaks are kept as hard breaks.
+func Escape(s string) string {
+	return strings.ReplaceAll(textEscaper.Replace(strings.TrimSpace(s)), "\n", "\\\n")
+}
+
+// cellEscaper keeps text on the single line table rows are made of.
+var cellEscaper = strings.NewReplacer("\\\n", "<br>")
+
+func (c *Compiler) line(format string, args ...any) {
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+// Compile returns the Markdown document.
+func (c *Compiler) Compile() string {
+	s := c.doc.Scene
+	c.line("<!-- This file was generated automatically by figz. https://github.com/heyvito/figz -->")
+	c.line("<!-- Input file: %s -->", scene.EscapeComment(c.opts.FilePath))
+	if root := s.Root; root != s.Page {
+		c.line("<!-- Root: %s (%s) -->", scene.EscapeComment(root.Name), scene.GUIDKey(root.Guid))
+	}
+	c.writeSection(c.doc.Root)
+	return c.b.String()
+}
+
+// item returns the text of i, linked to its URL and followed by its votes
+// and stamps. URLs with schemes other than http, https and mailto are left
+// out, keeping the text alone.
+func item(i *outline.Item) string {
+	text := Escape(i.Text)
+	if scene.SafeURL(i.URL) {
+		text = "[" + text + "](" + strings.ReplaceAll(i.URL, ")", "%29") + ")"
+	}
+	if tally := i.Tally(); tally != "" {
+		text += " (" + tally + ")"
+	}
+	return text
+}
+
+func (c *Compiler) writeSection(sec *outline.Section) {
+	c.line("")
+	c.line("%s %s", strings.Repeat("#", min(sec.Level, 6)), Escape(sec.Title))
+	for _, b := range sec.Blocks {
+		c.line("")
+		switch b.Kind {
+		case outline.BlockParagraph, outline.BlockLink:
+			c.line("%s", item(b.Items[0]))
+		case outline.BlockStickies:
+			c.line("**%s stickies**", strings.ToUpper(b.Color[:1])+b.Color[1:])
+			c.line("")
+			for _, v := range b.Items {
+				c.line("- %s", strings.ReplaceAll(item(v), "\n", "\n  "))
+			}
+		case outline.BlockTable:
+			c.writeTable(b.Rows)
+		case outline.BlockCode:
+			c.writeCode(b)
+		}
+	}
+	if len(sec.Connections) > 0 {
+		c.line("")
+		c.line("**Connections**")
+		c.line("")
+		for _, v := range sec.Connections {
+			c.line("- %s", Escape(v.String()))
+		}
+	}
+	for _, v := range sec.Sections {
+		c.writeSection(v)
+	}
+}
+
+func (c *Compiler) writeTable(rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	for i, row := range rows {
+		cells := make([]string, columns)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = cellEscaper.Replace(Escape(row[j]))
+			}
+		}
+		c.line("| %s |", strings.Join(cells, " | "))
+		if i == 0 {
+			c.line("|%s", strings.Repeat(" --- |", columns))
+		}
+	}
+}
+
+func (c *Compiler) writeCode(b *outline.Block) {
+	code := strings.TrimRight(b.Items[0].Text, "\n")
+	// The fence must be longer than any run of backticks in the code.
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	c.line("%s%s", fence, b.Language)
+	c.line("%s", code)
+	c.line("%s", fence)
+	if tally := b.Items[0].Tally(); tally != "" {
+		c.line("")
+		c.line("(%s)", tally)
+	}
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/outline"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Renderer{Opts: &Options{FilePath: "board.jam"}}
+	if err := r.Render(scenetest.Scene(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	scenetest.Golden(t, "board.md", buf.Bytes())
+
+	for _, want := range []string{"# Board\n", "## Ideas\n", "- Ship it (1 vote)\n", "```go\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	for in, want := range map[string]string{
+		"# not a title": `\# not a title`,
+		"a | b":         `a \| b`,
+		"two\nlines":    "two\\\nlines",
+		"  padded  ":    "padded",
+	} {
+		if got := Escape(in); got != want {
+			t.Errorf("Escape(%q) = %q, expected %q", in, got, want)
+		}
+	}
+}
+
+func TestItemLinks(t *testing.T) {
+	if got := item(&outline.Item{Text: "Docs", URL: "https://example.com/a_(b)"}); got != "[Docs](https://example.com/a_(b%29)" {
+		t.Errorf("unexpected link %q", got)
+	}
+	if got := item(&outline.Item{Text: "Docs", URL: "javascript:alert(1)"}); got != "Docs" {
+		t.Errorf("expected unsafe URLs to be left out, got %q", got)
+	}
+}
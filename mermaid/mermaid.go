@@ -0,0 +1,294 @@
+package mermaid
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the graph of shapes and connectors of scenes as Mermaid
+// flowcharts.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{graph: graph.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	graph  *graph.Graph
+	opts   *Options
+	b      strings.Builder
+	indent int
+	styles []string
+	links  int
+}
+
+// nodeShape holds the delimiters enclosing the label of a node.
+type nodeShape struct {
+	open, close string
+}
+
+var nodeShapes = map[fig.ShapeWithTextType]nodeShape{
+	fig.ShapeWithTextTypeSquare:             {"[", "]"},
+	fig.ShapeWithTextTypeRoundedRectangle:   {"(", ")"},
+	fig.ShapeWithTextTypeEllipse:            {"((", "))"},
+	fig.ShapeWithTextTypeDiamond:            {"{", "}"},
+	fig.ShapeWithTextTypeParallelogramRight: {"[/", "/]"},
+	fig.ShapeWithTextTypeParallelogramLeft:  {`[\`, `\]`},
+	fig.ShapeWithTextTypeEngDatabase:        {"[(", ")]"},
+	fig.ShapeWithTextTypeTrapezoid:          {"[/", `\]`},
+	fig.ShapeWithTextTypePredefinedProcess:  {"[[", "]]"},
+	fig.ShapeWithTextTypeHexagon:            {"{{", "}}"},
+	fig.ShapeWithTextTypeChevron:            {">", "]"},
+	fig.ShapeWithTextTypeArrowRight:         {">", "]"},
+	fig.ShapeWithTextTypeSummingJunction:    {"((", "))"},
+	fig.ShapeWithTextTypeOr:                 {"((", "))"},
+}
+
+var shapeNames = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeTriangleUp:       "triangle",
+	fig.ShapeWithTextTypeTriangleDown:     "triangle",
+	fig.ShapeWithTextTypeEngQueue:         "queue",
+	fig.ShapeWithTextTypeEngFile:          "file",
+	fig.ShapeWithTextTypeEngFolder:        "folder",
+	fig.ShapeWithTextTypeShield:           "shield",
+	fig.ShapeWithTextTypeDocumentSingle:   "document",
+	fig.ShapeWithTextTypeDocumentMultiple: "document",
+	fig.ShapeWithTextTypeManualInput:      "manual input",
+	fig.ShapeWithTextTypePentagon:         "pentagon",
+	fig.ShapeWithTextTypeOctagon:          "octagon",
+	fig.ShapeWithTextTypeStar:             "star",
+	fig.ShapeWithTextTypePlus:             "plus",
+	fig.ShapeWithTextTypeArrowLeft:        "left arrow",
+	fig.ShapeWithTextTypeSpeechBubble:     "speech bubble",
+	fig.ShapeWithTextTypeInternalStorage:  "internal storage",
+}
+
+var kindShapes = map[scene.Kind]nodeShape{
+	scene.KindEllipse: {"((", "))"},
+	scene.KindStar:    {"{{", "}}"},
+}
+
+var labelEscaper = strings.NewReplacer(
+	`"`, "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"\r", "",
+	"\n", "<br>",
+)
+
+// Quote returns s as a quoted Mermaid label.
+func Quote(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("    ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+func (c *Compiler) warn(format string, args ...any) {
+	c.line("%%%% %s", graph.Warning(format, args...))
+}
+
+var directions = map[scene.Side]string{
+	scene.SideRight:  "LR",
+	scene.SideLeft:   "RL",
+	scene.SideBottom: "TD",
+	scene.SideTop:    "BT",
+}
+
+// Direction returns the direction the flowchart is laid out in, following
+// the one most connectors run in.
+func Direction(g *graph.Graph) string {
+	return directions[g.Flow()]
+}
+
+// Compile returns the Mermaid flowchart for the scene.
+func (c *Compiler) Compile() string {
+	s := c.graph.Scene
+	c.line("%%%% This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.line("%%%% Input file: %s", c.opts.FilePath)
+	if root := s.Root; root != s.Page {
+		c.line("%%%% Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+	}
+	c.line("flowchart %s", Direction(c.graph))
+	c.indent++
+	for _, v := range c.graph.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range c.graph.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range c.graph.Edges {
+		c.writeEdge(e)
+	}
+	for _, v := range c.styles {
+		c.line("%s", v)
+	}
+	c.indent--
+	return c.b.String()
+}
+
+// writeCluster writes sections and frames as subgraphs. Groups do not
+// outline their content, so their nodes are written in place.
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	group := v.Kind == scene.KindGroup
+	if !group {
+		c.line("subgraph %s [%s]", graph.SafeID(v.ID), Quote(v.Label))
+		c.indent++
+		if v.Fill != nil {
+			c.styles = append(c.styles, fmt.Sprintf("style %s fill:%s", graph.SafeID(v.ID), v.Fill.Hex()))
+		}
+	}
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+	if !group {
+		c.indent--
+		c.line("end")
+	}
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	id := graph.SafeID(n.ID)
+	shape := nodeShape{"[", "]"}
+	switch n.Kind {
+	case scene.KindShape:
+		if v, ok := nodeShapes[n.Shape]; ok {
+			shape = v
+		} else {
+			c.warn("%s is a %s, which Mermaid cannot draw", id, shapeNames[n.Shape])
+		}
+	case scene.KindLine, scene.KindVector:
+		c.warn("%s is a %s, drawn as a box", id, n.Kind)
+	case scene.KindOther:
+		c.warn("%s holds an image, which is not exported", id)
+	default:
+		if v, ok := kindShapes[n.Kind]; ok {
+			shape = v
+		}
+	}
+	c.line("%s%s%s%s", id, shape.open, Quote(n.Label), shape.close)
+
+	var style []string
+	switch {
+	case n.Kind == scene.KindText:
+		style = append(style, "fill:none", "stroke:none")
+		if n.Fill != nil {
+			style = append(style, "color:"+n.Fill.Hex())
+		}
+	case n.Fill != nil:
+		style = append(style, "fill:"+n.Fill.Hex())
+		if n.Fill.IsDark() {
+			style = append(style, "color:#ffffff")
+		}
+	}
+	if n.Stroke != nil && n.Kind != scene.KindText {
+		style = append(style, "stroke:"+n.Stroke.Hex())
+	}
+	if len(style) > 0 {
+		c.styles = append(c.styles, fmt.Sprintf("style %s %s", id, strings.Join(style, ",")))
+	}
+}
+
+// endpoint returns the identifier of the node or subgraph an edge end is
+// attached to, or an empty string when it is not part of the flowchart.
+func (c *Compiler) endpoint(end graph.Endpoint) string {
+	switch {
+	case end.Node != nil:
+		return graph.SafeID(end.Node.ID)
+	case end.Cluster != nil && end.Cluster.Kind != scene.KindGroup:
+		return graph.SafeID(end.Cluster.ID)
+	case end.Cluster != nil && end.Cluster.FirstNode() != nil:
+		return graph.SafeID(end.Cluster.FirstNode().ID)
+	}
+	return ""
+}
+
+// tip returns the character drawing cap at the end of a link, and whether
+// Mermaid can draw it.
+func tip(cap fig.StrokeCap, start bool) (string, bool) {
+	switch cap {
+	case fig.StrokeCapArrowLines, fig.StrokeCapArrowEquilateral, fig.StrokeCapTriangleFilled:
+		if start {
+			return "<", true
+		}
+		return ">", true
+	case fig.StrokeCapCircleFilled:
+		return "o", true
+	case fig.StrokeCapDiamondFilled:
+		if start {
+			return "<", false
+		}
+		return ">", false
+	}
+	return "", true
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	source, target := c.endpoint(e.Source), c.endpoint(e.Target)
+	if source == "" || target == "" {
+		c.warn("connector %s is not attached to both ends, and is omitted", e.ID)
+		return
+	}
+
+	tail, ok := tip(e.StartCap, true)
+	if !ok {
+		c.warn("the start of connector %s is drawn as an arrow", e.ID)
+	}
+	head, ok := tip(e.EndCap, false)
+	if !ok {
+		c.warn("the end of connector %s is drawn as an arrow", e.ID)
+	}
+	switch {
+	case head == "" && tail != "":
+		// Mermaid only draws tips at the start of links carrying one at
+		// their end too, so the link is reversed.
+		source, target = target, source
+		head, tail = strings.ReplaceAll(tail, "<", ">"), ""
+	case tail != "" && strings.ReplaceAll(tail, "<", ">") != head:
+		// Both tips of a link are drawn the same way, so links with
+		// different ones only keep an arrow at their end.
+		c.warn("connector %s has different tips, drawn as an arrow", e.ID)
+		head, tail = ">", ""
+	}
+	body := "--"
+	if e.Dashed {
+		body = "-.-"
+	}
+	if head == "" && !e.Dashed {
+		// Links without a head are drawn as three dashes.
+		head = "-"
+	}
+	link := tail + body + head
+
+	if e.Label != "" {
+		c.line("%s %s|%s| %s", source, link, Quote(e.Label), target)
+	} else {
+		c.line("%s %s %s", source, link, target)
+	}
+	if e.Color != nil {
+		c.styles = append(c.styles, fmt.Sprintf("linkStyle %d stroke:%s", c.links, e.Color.Hex()))
+	}
+	c.links++
+}
@@ -0,0 +1,116 @@
+package mermaid
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, s *scene.Scene) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: &Options{FilePath: "board.jam"}}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, scenetest.Scene(t))
+	scenetest.Golden(t, "board.mmd", []byte(out))
+
+	for _, want := range []string{
+		// The only connector runs from right to left.
+		"flowchart RL",
+		`n1_40["Retro #lt;2024#gt;"]`,
+		`n1_12["Write #quot;tests#quot; & docs"]`,
+		`n1_20 -->|"yes"| n1_11`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got, want := Quote("a <b>\n\"c\""), `"a #lt;b#gt;<br>#quot;c#quot;"`; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+// page returns two boxes, the second one placed at (x, y), connected by a
+// connector with the given tips.
+func page(x, y float64, start, end fig.StrokeCap) *fig.NodeChange {
+	box := func(local uint, x, y float64) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: &fig.GUID{SessionId: 1, LocalId: local}, Type: fig.NodeTypeShapeWithText, Visible: true, Opacity: 1,
+			Transform: &fig.Matrix{M00: 1, M11: 1, M02: x, M12: y}, Size: &fig.Vector{X: 100, Y: 100},
+			ShapeWithTextType: fig.ShapeWithTextTypeSquare,
+		}
+	}
+	return &fig.NodeChange{Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{
+		box(1, 0, 0),
+		box(2, x, y),
+		{
+			Guid: &fig.GUID{SessionId: 1, LocalId: 3}, Type: fig.NodeTypeConnector, Visible: true, Opacity: 1,
+			Transform:         &fig.Matrix{M00: 1, M11: 1},
+			ConnectorStart:    &fig.ConnectorEndpoint{EndpointNodeId: &fig.GUID{SessionId: 1, LocalId: 1}},
+			ConnectorEnd:      &fig.ConnectorEndpoint{EndpointNodeId: &fig.GUID{SessionId: 1, LocalId: 2}},
+			ConnectorStartCap: start,
+			ConnectorEndCap:   end,
+		},
+	}}
+}
+
+func build(t *testing.T, page *fig.NodeChange) *scene.Scene {
+	t.Helper()
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestDirection(t *testing.T) {
+	for _, tc := range []struct {
+		x, y float64
+		want string
+	}{
+		{300, 0, "LR"},
+		{-300, 0, "RL"},
+		{0, 300, "TD"},
+		{0, -300, "BT"},
+	} {
+		if got := Direction(graph.Build(build(t, page(tc.x, tc.y, fig.StrokeCapNone, fig.StrokeCapArrowLines)))); got != tc.want {
+			t.Errorf("box at %v,%v: expected %s, got %s", tc.x, tc.y, tc.want, got)
+		}
+	}
+}
+
+func TestTips(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		start, end fig.StrokeCap
+		want       string
+	}{
+		{"plain", fig.StrokeCapNone, fig.StrokeCapNone, "n1_1 --- n1_2"},
+		{"end", fig.StrokeCapNone, fig.StrokeCapArrowLines, "n1_1 --> n1_2"},
+		{"both", fig.StrokeCapArrowLines, fig.StrokeCapArrowLines, "n1_1 <--> n1_2"},
+		{"circles", fig.StrokeCapCircleFilled, fig.StrokeCapCircleFilled, "n1_1 o--o n1_2"},
+		{"start", fig.StrokeCapArrowLines, fig.StrokeCapNone, "n1_2 --> n1_1"},
+		{"mixed", fig.StrokeCapCircleFilled, fig.StrokeCapArrowLines, "n1_1 --> n1_2"},
+		{"mixed reversed", fig.StrokeCapArrowLines, fig.StrokeCapCircleFilled, "n1_1 --> n1_2"},
+	} {
+		out := render(t, build(t, page(300, 0, tc.start, tc.end)))
+		if !strings.Contains(out, "\n    "+tc.want+"\n") {
+			t.Errorf("%s: expected %q, got:\n%s", tc.name, tc.want, out)
+		}
+		if strings.HasPrefix(tc.name, "mixed") && !strings.Contains(out, "has different tips") {
+			t.Errorf("%s: expected a warning, got:\n%s", tc.name, out)
+		}
+	}
+}
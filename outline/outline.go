@@ -0,0 +1,268 @@
+package outline
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"slices"
+	"strings"
+)
+
+// Document is the text content of a scene, arranged as a document whose
+// sections follow the containers of the board.
+type Document struct {
+	Scene *scene.Scene
+	Root  *Section
+}
+
+// Section is the page, or a section or frame of the board. Blocks are in
+// reading order, and followed by the sections nested within it.
+type Section struct {
+	ID    string
+	Title string
+	// Level is the depth of the section, starting from 1 for the page.
+	Level       int
+	Blocks      []*Block
+	Connections []*Connection
+	Sections    []*Section
+}
+
+type BlockKind int
+
+const (
+	BlockParagraph BlockKind = iota
+	BlockStickies
+	BlockTable
+	BlockCode
+	BlockLink
+)
+
+// Block is a piece of content of a section. Paragraphs, code blocks and
+// links hold a single item, and sticky groups one for each sticky of their
+// color.
+type Block struct {
+	Kind  BlockKind
+	Color string
+	Items []*Item
+	// Rows holds the text of the cells of tables, starting from their
+	// header.
+	Rows [][]string
+	// Language is the name of the language of code blocks, if known.
+	Language string
+}
+
+// Item is the text of an element, along with the amount of votes and
+// stamps placed on it.
+type Item struct {
+	ID     string
+	Text   string
+	URL    string
+	Votes  int
+	Stamps int
+}
+
+// Connection is a connector between two elements, described by their text.
+// Ends not attached to an element are empty.
+type Connection struct {
+	ID    string
+	From  string
+	To    string
+	Label string
+}
+
+type builder struct {
+	scene  *scene.Scene
+	votes  map[string]int
+	stamps map[string]int
+}
+
+// Build arranges the text content of s as a document, following the tree
+// of nodes under the scene's root.
+func Build(s *scene.Scene) *Document {
+	b := &builder{scene: s, votes: map[string]int{}, stamps: map[string]int{}}
+	s.Walk(func(e *scene.Element) bool {
+		if e.Kind != scene.KindStamp || e.Host == nil {
+			return true
+		}
+		if scene.IsVote(e.Node) {
+			b.votes[e.Host.ID]++
+		} else {
+			b.stamps[e.Host.ID]++
+		}
+		return true
+	})
+
+	root := &Section{ID: scene.GUIDKey(s.Root.Guid), Title: s.Root.Name, Level: 1}
+	b.fill(root, s.Root.Children)
+	return &Document{Scene: s, Root: root}
+}
+
+// fill adds the content of nodes to sec. Groups, and containers left out
+// of the scene, have their content added to the section holding them.
+func (b *builder) fill(sec *Section, nodes []*fig.NodeChange) {
+	var elements []*scene.Element
+	var collect func(nodes []*fig.NodeChange)
+	collect = func(nodes []*fig.NodeChange) {
+		for _, n := range nodes {
+			e := b.scene.Element(scene.GUIDKey(n.Guid))
+			switch {
+			case e == nil || e.Kind == scene.KindGroup:
+				collect(n.Children)
+			case e.Kind == scene.KindConnector:
+				sec.Connections = append(sec.Connections, b.connection(e))
+			default:
+				elements = append(elements, e)
+			}
+		}
+	}
+	collect(nodes)
+
+	stickies := map[string]*Block{}
+	for _, e := range readingOrder(elements) {
+		switch e.Kind {
+		case scene.KindSection, scene.KindFrame:
+			sub := &Section{ID: e.ID, Title: e.Node.Name, Level: sec.Level + 1}
+			b.fill(sub, e.Node.Children)
+			sec.Sections = append(sec.Sections, sub)
+		case scene.KindSticky:
+			color := scene.StickyColorName(e.Style.Fill)
+			group, ok := stickies[color]
+			if !ok {
+				group = &Block{Kind: BlockStickies, Color: color}
+				stickies[color] = group
+				sec.Blocks = append(sec.Blocks, group)
+			}
+			group.Items = append(group.Items, b.item(e, e.Text.String()))
+		case scene.KindText, scene.KindShape:
+			if text := strings.TrimSpace(e.Text.String()); text != "" {
+				sec.Blocks = append(sec.Blocks, &Block{Kind: BlockParagraph, Items: []*Item{b.item(e, text)}})
+			}
+		case scene.KindTable:
+			sec.Blocks = append(sec.Blocks, b.table(e))
+		case scene.KindCodeBlock:
+			language, _ := scene.CodeLanguage(e.Node.CodeBlockLanguage)
+			sec.Blocks = append(sec.Blocks, &Block{
+				Kind:     BlockCode,
+				Items:    []*Item{b.item(e, e.Text.String())},
+				Language: language,
+			})
+		case scene.KindLinkPreview:
+			data := e.Node.LinkPreviewData
+			title := data.Title
+			if title == "" {
+				title = data.Url
+			}
+			item := b.item(e, title)
+			item.URL = data.Url
+			sec.Blocks = append(sec.Blocks, &Block{Kind: BlockLink, Items: []*Item{item}})
+		}
+	}
+}
+
+func (b *builder) item(e *scene.Element, text string) *Item {
+	return &Item{ID: e.ID, Text: text, URL: e.Link, Votes: b.votes[e.ID], Stamps: b.stamps[e.ID]}
+}
+
+func (b *builder) table(e *scene.Element) *Block {
+	block := &Block{Kind: BlockTable}
+	for _, row := range e.Table.Cells {
+		texts := make([]string, len(row))
+		for i, cell := range row {
+			if cell != nil {
+				texts[i] = cell.Text.String()
+			}
+		}
+		block.Rows = append(block.Rows, texts)
+	}
+	return block
+}
+
+// describe returns the text an element is referred to by.
+func describe(e *scene.Element) string {
+	if e == nil {
+		return ""
+	}
+	if text := strings.Join(strings.Fields(e.Text.String()), " "); text != "" {
+		return text
+	}
+	if e.Node.Name != "" {
+		return e.Node.Name
+	}
+	return e.Kind.String()
+}
+
+func (b *builder) connection(e *scene.Element) *Connection {
+	conn := e.Connector
+	return &Connection{
+		ID:    e.ID,
+		From:  describe(conn.Start.Element),
+		To:    describe(conn.End.Element),
+		Label: strings.Join(strings.Fields(conn.Label.String()), " "),
+	}
+}
+
+// readingOrder returns elements arranged in rows, from top to bottom, and
+// from left to right within each row. An element belongs to the row above
+// when it starts before the middle of that row's first element.
+func readingOrder(elements []*scene.Element) []*scene.Element {
+	sorted := slices.Clone(elements)
+	slices.SortStableFunc(sorted, func(a, b *scene.Element) int {
+		return cmp.Compare(a.Bounds.Min.Y, b.Bounds.Min.Y)
+	})
+
+	var rows [][]*scene.Element
+	for _, e := range sorted {
+		if n := len(rows); n > 0 && e.Bounds.Min.Y < rows[n-1][0].Bounds.Center().Y {
+			rows[n-1] = append(rows[n-1], e)
+			continue
+		}
+		rows = append(rows, []*scene.Element{e})
+	}
+
+	res := make([]*scene.Element, 0, len(sorted))
+	for _, row := range rows {
+		slices.SortStableFunc(row, func(a, b *scene.Element) int {
+			return cmp.Compare(a.Bounds.Min.X, b.Bounds.Min.X)
+		})
+		res = append(res, row...)
+	}
+	return res
+}
+
+// Tally describes the votes and stamps placed on an item, such as
+// "2 votes, 1 stamp", or returns an empty string when there are none.
+func (i *Item) Tally() string {
+	var parts []string
+	if i.Votes > 0 {
+		parts = append(parts, plural(i.Votes, "vote"))
+	}
+	if i.Stamps > 0 {
+		parts = append(parts, plural(i.Stamps, "stamp"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// String describes the connection as "A → B (label)". Unattached ends are
+// shown as a question mark.
+func (c *Connection) String() string {
+	from, to := c.From, c.To
+	if from == "" {
+		from = "?"
+	}
+	if to == "" {
+		to = "?"
+	}
+	s := from + " → " + to
+	if c.Label != "" {
+		s += " (" + c.Label + ")"
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package outline
+
+import (
+	"github.com/heyvito/figz/scene/scenetest"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	doc := Build(scenetest.Scene(t))
+	root := doc.Root
+	if root.ID != "0:1" || root.Title != "Board" || root.Level != 1 {
+		t.Fatalf("expected the page to be the root section, got %+v", root)
+	}
+	var texts []string
+	for _, b := range root.Blocks {
+		texts = append(texts, b.Items[0].Text)
+	}
+	// Blocks are read from top to bottom, then from left to right.
+	if want := []string{"Retro <2024>", "Decide", "fmt.Println(\"hi\")"}; len(texts) != len(want) || texts[0] != want[0] || texts[1] != want[1] {
+		t.Errorf("expected blocks %q, got %q", want, texts)
+	}
+	if b := root.Blocks[2]; b.Kind != BlockCode || b.Language != "go" {
+		t.Errorf("expected a Go code block, got %+v", b)
+	}
+	if len(root.Connections) != 1 || root.Connections[0].String() != "Decide → Ship it (yes)" {
+		t.Errorf("expected the connector to be described, got %v", root.Connections)
+	}
+
+	if len(root.Sections) != 1 {
+		t.Fatalf("expected the section to be nested, got %d sections", len(root.Sections))
+	}
+	ideas := root.Sections[0]
+	if ideas.Title != "Ideas" || ideas.Level != 2 || len(ideas.Blocks) != 1 {
+		t.Fatalf("unexpected section %+v", ideas)
+	}
+	stickies := ideas.Blocks[0]
+	if stickies.Kind != BlockStickies || stickies.Color != "yellow" || len(stickies.Items) != 2 {
+		t.Fatalf("expected the stickies to be grouped by color, got %+v", stickies)
+	}
+	if got := stickies.Items[0].Tally(); got != "1 vote" {
+		t.Errorf("expected the vote to be tallied, got %q", got)
+	}
+}
+
+func TestTally(t *testing.T) {
+	for _, tt := range []struct {
+		item Item
+		want string
+	}{
+		{Item{}, ""},
+		{Item{Votes: 2}, "2 votes"},
+		{Item{Votes: 1, Stamps: 3}, "1 vote, 3 stamps"},
+	} {
+		if got := tt.item.Tally(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestConnectionString(t *testing.T) {
+	if got := (&Connection{From: "A"}).String(); got != "A → ?" {
+		t.Errorf("expected unattached ends to be shown as question marks, got %q", got)
+	}
+}
@@ -0,0 +1,263 @@
+package plantuml
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/graph"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"strings"
+)
+
+type Options struct {
+	FilePath string
+}
+
+// Renderer renders the graph of shapes and connectors of scenes as
+// PlantUML component diagrams.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{graph: graph.Build(s), opts: opts}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	graph  *graph.Graph
+	opts   *Options
+	b      strings.Builder
+	indent int
+}
+
+var nodeShapes = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeSquare:            "rectangle",
+	fig.ShapeWithTextTypeRoundedRectangle:  "card",
+	fig.ShapeWithTextTypeEllipse:           "usecase",
+	fig.ShapeWithTextTypeEngDatabase:       "database",
+	fig.ShapeWithTextTypeEngQueue:          "queue",
+	fig.ShapeWithTextTypeEngFile:           "file",
+	fig.ShapeWithTextTypeEngFolder:         "folder",
+	fig.ShapeWithTextTypePredefinedProcess: "component",
+	fig.ShapeWithTextTypeDocumentSingle:    "artifact",
+	fig.ShapeWithTextTypeDocumentMultiple:  "collections",
+	fig.ShapeWithTextTypeHexagon:           "hexagon",
+	fig.ShapeWithTextTypeSummingJunction:   "circle",
+	fig.ShapeWithTextTypeOr:                "circle",
+	fig.ShapeWithTextTypeInternalStorage:   "storage",
+}
+
+var shapeNames = map[fig.ShapeWithTextType]string{
+	fig.ShapeWithTextTypeDiamond:            "diamond",
+	fig.ShapeWithTextTypeTriangleUp:         "triangle",
+	fig.ShapeWithTextTypeTriangleDown:       "triangle",
+	fig.ShapeWithTextTypeParallelogramRight: "parallelogram",
+	fig.ShapeWithTextTypeParallelogramLeft:  "parallelogram",
+	fig.ShapeWithTextTypeTrapezoid:          "trapezoid",
+	fig.ShapeWithTextTypeShield:             "shield",
+	fig.ShapeWithTextTypeManualInput:        "manual input",
+	fig.ShapeWithTextTypeChevron:            "chevron",
+	fig.ShapeWithTextTypePentagon:           "pentagon",
+	fig.ShapeWithTextTypeOctagon:            "octagon",
+	fig.ShapeWithTextTypeStar:               "star",
+	fig.ShapeWithTextTypePlus:               "plus",
+	fig.ShapeWithTextTypeArrowLeft:          "left arrow",
+	fig.ShapeWithTextTypeArrowRight:         "right arrow",
+	fig.ShapeWithTextTypeSpeechBubble:       "speech bubble",
+}
+
+var kindShapes = map[scene.Kind]string{
+	scene.KindSticky:      "card",
+	scene.KindText:        "label",
+	scene.KindTable:       "rectangle",
+	scene.KindCodeBlock:   "rectangle",
+	scene.KindLinkPreview: "rectangle",
+	scene.KindRectangle:   "rectangle",
+	scene.KindEllipse:     "usecase",
+}
+
+// tips holds the characters drawing caps at the end of links, pointing
+// towards their target.
+var tips = map[fig.StrokeCap]string{
+	fig.StrokeCapArrowLines:       ">",
+	fig.StrokeCapArrowEquilateral: ">",
+	fig.StrokeCapTriangleFilled:   ">",
+	fig.StrokeCapDiamondFilled:    "*",
+	fig.StrokeCapCircleFilled:     "0",
+}
+
+var labelEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, "<U+0022>",
+	"\r", "",
+	"\n", `\n`,
+)
+
+// Quote returns s as a quoted PlantUML label.
+func Quote(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+func (c *Compiler) warn(format string, args ...any) {
+	c.line("' %s", graph.Warning(format, args...))
+}
+
+// Compile returns the PlantUML diagram for the scene.
+func (c *Compiler) Compile() string {
+	s := c.graph.Scene
+	c.line("' This file was generated automatically by figz. https://github.com/heyvito/figz")
+	c.line("' Input file: %s", c.opts.FilePath)
+	if root := s.Root; root != s.Page {
+		c.line("' Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
+	}
+	c.line("@startuml")
+	if !c.graph.Flow().IsVertical() {
+		c.line("left to right direction")
+	}
+	for _, v := range c.graph.Clusters {
+		c.writeCluster(v)
+	}
+	for _, n := range c.graph.Roots {
+		c.writeNode(n)
+	}
+	for _, e := range c.graph.Edges {
+		c.writeEdge(e)
+	}
+	c.line("@enduml")
+	return c.b.String()
+}
+
+// colors returns the color specification following the declaration of an
+// element, or an empty string when it uses the default colors.
+func colors(fill, stroke, text *scene.Color) string {
+	var spec []string
+	if fill != nil {
+		spec = append(spec, fill.Hex())
+	}
+	if stroke != nil {
+		spec = append(spec, "line:"+strings.TrimPrefix(stroke.Hex(), "#"))
+	}
+	if text != nil {
+		spec = append(spec, "text:"+strings.TrimPrefix(text.Hex(), "#"))
+	}
+	if len(spec) == 0 {
+		return ""
+	}
+	if fill == nil {
+		return " #" + strings.Join(spec, ";")
+	}
+	return " " + strings.Join(spec, ";")
+}
+
+// writeCluster writes sections as rectangles and frames as frames. Groups
+// do not outline their content, so their nodes are only kept together.
+func (c *Compiler) writeCluster(v *graph.Cluster) {
+	switch v.Kind {
+	case scene.KindGroup:
+		c.line("together {")
+	case scene.KindFrame:
+		c.line("frame %s as %s%s {", Quote(v.Label), graph.SafeID(v.ID), colors(v.Fill, nil, nil))
+	default:
+		c.line("rectangle %s as %s%s {", Quote(v.Label), graph.SafeID(v.ID), colors(v.Fill, nil, nil))
+	}
+	c.indent++
+	for _, child := range v.Clusters {
+		c.writeCluster(child)
+	}
+	for _, n := range v.Nodes {
+		c.writeNode(n)
+	}
+	c.indent--
+	c.line("}")
+}
+
+func (c *Compiler) writeNode(n *graph.Node) {
+	id := graph.SafeID(n.ID)
+	element := "rectangle"
+	switch n.Kind {
+	case scene.KindShape:
+		if v, ok := nodeShapes[n.Shape]; ok {
+			element = v
+		} else {
+			c.warn("%s is a %s, which PlantUML cannot draw", id, shapeNames[n.Shape])
+		}
+	case scene.KindLine, scene.KindVector:
+		c.warn("%s is a %s, drawn as a box", id, n.Kind)
+	case scene.KindOther:
+		c.warn("%s holds an image, which is not exported", id)
+	default:
+		if v, ok := kindShapes[n.Kind]; ok {
+			element = v
+		}
+	}
+
+	var spec string
+	switch {
+	case n.Kind == scene.KindText:
+		spec = colors(nil, nil, n.Fill)
+	case n.Fill != nil && n.Fill.IsDark():
+		spec = colors(n.Fill, n.Stroke, &scene.Color{R: 1, G: 1, B: 1, A: 1})
+	default:
+		spec = colors(n.Fill, n.Stroke, nil)
+	}
+	c.line("%s %s as %s%s", element, Quote(n.Label), id, spec)
+}
+
+// endpoint returns the alias of the element an edge end is attached to, or
+// an empty string when it is not part of the diagram.
+func (c *Compiler) endpoint(end graph.Endpoint) string {
+	switch {
+	case end.Node != nil:
+		return graph.SafeID(end.Node.ID)
+	case end.Cluster != nil && end.Cluster.Kind != scene.KindGroup:
+		return graph.SafeID(end.Cluster.ID)
+	case end.Cluster != nil && end.Cluster.FirstNode() != nil:
+		return graph.SafeID(end.Cluster.FirstNode().ID)
+	}
+	return ""
+}
+
+func (c *Compiler) writeEdge(e *graph.Edge) {
+	source, target := c.endpoint(e.Source), c.endpoint(e.Target)
+	if source == "" || target == "" {
+		c.warn("connector %s is not attached to both ends, and is omitted", e.ID)
+		return
+	}
+
+	tail := tips[e.StartCap]
+	if tail == ">" {
+		tail = "<"
+	}
+	head := tips[e.EndCap]
+
+	var body string
+	switch {
+	case e.Color != nil && e.Dashed:
+		body = "-[" + e.Color.Hex() + ",dashed]-"
+	case e.Color != nil:
+		body = "-[" + e.Color.Hex() + "]-"
+	case e.Dashed:
+		body = ".."
+	default:
+		body = "--"
+	}
+
+	link := fmt.Sprintf("%s %s%s%s %s", source, tail, body, head, target)
+	if e.Label != "" {
+		// Link labels run until the end of the line, and need no quotes.
+		link += " : " + strings.NewReplacer("\r", "", "\n", `\n`).Replace(e.Label)
+	}
+	c.line("%s", link)
+}
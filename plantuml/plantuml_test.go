@@ -0,0 +1,39 @@
+package plantuml
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	r := &Renderer{Opts: &Options{FilePath: "board.jam"}}
+	if err := r.Render(scenetest.Scene(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	scenetest.Golden(t, "board.puml", buf.Bytes())
+
+	out := buf.String()
+	for _, want := range []string{
+		"left to right direction",
+		`rectangle "Ideas" as n1_10 #e6f2ff {`,
+		`card "Write <U+0022>tests<U+0022> & docs" as n1_12 #ffd966`,
+		"' Warning: n1_20 is a diamond, which PlantUML cannot draw",
+		"n1_20 -[#000000]-> n1_11 : yes",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if !strings.HasPrefix(out[strings.Index(out, "@startuml"):], "@startuml\n") || !strings.HasSuffix(out, "@enduml\n") {
+		t.Errorf("expected the diagram to be enclosed in @startuml and @enduml, got:\n%s", out)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got, want := Quote("a\\b \"c\"\r\nd"), `"a\\b <U+0022>c<U+0022>\nd"`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,271 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"math"
+)
+
+// Side is one of the four sides of an element's outline.
+type Side int
+
+const (
+	SideTop Side = iota
+	SideRight
+	SideBottom
+	SideLeft
+)
+
+// SideOf returns the side of its node the given magnet sits on. Automatic
+// and centered magnets are treated as the top one.
+func SideOf(magnet fig.ConnectorMagnet) Side {
+	switch magnet {
+	case fig.ConnectorMagnetLeft:
+		return SideLeft
+	case fig.ConnectorMagnetBottom:
+		return SideBottom
+	case fig.ConnectorMagnetRight:
+		return SideRight
+	default:
+		return SideTop
+	}
+}
+
+// Normal returns the unit vector pointing outwards from s.
+func (s Side) Normal() Point {
+	switch s {
+	case SideRight:
+		return Point{X: 1}
+	case SideBottom:
+		return Point{Y: 1}
+	case SideLeft:
+		return Point{X: -1}
+	default:
+		return Point{Y: -1}
+	}
+}
+
+// IsVertical reports whether s faces up or down.
+func (s Side) IsVertical() bool {
+	return s == SideTop || s == SideBottom
+}
+
+// sideFacing returns the side closest to the direction of v.
+func sideFacing(v Point) Side {
+	switch {
+	case math.Abs(v.X) > math.Abs(v.Y) && v.X > 0:
+		return SideRight
+	case math.Abs(v.X) > math.Abs(v.Y):
+		return SideLeft
+	case v.Y > 0:
+		return SideBottom
+	default:
+		return SideTop
+	}
+}
+
+// Endpoint is an end of a connector. Point lies on the outline of the node
+// it is attached to, at the middle of Side, and Direction is the side that
+// magnet faces once the node's transform is applied. Element is nil when
+// the node is not part of the scene, and ID is empty for detached ends.
+type Endpoint struct {
+	ID        string
+	Element   *Element
+	Magnet    fig.ConnectorMagnet
+	Side      Side
+	Direction Side
+	Point     Point
+}
+
+// Route is the shape of the path a connector is drawn along.
+type Route int
+
+const (
+	// RouteStraight is a single segment.
+	RouteStraight Route = iota
+	// RouteElbow is a path with a single turn.
+	RouteElbow
+	// RouteStep leaves its start along the start's direction, turns at the
+	// middle of the way and reaches its end along the same axis.
+	RouteStep
+	// RoutePolyline follows the connector's control points.
+	RoutePolyline
+)
+
+// Connector is the resolved path of a connector, in the scene's coordinate
+// space. Points holds every vertex of the path, from Start.Anchor() to
+// End.Anchor().
+type Connector struct {
+	Start, End Endpoint
+	Route      Route
+	Points     []Point
+
+	Label *Text
+	// LabelPosition is the fraction of the path's length the label is
+	// placed at.
+	LabelPosition float64
+
+	StartCap, EndCap fig.StrokeCap
+	LineStyle        fig.ConnectorLineStyle
+
+	// Clipped is set for connectors crossing the edge of the selected
+	// region, which are to be clipped to it.
+	Clipped bool
+}
+
+// Length returns the length of the connector's path.
+func (c *Connector) Length() float64 {
+	l := 0.0
+	for i := 1; i < len(c.Points); i++ {
+		l += c.Points[i-1].Distance(c.Points[i])
+	}
+	return l
+}
+
+// LabelPoint returns the point of the path the label is placed at.
+func (c *Connector) LabelPoint() Point {
+	remaining := c.Length() * c.LabelPosition
+	for i := 1; i < len(c.Points); i++ {
+		a, b := c.Points[i-1], c.Points[i]
+		d := a.Distance(b)
+		if d > 0 && remaining <= d {
+			return a.Add(b.Sub(a).Mul(remaining / d))
+		}
+		remaining -= d
+	}
+	return c.Points[len(c.Points)-1]
+}
+
+// labelPosition returns the fraction of the path a label is placed at.
+func labelPosition(midPoint *fig.ConnectorTextMidpoint) float64 {
+	if midPoint == nil {
+		return 0.5
+	}
+	if midPoint.Section == fig.ConnectorTextSectionMiddleToEnd {
+		return 0.5 + midPoint.Offset/2
+	}
+	return 0.5 - midPoint.Offset/2
+}
+
+// endpoint resolves e, looking its node up through geometry. space maps
+// the connector's coordinate space into the scene's.
+func endpoint(e *fig.ConnectorEndpoint, space Transform, geometry map[string]placement) Endpoint {
+	if e == nil {
+		return Endpoint{}
+	}
+	res := Endpoint{Magnet: e.Magnet, Side: SideOf(e.Magnet)}
+	res.Direction = res.Side
+	if e.EndpointNodeId != nil {
+		res.ID = GUIDKey(e.EndpointNodeId)
+		if g, ok := geometry[res.ID]; ok {
+			res.Element = g.element
+			local := g.size.Mul(0.5).Add(Point{
+				X: res.Side.Normal().X * g.size.X / 2,
+				Y: res.Side.Normal().Y * g.size.Y / 2,
+			})
+			res.Point = g.transform.Apply(local)
+			res.Direction = sideFacing(g.transform.ApplyLinear(res.Side.Normal()))
+			return res
+		}
+	}
+	if e.Position != nil {
+		res.Point = space.Apply(Point{X: e.Position.X, Y: e.Position.Y})
+	}
+	return res
+}
+
+// isMagnetAligned reports whether leaving p1 towards dir heads to p2.
+func isMagnetAligned(p1, p2 Point, dir Side) bool {
+	return (p1.X < p2.X && dir == SideRight) ||
+		(p1.X > p2.X && dir == SideLeft) ||
+		(p1.Y < p2.Y && dir == SideBottom) ||
+		(p1.Y > p2.Y && dir == SideTop)
+}
+
+// elbowCorner returns the corner of an L-shaped path from p1 to p2 leaving
+// and reaching them along their directions, if any.
+func elbowCorner(p1, p2 Point, startDir, endDir Side) *Point {
+	if p1.X == p2.X || p1.Y == p2.Y {
+		return nil
+	}
+	for _, z := range []Point{{p1.X, p2.Y}, {p2.X, p1.Y}} {
+		if isMagnetAligned(p1, z, startDir) && isMagnetAligned(p2, z, endDir) {
+			return &z
+		}
+	}
+	return nil
+}
+
+// EndpointGap is the distance, in canvas units, left between connectors and
+// the outline of the nodes they are attached to.
+const EndpointGap = 5
+
+// Anchor returns the point a connector starts or ends at, EndpointGap away
+// from the outline of its node along Direction. Detached ends are returned
+// as they are.
+func (e Endpoint) Anchor() Point {
+	if e.ID == "" {
+		return e.Point
+	}
+	return e.Point.Add(e.Direction.Normal().Mul(EndpointGap))
+}
+
+// route computes the path of c. controlPoints are given in the connector's
+// coordinate space, which space maps into the scene's.
+func (c *Connector) route(controlPoints []*fig.ConnectorControlPoint, space Transform) {
+	start, end := c.Start.Anchor(), c.End.Anchor()
+
+	if len(controlPoints) > 0 {
+		c.Route = RoutePolyline
+		c.Points = []Point{start}
+		cur, last := start, start
+		for _, cp := range controlPoints {
+			if cp == nil || cp.Position == nil {
+				continue
+			}
+			p := space.Apply(Point{X: cp.Position.X, Y: cp.Position.Y})
+			if cp.Axis != nil && cp.Axis.X == 1 {
+				cur = Point{X: cur.X, Y: p.Y}
+			} else {
+				cur = Point{X: p.X, Y: cur.Y}
+			}
+			c.Points = append(c.Points, cur)
+			last = p
+		}
+		if last != cur {
+			c.Points = append(c.Points, last)
+		}
+		// The last leg reaches the end along the axis its magnet faces,
+		// turning right before it when the last point is not aligned with
+		// it.
+		var corner Point
+		if c.End.Direction.IsVertical() {
+			corner = Point{X: end.X, Y: last.Y}
+		} else {
+			corner = Point{X: last.X, Y: end.Y}
+		}
+		if corner != last && corner != end {
+			c.Points = append(c.Points, corner)
+		}
+		c.Points = append(c.Points, end)
+		return
+	}
+
+	if start.X == end.X || start.Y == end.Y {
+		c.Route = RouteStraight
+		c.Points = []Point{start, end}
+		return
+	}
+	if corner := elbowCorner(start, end, c.Start.Direction, c.End.Direction); corner != nil {
+		c.Route = RouteElbow
+		c.Points = []Point{start, *corner, end}
+		return
+	}
+
+	c.Route = RouteStep
+	mid := start.Mid(end)
+	if c.Start.Direction.IsVertical() {
+		c.Points = []Point{start, {X: start.X, Y: mid.Y}, {X: end.X, Y: mid.Y}, end}
+	} else {
+		c.Points = []Point{start, {X: mid.X, Y: start.Y}, {X: mid.X, Y: end.Y}, end}
+	}
+}
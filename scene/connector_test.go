@@ -0,0 +1,110 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"slices"
+	"testing"
+)
+
+// attached returns an endpoint at p on the side of its node facing dir.
+func attached(p Point, dir Side) Endpoint {
+	return Endpoint{ID: "1:1", Side: dir, Direction: dir, Point: p}
+}
+
+func TestEndpointAnchor(t *testing.T) {
+	if got := attached(Point{X: 10, Y: 10}, SideRight).Anchor(); got != (Point{X: 10 + EndpointGap, Y: 10}) {
+		t.Errorf("expected the anchor to leave a gap along the direction, got %v", got)
+	}
+	if got := (Endpoint{Point: Point{X: 10, Y: 10}, Direction: SideRight}).Anchor(); got != (Point{X: 10, Y: 10}) {
+		t.Errorf("expected detached ends to be kept as they are, got %v", got)
+	}
+}
+
+func TestRoute(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		start, end    Endpoint
+		controlPoints []*fig.ConnectorControlPoint
+		route         Route
+		want          []Point
+	}{
+		{
+			name:  "straight",
+			start: attached(Point{X: 0, Y: 0}, SideRight),
+			end:   attached(Point{X: 100, Y: 0}, SideLeft),
+			route: RouteStraight,
+			want:  []Point{{X: 5, Y: 0}, {X: 95, Y: 0}},
+		},
+		{
+			name:  "elbow",
+			start: attached(Point{X: 0, Y: 0}, SideRight),
+			end:   attached(Point{X: 100, Y: 100}, SideTop),
+			route: RouteElbow,
+			want:  []Point{{X: 5, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 95}},
+		},
+		{
+			name:  "step",
+			start: attached(Point{X: 0, Y: 0}, SideRight),
+			end:   attached(Point{X: 100, Y: 100}, SideLeft),
+			route: RouteStep,
+			want:  []Point{{X: 5, Y: 0}, {X: 50, Y: 0}, {X: 50, Y: 100}, {X: 95, Y: 100}},
+		},
+		{
+			// Control points without a position are skipped, and the last
+			// leg reaches the end vertically, as its magnet faces up.
+			name:  "polyline",
+			start: attached(Point{X: 0, Y: 0}, SideRight),
+			end:   attached(Point{X: 200, Y: 200}, SideTop),
+			controlPoints: []*fig.ConnectorControlPoint{
+				nil,
+				{},
+				{Position: &fig.Vector{X: 100, Y: 50}},
+			},
+			route: RoutePolyline,
+			want:  []Point{{X: 5, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50}, {X: 200, Y: 50}, {X: 200, Y: 195}},
+		},
+		{
+			name:  "polyline horizontal end",
+			start: attached(Point{X: 0, Y: 0}, SideRight),
+			end:   attached(Point{X: 200, Y: 200}, SideLeft),
+			controlPoints: []*fig.ConnectorControlPoint{
+				{Position: &fig.Vector{X: 100, Y: 50}},
+			},
+			route: RoutePolyline,
+			want:  []Point{{X: 5, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 50}, {X: 100, Y: 200}, {X: 195, Y: 200}},
+		},
+	} {
+		c := &Connector{Start: tc.start, End: tc.end}
+		c.route(tc.controlPoints, Identity())
+		if c.Route != tc.route || !slices.Equal(c.Points, tc.want) {
+			t.Errorf("%s: expected route %v through %v, got %v through %v", tc.name, tc.route, tc.want, c.Route, c.Points)
+		}
+	}
+}
+
+func TestEndpointFollowsTransform(t *testing.T) {
+	// A 100x50 node rotated 90 degrees clockwise around its top-left
+	// corner, which sits at (200, 0).
+	rotated := &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeRectangle, Visible: true, Opacity: 1,
+		Transform: &fig.Matrix{M00: 0, M01: -1, M02: 200, M10: 1, M11: 0, M12: 0},
+		Size:      &fig.Vector{X: 100, Y: 50},
+	}
+	geometry := map[string]placement{"1:1": {transform: TransformFrom(rotated.Transform), size: Point{X: 100, Y: 50}}}
+
+	for _, tc := range []struct {
+		magnet    fig.ConnectorMagnet
+		point     Point
+		direction Side
+	}{
+		{fig.ConnectorMagnetTop, Point{X: 200, Y: 50}, SideRight},
+		{fig.ConnectorMagnetBottom, Point{X: 150, Y: 50}, SideLeft},
+		{fig.ConnectorMagnetLeft, Point{X: 175, Y: 0}, SideTop},
+		{fig.ConnectorMagnetRight, Point{X: 175, Y: 100}, SideBottom},
+	} {
+		e := endpoint(&fig.ConnectorEndpoint{EndpointNodeId: guid(1), Magnet: tc.magnet}, Identity(), geometry)
+		if e.Point.Distance(tc.point) > 1e-9 || e.Direction != tc.direction {
+			t.Errorf("magnet %v: expected %v facing %v, got %v facing %v", tc.magnet, tc.point, tc.direction, e.Point, e.Direction)
+		}
+	}
+}
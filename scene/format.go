@@ -0,0 +1,53 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strconv"
+	"strings"
+)
+
+// FormatNumber prints v with the given amount of decimal places, omitting
+// trailing zeros.
+func FormatNumber(v float64, precision int) string {
+	s := strconv.FormatFloat(v, 'f', precision, 64)
+	if strings.ContainsRune(s, '.') {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+var commentEscaper = strings.NewReplacer(`--`, `- -`)
+
+// EscapeComment keeps s from terminating the XML or HTML comment it is
+// written in.
+func EscapeComment(s string) string {
+	return commentEscaper.Replace(s)
+}
+
+var codeLanguages = map[fig.CodeBlockLanguage]string{
+	fig.CodeBlockLanguageTypescript: "ts",
+	fig.CodeBlockLanguageCpp:        "cpp",
+	fig.CodeBlockLanguageRuby:       "rb",
+	fig.CodeBlockLanguageCss:        "css",
+	fig.CodeBlockLanguageJavascript: "js",
+	fig.CodeBlockLanguageHtml:       "html",
+	fig.CodeBlockLanguageJson:       "json",
+	fig.CodeBlockLanguageGraphql:    "graphql",
+	fig.CodeBlockLanguagePython:     "py",
+	fig.CodeBlockLanguageGo:         "go",
+	fig.CodeBlockLanguageSql:        "sql",
+	fig.CodeBlockLanguageSwift:      "swift",
+	fig.CodeBlockLanguageKotlin:     "kotlin",
+	fig.CodeBlockLanguageRust:       "rust",
+	fig.CodeBlockLanguageBash:       "sh",
+}
+
+// CodeLanguage returns the short name code in lang is tagged with, such as
+// "ts" or "py". ok is false for plain text and unknown languages.
+func CodeLanguage(lang fig.CodeBlockLanguage) (name string, ok bool) {
+	name, ok = codeLanguages[lang]
+	return
+}
@@ -0,0 +1,39 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	for _, tc := range []struct {
+		v         float64
+		precision int
+		want      string
+	}{
+		{1.5, 2, "1.5"},
+		{2, 2, "2"},
+		{0.126, 2, "0.13"},
+		{-0.0001, 2, "0"},
+		{1234.5678, 3, "1234.568"},
+	} {
+		if got := FormatNumber(tc.v, tc.precision); got != tc.want {
+			t.Errorf("FormatNumber(%v, %d): expected %s, got %s", tc.v, tc.precision, tc.want, got)
+		}
+	}
+}
+
+func TestEscapeComment(t *testing.T) {
+	if got := EscapeComment("a -- b --> c"); got != "a - - b - -> c" {
+		t.Errorf("expected dashes to be split, got %q", got)
+	}
+}
+
+func TestCodeLanguage(t *testing.T) {
+	if name, ok := CodeLanguage(fig.CodeBlockLanguageTypescript); !ok || name != "ts" {
+		t.Errorf("expected ts, got %q, %v", name, ok)
+	}
+	if _, ok := CodeLanguage(fig.CodeBlockLanguagePlaintext); ok {
+		t.Error("expected plain text to have no language")
+	}
+}
@@ -0,0 +1,185 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"math"
+)
+
+// Point is a position or size in canvas units.
+type Point struct {
+	X, Y float64
+}
+
+func (p Point) Add(o Point) Point {
+	return Point{X: p.X + o.X, Y: p.Y + o.Y}
+}
+
+func (p Point) Sub(o Point) Point {
+	return Point{X: p.X - o.X, Y: p.Y - o.Y}
+}
+
+func (p Point) Mul(f float64) Point {
+	return Point{X: p.X * f, Y: p.Y * f}
+}
+
+func (p Point) Mid(o Point) Point {
+	return Point{X: (p.X + o.X) / 2, Y: (p.Y + o.Y) / 2}
+}
+
+// Distance returns the euclidean distance between p and o.
+func (p Point) Distance(o Point) float64 {
+	return math.Hypot(o.X-p.X, o.Y-p.Y)
+}
+
+// Rect is an axis-aligned rectangle between Min and Max.
+type Rect struct {
+	Min, Max Point
+}
+
+// EmptyRect returns a rectangle that any other one extends when united
+// with it.
+func EmptyRect() Rect {
+	return Rect{
+		Min: Point{X: math.Inf(1), Y: math.Inf(1)},
+		Max: Point{X: math.Inf(-1), Y: math.Inf(-1)},
+	}
+}
+
+func (r Rect) IsEmpty() bool {
+	return r.Min.X > r.Max.X || r.Min.Y > r.Max.Y
+}
+
+func (r Rect) Size() Point {
+	if r.IsEmpty() {
+		return Point{}
+	}
+	return r.Max.Sub(r.Min)
+}
+
+func (r Rect) Center() Point {
+	return r.Min.Mid(r.Max)
+}
+
+// Union returns the smallest rectangle containing both r and o.
+func (r Rect) Union(o Rect) Rect {
+	return Rect{
+		Min: Point{X: min(r.Min.X, o.Min.X), Y: min(r.Min.Y, o.Min.Y)},
+		Max: Point{X: max(r.Max.X, o.Max.X), Y: max(r.Max.Y, o.Max.Y)},
+	}
+}
+
+// Extend returns the smallest rectangle containing both r and p.
+func (r Rect) Extend(p Point) Rect {
+	return r.Union(Rect{Min: p, Max: p})
+}
+
+func (r Rect) Intersects(o Rect) bool {
+	return r.Min.X <= o.Max.X && o.Min.X <= r.Max.X && r.Min.Y <= o.Max.Y && o.Min.Y <= r.Max.Y
+}
+
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// Transform is a 2x3 affine matrix.
+type Transform struct {
+	M00, M01, M02 float64
+	M10, M11, M12 float64
+}
+
+func Identity() Transform {
+	return Transform{M00: 1, M11: 1}
+}
+
+// TransformFrom converts a node's matrix, treating a missing one as the
+// identity.
+func TransformFrom(m *fig.Matrix) Transform {
+	if m == nil {
+		return Identity()
+	}
+	return Transform{M00: m.M00, M01: m.M01, M02: m.M02, M10: m.M10, M11: m.M11, M12: m.M12}
+}
+
+func (t Transform) Apply(p Point) Point {
+	return Point{
+		X: t.M00*p.X + t.M01*p.Y + t.M02,
+		Y: t.M10*p.X + t.M11*p.Y + t.M12,
+	}
+}
+
+// ApplyLinear applies the transform to p, ignoring its translation.
+func (t Transform) ApplyLinear(p Point) Point {
+	return Point{
+		X: t.M00*p.X + t.M01*p.Y,
+		Y: t.M10*p.X + t.M11*p.Y,
+	}
+}
+
+// Compose returns the transform applying o, then t.
+func (t Transform) Compose(o Transform) Transform {
+	return Transform{
+		M00: t.M00*o.M00 + t.M01*o.M10,
+		M01: t.M00*o.M01 + t.M01*o.M11,
+		M02: t.M00*o.M02 + t.M01*o.M12 + t.M02,
+		M10: t.M10*o.M00 + t.M11*o.M10,
+		M11: t.M10*o.M01 + t.M11*o.M11,
+		M12: t.M10*o.M02 + t.M11*o.M12 + t.M12,
+	}
+}
+
+// Inverse returns the transform undoing t. Degenerate transforms are
+// returned unchanged.
+func (t Transform) Inverse() Transform {
+	det := t.M00*t.M11 - t.M01*t.M10
+	if det == 0 {
+		return t
+	}
+	inv := Transform{
+		M00: t.M11 / det,
+		M01: -t.M01 / det,
+		M10: -t.M10 / det,
+		M11: t.M00 / det,
+	}
+	inv.M02 = -(inv.M00*t.M02 + inv.M01*t.M12)
+	inv.M12 = -(inv.M10*t.M02 + inv.M11*t.M12)
+	return inv
+}
+
+func (t Transform) IsTranslation() bool {
+	return t.M00 == 1 && t.M11 == 1 && t.M01 == 0 && t.M10 == 0
+}
+
+// Rotation returns the clockwise rotation, in degrees, applied by the
+// transform.
+func (t Transform) Rotation() float64 {
+	return math.Atan2(t.M10, t.M00) * 180 / math.Pi
+}
+
+// Orientation returns the clockwise rotation, in degrees, applied by the
+// transform after mirroring, for drawings that are mirrored within their own
+// box before being rotated. flipH mirrors them across their vertical axis,
+// and flipV across their horizontal one. Mirrored transforms use whichever
+// flip needs the smallest rotation.
+func (t Transform) Orientation() (rotation float64, flipH, flipV bool) {
+	if t.M00*t.M11-t.M01*t.M10 >= 0 {
+		return t.Rotation(), false, false
+	}
+	// Flipping vertically leaves the first column of the matrix unchanged,
+	// while flipping horizontally negates it.
+	vertical := t.Rotation()
+	horizontal := math.Atan2(-t.M10, -t.M00) * 180 / math.Pi
+	if math.Abs(horizontal) <= math.Abs(vertical) {
+		return horizontal, true, false
+	}
+	return vertical, false, true
+}
+
+// Bounds returns the bounding box of the rectangle between the origin and
+// size, once transformed.
+func (t Transform) Bounds(size Point) Rect {
+	r := EmptyRect()
+	for _, corner := range []Point{{0, 0}, {size.X, 0}, size, {0, size.Y}} {
+		r = r.Extend(t.Apply(corner))
+	}
+	return r
+}
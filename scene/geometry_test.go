@@ -0,0 +1,25 @@
+package scene
+
+import "testing"
+
+func TestOrientation(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		t            Transform
+		rotation     float64
+		flipH, flipV bool
+	}{
+		{"identity", Identity(), 0, false, false},
+		{"rotated", Transform{M01: -1, M10: 1}, 90, false, false},
+		{"mirrored horizontally", Transform{M00: -1, M11: 1}, 0, true, false},
+		{"mirrored vertically", Transform{M00: 1, M11: -1}, 0, false, true},
+		{"mirrored diagonally", Transform{M01: 1, M10: 1}, -90, true, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rotation, flipH, flipV := tt.t.Orientation()
+			if rotation != tt.rotation || flipH != tt.flipH || flipV != tt.flipV {
+				t.Errorf("expected %v, %v, %v, got %v, %v, %v", tt.rotation, tt.flipH, tt.flipV, rotation, flipH, flipV)
+			}
+		})
+	}
+}
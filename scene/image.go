@@ -0,0 +1,99 @@
+package scene
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/heyvito/figz/fig"
+)
+
+// Image is the content of an image referenced by the document. Hash is the
+// hex-encoded hash it is stored under, and Format its file extension.
+type Image struct {
+	Hash   string
+	Data   []byte
+	Format string
+}
+
+var imageTypes = map[string]string{
+	"png": "image/png",
+	"jpg": "image/jpeg",
+	"gif": "image/gif",
+}
+
+// MIMEType returns the media type of img, or an empty string for formats
+// other than PNG, JPEG and GIF.
+func (img *Image) MIMEType() string {
+	return imageTypes[img.Format]
+}
+
+// ImageFill is an image paint of an element. Image is the still image to be
+// drawn, and is nil when Err is set.
+type ImageFill struct {
+	Paint *fig.Paint
+	Image *Image
+	Err   error
+}
+
+func imageFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		return "png"
+	case bytes.HasPrefix(data, []byte("\xff\xd8")):
+		return "jpg"
+	case bytes.HasPrefix(data, []byte("%PDF")):
+		return "pdf"
+	case bytes.HasPrefix(data, []byte("GIF8")):
+		return "gif"
+	default:
+		return ""
+	}
+}
+
+// ResolveImage looks img up among the archive's images and the document's
+// blobs.
+func ResolveImage(img *fig.Image, images map[string][]byte, blobs []*fig.Blob) (*Image, error) {
+	if img == nil {
+		return nil, fmt.Errorf("image not found in document")
+	}
+	if len(img.Hash) > 0 {
+		hash := hex.EncodeToString(img.Hash)
+		if data, ok := images[hash]; ok {
+			return &Image{Hash: hash, Data: data, Format: imageFormat(data)}, nil
+		}
+	}
+	// DataBlob is 0 when it is not set, and then refers to an unrelated
+	// blob. Images with a hash only use their blob when it has that hash.
+	if int(img.DataBlob) < len(blobs) && blobs[img.DataBlob] != nil {
+		data := blobs[img.DataBlob].Bytes
+		sum := sha1.Sum(data)
+		if len(img.Hash) == 0 || bytes.Equal(sum[:], img.Hash) {
+			return &Image{Hash: hex.EncodeToString(sum[:]), Data: data, Format: imageFormat(data)}, nil
+		}
+	}
+	return nil, fmt.Errorf("image not found in document")
+}
+
+// imageFills resolves the image paints of n. GIFs and videos are replaced
+// by the still frame FigJam keeps alongside them.
+func imageFills(n *fig.NodeChange, images map[string][]byte, blobs []*fig.Blob) []ImageFill {
+	var res []ImageFill
+	for _, p := range n.FillPaints {
+		if p == nil || !p.Visible {
+			continue
+		}
+		img := p.Image
+		switch {
+		case n.Type == fig.NodeTypeMedia && p.ImageThumbnail != nil:
+			img = p.ImageThumbnail
+		case p.Type == fig.PaintTypeImage && p.Image != nil:
+		default:
+			continue
+		}
+		fill := ImageFill{Paint: p}
+		fill.Image, fill.Err = ResolveImage(img, images, blobs)
+		res = append(res, fill)
+	}
+	return res
+}
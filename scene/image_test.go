@@ -0,0 +1,29 @@
+package scene
+
+import (
+	"crypto/sha1"
+	"github.com/heyvito/figz/fig"
+	"testing"
+)
+
+func TestResolveImage(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+	sum := sha1.Sum(png)
+	blobs := []*fig.Blob{{Bytes: []byte("unrelated")}, {Bytes: png}}
+
+	img, err := ResolveImage(&fig.Image{Hash: []byte{0xaa, 0xbb}}, map[string][]byte{"aabb": png}, nil)
+	if err != nil || img.Hash != "aabb" || img.Format != "png" {
+		t.Errorf("expected the archive's image, got %+v, %v", img, err)
+	}
+	img, err = ResolveImage(&fig.Image{Hash: sum[:], DataBlob: 1}, nil, blobs)
+	if err != nil || string(img.Data) != string(png) {
+		t.Errorf("expected the blob with the image's hash, got %+v, %v", img, err)
+	}
+	// An unset DataBlob refers to blob 0, whose hash does not match.
+	if _, err = ResolveImage(&fig.Image{Hash: sum[:]}, nil, blobs); err == nil {
+		t.Error("expected an error for an image missing from the document")
+	}
+	if _, err = ResolveImage(nil, nil, blobs); err == nil {
+		t.Error("expected an error for a nil image")
+	}
+}
@@ -0,0 +1,284 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"math"
+)
+
+// kappa is the distance of the control points of a cubic Bézier curve
+// approximating a quarter of a unit circle.
+const kappa = 0.5522847498
+
+// defaultStarInnerScale is the inner radius of stars lacking one, as a
+// fraction of their outer radius.
+const defaultStarInnerScale = 0.382
+
+func moveTo(p Point) PathCommand { return PathCommand{Kind: PathMoveTo, Points: []Point{p}} }
+func lineTo(p Point) PathCommand { return PathCommand{Kind: PathLineTo, Points: []Point{p}} }
+func closePath() PathCommand     { return PathCommand{Kind: PathClose} }
+
+func cubicTo(c1, c2, p Point) PathCommand {
+	return PathCommand{Kind: PathCubicTo, Points: []Point{c1, c2, p}}
+}
+
+// polygon returns a closed path through points.
+func polygon(points ...Point) []PathCommand {
+	res := []PathCommand{moveTo(points[0])}
+	for _, p := range points[1:] {
+		res = append(res, lineTo(p))
+	}
+	return append(res, closePath())
+}
+
+// scaled returns points, given as fractions of size, in absolute units.
+func scaled(size Point, points ...Point) []Point {
+	res := make([]Point, len(points))
+	for i, p := range points {
+		res[i] = Point{X: p.X * size.X, Y: p.Y * size.Y}
+	}
+	return res
+}
+
+// arcSegments returns the curves following an elliptical arc from start to
+// end, in radians, with angles growing clockwise. The current point must
+// already be at the arc's start.
+func arcSegments(center Point, rx, ry, start, end float64) []PathCommand {
+	var res []PathCommand
+	steps := int(math.Ceil(math.Abs(end-start)/(math.Pi/2) - 1e-9))
+	if steps == 0 {
+		return nil
+	}
+	step := (end - start) / float64(steps)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+	at := func(a float64) Point {
+		return Point{X: center.X + rx*math.Cos(a), Y: center.Y + ry*math.Sin(a)}
+	}
+	for i := range steps {
+		a0 := start + float64(i)*step
+		a1 := a0 + step
+		p0, p1 := at(a0), at(a1)
+		c1 := Point{X: p0.X - k*rx*math.Sin(a0), Y: p0.Y + k*ry*math.Cos(a0)}
+		c2 := Point{X: p1.X + k*rx*math.Sin(a1), Y: p1.Y - k*ry*math.Cos(a1)}
+		res = append(res, cubicTo(c1, c2, p1))
+	}
+	return res
+}
+
+// ellipse returns a closed ellipse inscribed in the rectangle between min
+// and max.
+func ellipse(min, max Point) []PathCommand {
+	center := min.Mid(max)
+	rx, ry := (max.X-min.X)/2, (max.Y-min.Y)/2
+	res := []PathCommand{moveTo(Point{X: center.X + rx, Y: center.Y})}
+	res = append(res, arcSegments(center, rx, ry, 0, 2*math.Pi)...)
+	return append(res, closePath())
+}
+
+// roundedRect returns a rectangle of the given size whose corners are
+// rounded by radii, given clockwise from the top left one.
+func roundedRect(size Point, radii [4]float64) []PathCommand {
+	limit := min(size.X, size.Y) / 2
+	for i := range radii {
+		radii[i] = max(min(radii[i], limit), 0)
+	}
+	tl, tr, br, bl := radii[0], radii[1], radii[2], radii[3]
+	res := []PathCommand{moveTo(Point{X: tl})}
+	corner := func(r float64, from, c1, c2, to Point) {
+		if r == 0 {
+			res = append(res, lineTo(from))
+			return
+		}
+		res = append(res, lineTo(from), cubicTo(c1, c2, to))
+	}
+	w, h := size.X, size.Y
+	corner(tr, Point{X: w - tr}, Point{X: w - tr + tr*kappa}, Point{X: w, Y: tr - tr*kappa}, Point{X: w, Y: tr})
+	corner(br, Point{X: w, Y: h - br}, Point{X: w, Y: h - br + br*kappa}, Point{X: w - br + br*kappa, Y: h}, Point{X: w - br, Y: h})
+	corner(bl, Point{X: bl, Y: h}, Point{X: bl - bl*kappa, Y: h}, Point{Y: h - bl + bl*kappa}, Point{Y: h - bl})
+	corner(tl, Point{Y: tl}, Point{Y: tl - tl*kappa}, Point{X: tl - tl*kappa}, Point{X: tl})
+	return append(res, closePath())
+}
+
+// PolygonPoints returns count vertices inscribed in the ellipse filling
+// size, starting at the top. When inner is not zero, each vertex is
+// followed by another one at that fraction of the radius, forming a star.
+func PolygonPoints(size Point, count int, inner float64) []Point {
+	if count < 3 {
+		count = 3
+	}
+	rx, ry := size.X/2, size.Y/2
+	steps := count
+	if inner > 0 {
+		steps *= 2
+	}
+	points := make([]Point, steps)
+	for i := range steps {
+		f := 1.0
+		if inner > 0 && i%2 == 1 {
+			f = inner
+		}
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(steps)
+		points[i] = Point{X: rx + rx*f*math.Cos(angle), Y: ry + ry*f*math.Sin(angle)}
+	}
+	return points
+}
+
+// CornerRadii returns the radii of the corners of a rectangle node,
+// clockwise from the top left one.
+func CornerRadii(n *fig.NodeChange) [4]float64 {
+	if n.RectangleCornerRadiiIndependent {
+		return [4]float64{
+			n.RectangleTopLeftCornerRadius,
+			n.RectangleTopRightCornerRadius,
+			n.RectangleBottomRightCornerRadius,
+			n.RectangleBottomLeftCornerRadius,
+		}
+	}
+	r := n.CornerRadius
+	return [4]float64{r, r, r, r}
+}
+
+// ellipseOutline returns the outline of an ellipse node, which may be
+// restricted to an arc, or hollowed into a ring.
+func ellipseOutline(n *fig.NodeChange, size Point) []PathCommand {
+	arc := n.ArcData
+	full := arc == nil || math.Abs(arc.EndingAngle-arc.StartingAngle) >= 2*math.Pi-1e-3
+	if arc == nil || (full && arc.InnerRadius == 0) {
+		return ellipse(Point{}, size)
+	}
+	center := size.Mul(0.5)
+	rx, ry := size.X/2, size.Y/2
+	inner := arc.InnerRadius
+	at := func(a, f float64) Point {
+		return Point{X: center.X + rx*f*math.Cos(a), Y: center.Y + ry*f*math.Sin(a)}
+	}
+	if full {
+		ring := ellipse(Point{}, size)
+		return append(ring, ellipse(center.Sub(Point{X: rx * inner, Y: ry * inner}), center.Add(Point{X: rx * inner, Y: ry * inner}))...)
+	}
+	start, end := arc.StartingAngle, arc.EndingAngle
+	res := []PathCommand{moveTo(at(start, 1))}
+	res = append(res, arcSegments(center, rx, ry, start, end)...)
+	if inner == 0 {
+		res = append(res, lineTo(center))
+	} else {
+		res = append(res, lineTo(at(end, inner)))
+		res = append(res, arcSegments(center, rx*inner, ry*inner, end, start)...)
+	}
+	return append(res, closePath())
+}
+
+// shapeOutline returns the outline of a shape with text of the given type.
+func shapeOutline(kind fig.ShapeWithTextType, size Point) []PathCommand {
+	w, h := size.X, size.Y
+	switch kind {
+	case fig.ShapeWithTextTypeEllipse, fig.ShapeWithTextTypeSummingJunction, fig.ShapeWithTextTypeOr:
+		return ellipse(Point{}, size)
+	case fig.ShapeWithTextTypeRoundedRectangle:
+		r := min(w, h) * 0.15
+		return roundedRect(size, [4]float64{r, r, r, r})
+	case fig.ShapeWithTextTypeDiamond:
+		return polygon(scaled(size, Point{0.5, 0}, Point{1, 0.5}, Point{0.5, 1}, Point{0, 0.5})...)
+	case fig.ShapeWithTextTypeTriangleUp:
+		return polygon(scaled(size, Point{0.5, 0}, Point{1, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeTriangleDown:
+		return polygon(scaled(size, Point{0, 0}, Point{1, 0}, Point{0.5, 1})...)
+	case fig.ShapeWithTextTypeParallelogramRight:
+		return polygon(scaled(size, Point{0.2, 0}, Point{1, 0}, Point{0.8, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeParallelogramLeft:
+		return polygon(scaled(size, Point{0, 0}, Point{0.8, 0}, Point{1, 1}, Point{0.2, 1})...)
+	case fig.ShapeWithTextTypeTrapezoid:
+		return polygon(scaled(size, Point{0.2, 0}, Point{0.8, 0}, Point{1, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeManualInput:
+		return polygon(scaled(size, Point{0, 0.25}, Point{1, 0}, Point{1, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeHexagon:
+		return polygon(scaled(size, Point{0.25, 0}, Point{0.75, 0}, Point{1, 0.5}, Point{0.75, 1}, Point{0.25, 1}, Point{0, 0.5})...)
+	case fig.ShapeWithTextTypePentagon:
+		return polygon(PolygonPoints(size, 5, 0)...)
+	case fig.ShapeWithTextTypeOctagon:
+		return polygon(scaled(size, Point{0.3, 0}, Point{0.7, 0}, Point{1, 0.3}, Point{1, 0.7}, Point{0.7, 1}, Point{0.3, 1}, Point{0, 0.7}, Point{0, 0.3})...)
+	case fig.ShapeWithTextTypeStar:
+		return polygon(PolygonPoints(size, 5, defaultStarInnerScale)...)
+	case fig.ShapeWithTextTypePlus:
+		return polygon(scaled(size,
+			Point{1.0 / 3, 0}, Point{2.0 / 3, 0}, Point{2.0 / 3, 1.0 / 3}, Point{1, 1.0 / 3},
+			Point{1, 2.0 / 3}, Point{2.0 / 3, 2.0 / 3}, Point{2.0 / 3, 1}, Point{1.0 / 3, 1},
+			Point{1.0 / 3, 2.0 / 3}, Point{0, 2.0 / 3}, Point{0, 1.0 / 3}, Point{1.0 / 3, 1.0 / 3})...)
+	case fig.ShapeWithTextTypeChevron:
+		return polygon(scaled(size, Point{0, 0}, Point{0.75, 0}, Point{1, 0.5}, Point{0.75, 1}, Point{0, 1}, Point{0.25, 0.5})...)
+	case fig.ShapeWithTextTypeArrowRight:
+		return polygon(scaled(size, Point{0, 0.25}, Point{0.6, 0.25}, Point{0.6, 0}, Point{1, 0.5}, Point{0.6, 1}, Point{0.6, 0.75}, Point{0, 0.75})...)
+	case fig.ShapeWithTextTypeArrowLeft:
+		return polygon(scaled(size, Point{1, 0.25}, Point{0.4, 0.25}, Point{0.4, 0}, Point{0, 0.5}, Point{0.4, 1}, Point{0.4, 0.75}, Point{1, 0.75})...)
+	case fig.ShapeWithTextTypeEngFile:
+		return polygon(scaled(size, Point{0, 0}, Point{0.75, 0}, Point{1, 0.25}, Point{1, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeEngFolder:
+		return polygon(scaled(size, Point{0, 0}, Point{0.4, 0}, Point{0.5, 0.15}, Point{1, 0.15}, Point{1, 1}, Point{0, 1})...)
+	case fig.ShapeWithTextTypeSpeechBubble:
+		return polygon(scaled(size, Point{0, 0}, Point{1, 0}, Point{1, 0.8}, Point{0.4, 0.8}, Point{0.2, 1}, Point{0.2, 0.8}, Point{0, 0.8})...)
+	case fig.ShapeWithTextTypeShield:
+		return []PathCommand{
+			moveTo(Point{}), lineTo(Point{X: w}), lineTo(Point{X: w, Y: h * 0.5}),
+			cubicTo(Point{X: w, Y: h * 0.8}, Point{X: w * 0.7, Y: h * 0.9}, Point{X: w * 0.5, Y: h}),
+			cubicTo(Point{X: w * 0.3, Y: h * 0.9}, Point{Y: h * 0.8}, Point{Y: h * 0.5}),
+			closePath(),
+		}
+	case fig.ShapeWithTextTypeDocumentSingle, fig.ShapeWithTextTypeDocumentMultiple:
+		return []PathCommand{
+			moveTo(Point{}), lineTo(Point{X: w}), lineTo(Point{X: w, Y: h * 0.85}),
+			cubicTo(Point{X: w * 0.75, Y: h * 0.7}, Point{X: w * 0.5, Y: h * 1.1}, Point{X: w * 0.25, Y: h * 0.95}),
+			cubicTo(Point{X: w * 0.15, Y: h * 0.9}, Point{X: w * 0.05, Y: h * 0.85}, Point{Y: h * 0.9}),
+			closePath(),
+		}
+	case fig.ShapeWithTextTypeEngDatabase:
+		ry := min(h*0.125, w/4)
+		res := []PathCommand{moveTo(Point{Y: ry})}
+		res = append(res, arcSegments(Point{X: w / 2, Y: ry}, w/2, ry, math.Pi, 2*math.Pi)...)
+		res = append(res, lineTo(Point{X: w, Y: h - ry}))
+		res = append(res, arcSegments(Point{X: w / 2, Y: h - ry}, w/2, ry, 0, math.Pi)...)
+		res = append(res, closePath(), moveTo(Point{Y: ry}))
+		return append(res, arcSegments(Point{X: w / 2, Y: ry}, w/2, ry, math.Pi, 0)...)
+	case fig.ShapeWithTextTypeEngQueue:
+		rx := min(w*0.125, h/4)
+		res := []PathCommand{moveTo(Point{X: rx})}
+		res = append(res, lineTo(Point{X: w - rx}))
+		res = append(res, arcSegments(Point{X: w - rx, Y: h / 2}, rx, h/2, -math.Pi/2, math.Pi/2)...)
+		res = append(res, lineTo(Point{X: rx, Y: h}))
+		res = append(res, arcSegments(Point{X: rx, Y: h / 2}, rx, h/2, math.Pi/2, 3*math.Pi/2)...)
+		res = append(res, closePath(), moveTo(Point{X: w - rx}))
+		return append(res, arcSegments(Point{X: w - rx, Y: h / 2}, rx, h/2, -math.Pi/2, -3*math.Pi/2)...)
+	}
+	return polygon(Point{}, Point{X: w}, size, Point{Y: h})
+}
+
+// Outline returns the outline of e in its own coordinate space, or nil for
+// elements that are not drawn as a single filled shape.
+func Outline(e *Element) []PathCommand {
+	n := e.Node
+	switch e.Kind {
+	case KindShape:
+		return shapeOutline(n.ShapeWithTextType, e.Size)
+	case KindSticky, KindTable, KindCodeBlock, KindWashiTape, KindLinkPreview:
+		return polygon(Point{}, Point{X: e.Size.X}, e.Size, Point{Y: e.Size.Y})
+	case KindRectangle:
+		return roundedRect(e.Size, CornerRadii(n))
+	case KindEllipse:
+		return ellipseOutline(n, e.Size)
+	case KindStar:
+		inner := n.StarInnerScale
+		if inner == 0 {
+			inner = defaultStarInnerScale
+		}
+		return polygon(PolygonPoints(e.Size, int(n.Count), inner)...)
+	case KindPolygon:
+		return polygon(PolygonPoints(e.Size, int(n.Count), 0)...)
+	case KindLine:
+		return []PathCommand{moveTo(Point{}), lineTo(Point{X: e.Size.X})}
+	case KindVector:
+		var res []PathCommand
+		for _, p := range e.Paths {
+			res = append(res, p...)
+		}
+		return res
+	}
+	return nil
+}
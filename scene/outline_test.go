@@ -0,0 +1,66 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"math"
+	"testing"
+)
+
+func TestPolygonPoints(t *testing.T) {
+	points := PolygonPoints(Point{X: 100, Y: 100}, 5, 0.5)
+	if len(points) != 10 {
+		t.Fatalf("expected a five-pointed star to have 10 vertices, got %d", len(points))
+	}
+	if points[0].Distance(Point{X: 50, Y: 0}) > 1e-9 {
+		t.Errorf("expected the first vertex at the top, got %v", points[0])
+	}
+	if r := points[1].Distance(Point{X: 50, Y: 50}); math.Abs(r-25) > 1e-9 {
+		t.Errorf("expected inner vertices at half the radius, got %v", r)
+	}
+	if n := len(PolygonPoints(Point{X: 10, Y: 10}, 1, 0)); n != 3 {
+		t.Errorf("expected at least a triangle, got %d vertices", n)
+	}
+}
+
+func TestOutline(t *testing.T) {
+	rect := &Element{Kind: KindRectangle, Size: Point{X: 100, Y: 50}, Node: &fig.NodeChange{}}
+	cmds := Outline(rect)
+	if len(cmds) == 0 || cmds[0].Kind != PathMoveTo || cmds[len(cmds)-1].Kind != PathClose {
+		t.Fatalf("expected a closed outline, got %v", cmds)
+	}
+	for _, cmd := range cmds {
+		if cmd.Kind == PathCubicTo {
+			t.Fatalf("expected sharp corners, got %v", cmds)
+		}
+	}
+
+	rect.Node = &fig.NodeChange{CornerRadius: 10}
+	for _, cmd := range Outline(rect) {
+		if cmd.Kind == PathCubicTo {
+			return
+		}
+	}
+	t.Error("expected rounded corners to be drawn as curves")
+}
+
+func TestOutlineOfContainers(t *testing.T) {
+	if cmds := Outline(&Element{Kind: KindSection, Node: &fig.NodeChange{}}); cmds != nil {
+		t.Errorf("expected no outline for sections, got %v", cmds)
+	}
+}
+
+func TestSafeURL(t *testing.T) {
+	for url, want := range map[string]bool{
+		"https://example.com":  true,
+		"HTTP://example.com":   true,
+		"mailto:a@example.com": true,
+		"javascript:alert(1)":  false,
+		" javascript:alert(1)": false,
+		"data:text/html,hi":    false,
+		"example.com":          false,
+	} {
+		if got := SafeURL(url); got != want {
+			t.Errorf("SafeURL(%q): expected %v, got %v", url, want, got)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package scene
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+type PathCommandKind byte
+
+const (
+	PathClose PathCommandKind = iota
+	PathMoveTo
+	PathLineTo
+	PathQuadTo
+	PathCubicTo
+)
+
+var pathCommandArity = map[PathCommandKind]int{
+	PathClose:   0,
+	PathMoveTo:  1,
+	PathLineTo:  1,
+	PathQuadTo:  2,
+	PathCubicTo: 3,
+}
+
+// PathCommand represents a single command of a geometry blob. Points are
+// kept in the node's local coordinate space.
+type PathCommand struct {
+	Kind   PathCommandKind
+	Points []Point
+}
+
+// DecodePathCommands decodes a geometry blob, which is composed of a command
+// byte followed by its points, each one represented by two little-endian
+// float32 values.
+func DecodePathCommands(data []byte) ([]PathCommand, error) {
+	var res []PathCommand
+	for offset := 0; offset < len(data); {
+		kind := PathCommandKind(data[offset])
+		offset++
+		arity, ok := pathCommandArity[kind]
+		if !ok {
+			return nil, fmt.Errorf("unknown path command %d at offset %d", kind, offset-1)
+		}
+		if offset+arity*8 > len(data) {
+			return nil, fmt.Errorf("short path command %d at offset %d", kind, offset-1)
+		}
+		cmd := PathCommand{Kind: kind, Points: make([]Point, arity)}
+		for i := range arity {
+			cmd.Points[i] = Point{
+				X: float64(math.Float32frombits(binary.LittleEndian.Uint32(data[offset:]))),
+				Y: float64(math.Float32frombits(binary.LittleEndian.Uint32(data[offset+4:]))),
+			}
+			offset += 8
+		}
+		res = append(res, cmd)
+	}
+	return res, nil
+}
@@ -0,0 +1,53 @@
+package scene
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// encodePath encodes commands the way geometry blobs store them.
+func encodePath(cmds ...PathCommand) []byte {
+	var data []byte
+	for _, cmd := range cmds {
+		data = append(data, byte(cmd.Kind))
+		for _, p := range cmd.Points {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(p.X)))
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(p.Y)))
+		}
+	}
+	return data
+}
+
+func TestDecodePathCommands(t *testing.T) {
+	cmds := []PathCommand{
+		{Kind: PathMoveTo, Points: []Point{{0, 0}}},
+		{Kind: PathLineTo, Points: []Point{{10, 0}}},
+		{Kind: PathQuadTo, Points: []Point{{10, 10}, {0, 10}}},
+		{Kind: PathClose, Points: []Point{}},
+	}
+	got, err := DecodePathCommands(encodePath(cmds...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(cmds) {
+		t.Fatalf("expected %d commands, got %d", len(cmds), len(got))
+	}
+	for i, cmd := range cmds {
+		if got[i].Kind != cmd.Kind || len(got[i].Points) != len(cmd.Points) {
+			t.Fatalf("command %d: expected %v, got %v", i, cmd, got[i])
+		}
+		for j, p := range cmd.Points {
+			if got[i].Points[j] != p {
+				t.Errorf("command %d, point %d: expected %v, got %v", i, j, p, got[i].Points[j])
+			}
+		}
+	}
+
+	if _, err := DecodePathCommands([]byte{9}); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+	if _, err := DecodePathCommands([]byte{byte(PathLineTo), 0, 0}); err == nil {
+		t.Error("expected an error for a short command")
+	}
+}
@@ -0,0 +1,14 @@
+package scene
+
+import (
+	"io"
+)
+
+// Renderer writes a scene in an output format. Renderers drawing the scene
+// draw each of its top-level elements in order, and the children of
+// sections, frames and groups right after the container holding them.
+// Children are placed in the scene's coordinate space like any other
+// element, so their own transforms apply as they are.
+type Renderer interface {
+	Render(s *Scene, w io.Writer) error
+}
@@ -0,0 +1,550 @@
+package scene
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+)
+
+// Kind classifies elements by how they are drawn.
+type Kind int
+
+const (
+	KindOther Kind = iota
+	KindShape
+	KindSticky
+	KindText
+	KindConnector
+	KindTable
+	KindCodeBlock
+	KindLinkPreview
+	KindRectangle
+	KindEllipse
+	KindLine
+	KindStar
+	KindPolygon
+	KindVector
+	KindHighlight
+	KindWashiTape
+	KindStamp
+	KindSection
+	KindFrame
+	KindGroup
+)
+
+var kindNames = map[Kind]string{
+	KindOther:       "other",
+	KindShape:       "shape",
+	KindSticky:      "sticky",
+	KindText:        "text",
+	KindConnector:   "connector",
+	KindTable:       "table",
+	KindCodeBlock:   "code-block",
+	KindLinkPreview: "link-preview",
+	KindRectangle:   "rectangle",
+	KindEllipse:     "ellipse",
+	KindLine:        "line",
+	KindStar:        "star",
+	KindPolygon:     "polygon",
+	KindVector:      "vector",
+	KindHighlight:   "highlight",
+	KindWashiTape:   "washi-tape",
+	KindStamp:       "stamp",
+	KindSection:     "section",
+	KindFrame:       "frame",
+	KindGroup:       "group",
+}
+
+func (k Kind) String() string {
+	return kindNames[k]
+}
+
+// IsDecoration reports whether elements of the kind are drawn on top of
+// others, rather than being part of the diagram.
+func (k Kind) IsDecoration() bool {
+	return k == KindHighlight || k == KindWashiTape || k == KindStamp
+}
+
+// IsContainer reports whether elements of the kind hold other elements.
+func (k Kind) IsContainer() bool {
+	return k == KindSection || k == KindFrame || k == KindGroup
+}
+
+// IsPrimitive reports whether elements of the kind are plain vector shapes.
+func (k Kind) IsPrimitive() bool {
+	switch k {
+	case KindRectangle, KindEllipse, KindLine, KindStar, KindPolygon, KindVector:
+		return true
+	}
+	return false
+}
+
+func kindOf(n *fig.NodeChange) Kind {
+	if n.LinkPreviewData != nil {
+		return KindLinkPreview
+	}
+	switch n.Type {
+	case fig.NodeTypeShapeWithText:
+		return KindShape
+	case fig.NodeTypeSticky:
+		return KindSticky
+	case fig.NodeTypeText:
+		return KindText
+	case fig.NodeTypeConnector:
+		return KindConnector
+	case fig.NodeTypeTable:
+		return KindTable
+	case fig.NodeTypeCodeBlock:
+		return KindCodeBlock
+	case fig.NodeTypeRectangle, fig.NodeTypeRoundedRectangle:
+		return KindRectangle
+	case fig.NodeTypeEllipse:
+		return KindEllipse
+	case fig.NodeTypeLine:
+		return KindLine
+	case fig.NodeTypeStar:
+		return KindStar
+	case fig.NodeTypeRegularPolygon:
+		return KindPolygon
+	case fig.NodeTypeVector:
+		return KindVector
+	case fig.NodeTypeHighlight:
+		return KindHighlight
+	case fig.NodeTypeWashiTape:
+		return KindWashiTape
+	case fig.NodeTypeStamp:
+		return KindStamp
+	case fig.NodeTypeSection:
+		return KindSection
+	case fig.NodeTypeFrame:
+		return KindFrame
+	case fig.NodeTypeGroup:
+		return KindGroup
+	}
+	return KindOther
+}
+
+// Element is a node of the document, resolved into the scene's coordinate
+// space. Sizes and positions are in canvas units.
+type Element struct {
+	ID       string
+	Kind     Kind
+	Node     *fig.NodeChange
+	Parent   *Element
+	Children []*Element
+
+	// Transform maps the element's own coordinates into the scene's, and
+	// Bounds is the bounding box of its outline once transformed.
+	Transform Transform
+	Size      Point
+	Bounds    Rect
+
+	Style Style
+	Text  *Text
+
+	// Link is the URL the whole element links to, and LinkAreas the linked
+	// spans of its text.
+	Link      string
+	LinkAreas []LinkArea
+
+	Images []ImageFill
+	// Paths holds the decoded fill geometry of primitives, falling back to
+	// their stroke geometry, and the stroke geometry of highlights.
+	Paths     [][]PathCommand
+	Connector *Connector
+	Table     *Table
+
+	// Emoji holds the code points of the emoji drawn on stamps and
+	// reactions, and Host the element they have been placed on.
+	Emoji []uint
+	Host  *Element
+}
+
+// Center returns the center of the element's outline.
+func (e *Element) Center() Point {
+	return e.Transform.Apply(e.Size.Mul(0.5))
+}
+
+// Scene is the resolved content of a page, or of a selection within it.
+type Scene struct {
+	Document *decoder.Document
+	Page     *fig.NodeChange
+	Root     *fig.NodeChange
+
+	// Bounds is the bounding box of the content, or of the selected node
+	// or region. Bounded is set when a selection defines it, in which case
+	// it should frame the output rather than the drawn content, and Clip
+	// when content is to be clipped to it.
+	Bounds  Rect
+	Bounded bool
+	Clip    bool
+
+	// Elements holds the elements drawn at the top level, in drawing order.
+	Elements []*Element
+
+	byID map[string]*Element
+
+	// index holds the bounds of indexed, the elements region queries select
+	// from. It is built by the first query.
+	index   *SpatialIndex
+	indexed []*Element
+}
+
+// Intersecting returns, parents before their children, the elements other
+// than connectors whose bounds intersect region, including those nested
+// within containers. The spatial index built by the first call is reused by
+// the following ones.
+func (s *Scene) Intersecting(region Rect) []*Element {
+	if s.index == nil {
+		var bounds []Rect
+		s.Walk(func(e *Element) bool {
+			if e.Kind != KindConnector && e.ID != "" {
+				s.indexed = append(s.indexed, e)
+				bounds = append(bounds, e.Bounds)
+			}
+			return true
+		})
+		s.index = NewSpatialIndex(bounds)
+	}
+	var res []*Element
+	for _, i := range s.index.Query(region) {
+		res = append(res, s.indexed[i])
+	}
+	return res
+}
+
+// Element returns the element identified by id, formatted as
+// "SESSION:LOCAL", if it is part of the scene.
+func (s *Scene) Element(id string) *Element {
+	return s.byID[id]
+}
+
+// Walk calls fn for each element of the scene, parents before their
+// children. Returning false from fn skips the element's children.
+func (s *Scene) Walk(fn func(e *Element) bool) {
+	var walk func(els []*Element)
+	walk = func(els []*Element) {
+		for _, e := range els {
+			if fn(e) {
+				walk(e.Children)
+			}
+		}
+	}
+	walk(s.Elements)
+}
+
+// GUIDKey formats g as "SESSION:LOCAL".
+func GUIDKey(g *fig.GUID) string {
+	return fmt.Sprintf("%d:%d", g.SessionId, g.LocalId)
+}
+
+// placement is the position of a node within the scene, whether or not it
+// is drawn.
+type placement struct {
+	element   *Element
+	transform Transform
+	size      Point
+}
+
+type builder struct {
+	doc      *decoder.Document
+	opts     *Options
+	scene    *Scene
+	geometry map[string]placement
+	// stray holds the connectors left out of a selection, as they are
+	// attached to nodes outside of it.
+	stray map[*fig.NodeChange]bool
+	// spaces maps connectors to the transform of the coordinate space their
+	// endpoints and control points are given in.
+	spaces map[*Element]Transform
+}
+
+// sizeOf returns the size of n's outline. Connectors attach to the outer
+// bounds of tables, which are defined by their rows and columns rather than
+// their own size.
+func sizeOf(n *fig.NodeChange) Point {
+	var size Point
+	if n.Size != nil {
+		size = Point{X: n.Size.X, Y: n.Size.Y}
+	}
+	if n.Type == fig.NodeTypeTable {
+		if s := MakeTable(n).Size(); s.X > 0 && s.Y > 0 {
+			size = s
+		}
+	}
+	return size
+}
+
+// Build resolves the page selected by opts into a scene.
+func Build(doc *decoder.Document, opts *Options) (*Scene, error) {
+	if opts == nil || opts.Page == nil {
+		return nil, fmt.Errorf("no page to build a scene from")
+	}
+	root := opts.Page
+	if opts.Root != nil {
+		root = opts.Root
+	}
+	b := &builder{
+		doc:  doc,
+		opts: opts,
+		scene: &Scene{
+			Document: doc,
+			Page:     opts.Page,
+			Root:     root,
+			Clip:     opts.Clip,
+			byID:     map[string]*Element{},
+		},
+		geometry: map[string]placement{},
+		spaces:   map[*Element]Transform{},
+	}
+
+	b.place(opts.Page, root)
+	b.stray = strayConnectors(opts.Page, root)
+
+	var elements []*Element
+	for _, v := range root.Children {
+		if !b.stray[v] {
+			elements = append(elements, b.element(v, nil, Identity()))
+		}
+	}
+	for _, v := range outerConnectors(opts.Page, root) {
+		elements = append(elements, b.element(v.node, nil, v.space))
+	}
+	b.resolveHosts(elements)
+
+	s := b.scene
+	s.Bounds = contentBounds(elements)
+	if root != opts.Page && root.Size != nil {
+		s.Bounds = Rect{Max: Point{X: root.Size.X, Y: root.Size.Y}}
+	}
+	s.Bounded = root != opts.Page
+
+	s.Elements = elements
+	var crossing map[*Element]bool
+	if opts.Region != nil {
+		mode := opts.RegionConnectors
+		if mode == "" {
+			mode = ConnectorsKeep
+		}
+		s.Elements, crossing = regionSelection(s, *opts.Region, mode)
+		// The index covers the elements of the whole root, and is built
+		// again for those selected.
+		s.index, s.indexed = nil, nil
+		s.Bounds, s.Bounded = *opts.Region, true
+	}
+
+	for _, e := range s.Elements {
+		b.register(e)
+	}
+	s.Walk(func(e *Element) bool {
+		if e.Kind == KindConnector {
+			b.connect(e, crossing[e])
+		}
+		return true
+	})
+	return s, nil
+}
+
+// place records the position of every node of page within root's
+// coordinate space.
+func (b *builder) place(page, root *fig.NodeChange) {
+	rootSpace := Identity()
+	walk(page, Identity(), func(v *fig.NodeChange, space Transform) bool {
+		if v == root {
+			rootSpace = space.Compose(TransformFrom(v.Transform))
+			return false
+		}
+		return true
+	})
+	toRoot := rootSpace.Inverse()
+	record := func(v *fig.NodeChange, space Transform) bool {
+		if v.Guid != nil {
+			b.geometry[GUIDKey(v.Guid)] = placement{
+				transform: space.Compose(TransformFrom(v.Transform)),
+				size:      sizeOf(v),
+			}
+		}
+		return true
+	}
+	if root != page {
+		walk(page, toRoot, func(v *fig.NodeChange, space Transform) bool {
+			return v != root && record(v, space)
+		})
+	}
+	walk(root, Identity(), record)
+}
+
+// element builds the element for n, whose parent's coordinate space is
+// mapped into the scene's by space.
+func (b *builder) element(n *fig.NodeChange, parent *Element, space Transform) *Element {
+	e := &Element{
+		Kind:      kindOf(n),
+		Node:      n,
+		Parent:    parent,
+		Transform: space.Compose(TransformFrom(n.Transform)),
+		Size:      sizeOf(n),
+		Style:     StyleOf(n),
+	}
+	if n.Guid != nil {
+		e.ID = GUIDKey(n.Guid)
+	}
+	e.Bounds = e.Transform.Bounds(e.Size)
+	if e.Kind == KindConnector {
+		b.spaces[e] = space
+	}
+
+	switch e.Kind {
+	case KindText, KindSticky:
+		e.Text = textOf(n, b.opts.Mentions)
+	case KindShape, KindConnector:
+		e.Text = PlainText(CleanupText(n.Name))
+	case KindCodeBlock:
+		if n.TextData != nil {
+			e.Text = PlainText(n.TextData.Characters)
+		}
+	case KindTable:
+		e.Table = MakeTable(n)
+	case KindStamp:
+		e.Text = PlainText(n.Name)
+	}
+
+	if n.Hyperlink != nil && n.Hyperlink.Url != "" {
+		e.Link = n.Hyperlink.Url
+	} else if n.TextData != nil {
+		for _, box := range n.TextData.HyperlinkBoxes {
+			if box.Url == "" || box.Bounds == nil {
+				continue
+			}
+			min := Point{X: box.Bounds.X, Y: box.Bounds.Y}
+			e.LinkAreas = append(e.LinkAreas, LinkArea{
+				URL:    box.Url,
+				Bounds: Rect{Min: min, Max: min.Add(Point{X: box.Bounds.W, Y: box.Bounds.H})},
+			})
+		}
+	}
+
+	if b.doc != nil {
+		e.Images = imageFills(n, b.doc.Images, b.doc.Blobs)
+	}
+	switch {
+	case e.Kind.IsPrimitive():
+		e.Paths = b.geometryPaths(n.FillGeometry)
+		if len(e.Paths) == 0 {
+			e.Paths = b.geometryPaths(n.StrokeGeometry)
+		}
+	case e.Kind == KindHighlight:
+		e.Paths = b.geometryPaths(n.StrokeGeometry)
+	}
+	if p := EmojiPaint(n.FillPaints); p != nil {
+		e.Emoji = p.EmojiCodePoints
+	}
+
+	if e.Kind.IsContainer() {
+		for _, v := range n.Children {
+			if !b.stray[v] {
+				e.Children = append(e.Children, b.element(v, e, e.Transform))
+			}
+		}
+		b.resolveHosts(e.Children)
+	}
+	return e
+}
+
+func (b *builder) geometryPaths(paths []*fig.Path) [][]PathCommand {
+	if b.doc == nil {
+		return nil
+	}
+	var res [][]PathCommand
+	for _, p := range paths {
+		if p == nil || int(p.CommandsBlob) >= len(b.doc.Blobs) || b.doc.Blobs[p.CommandsBlob] == nil {
+			continue
+		}
+		cmds, err := DecodePathCommands(b.doc.Blobs[p.CommandsBlob].Bytes)
+		if err != nil || len(cmds) == 0 {
+			continue
+		}
+		res = append(res, cmds)
+	}
+	return res
+}
+
+// register indexes e and its children as part of the scene.
+func (b *builder) register(e *Element) {
+	if e.ID != "" {
+		b.scene.byID[e.ID] = e
+		if g, ok := b.geometry[e.ID]; ok {
+			g.element = e
+			b.geometry[e.ID] = g
+		}
+	}
+	for _, v := range e.Children {
+		b.register(v)
+	}
+}
+
+// connect resolves the path of connector e, which is to be clipped to the
+// scene's bounds when clipped is set.
+func (b *builder) connect(e *Element, clipped bool) {
+	n := e.Node
+	space := b.spaces[e]
+	c := &Connector{
+		Start:         endpoint(n.ConnectorStart, space, b.geometry),
+		End:           endpoint(n.ConnectorEnd, space, b.geometry),
+		LabelPosition: labelPosition(n.ConnectorTextMidpoint),
+		StartCap:      n.ConnectorStartCap,
+		EndCap:        n.ConnectorEndCap,
+		LineStyle:     n.ConnectorLineStyle,
+		Clipped:       clipped,
+	}
+	if e.Text.String() != "" {
+		c.Label = e.Text
+	}
+	c.route(n.ConnectorControlPoints, space)
+
+	bounds := EmptyRect()
+	for _, p := range c.Points {
+		bounds = bounds.Extend(p)
+	}
+	e.Bounds = bounds
+	e.Connector = c
+}
+
+// resolveHosts finds the element each stamp and reaction among siblings
+// has been placed on: the smallest one containing its center.
+func (b *builder) resolveHosts(siblings []*Element) {
+	for _, e := range siblings {
+		if e.Kind != KindStamp && e.Emoji == nil {
+			continue
+		}
+		center := e.Bounds.Center()
+		for _, v := range siblings {
+			if v == e || v.Kind.IsDecoration() || v.Kind == KindConnector || !v.Bounds.Contains(center) {
+				continue
+			}
+			if e.Host == nil || area(v.Bounds) < area(e.Host.Bounds) {
+				e.Host = v
+			}
+		}
+	}
+}
+
+func area(r Rect) float64 {
+	s := r.Size()
+	return s.X * s.Y
+}
+
+// contentBounds returns the bounding box of elements, ignoring connectors
+// and empty elements.
+func contentBounds(elements []*Element) Rect {
+	r := EmptyRect()
+	for _, e := range elements {
+		if e.Kind == KindConnector || (e.Size.X == 0 && e.Size.Y == 0) {
+			continue
+		}
+		r = r.Union(e.Bounds)
+	}
+	if r.IsEmpty() {
+		return Rect{}
+	}
+	return r
+}
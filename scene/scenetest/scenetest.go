@@ -0,0 +1,120 @@
+// Package scenetest provides a small board for testing renderers, and
+// compares their output against golden files.
+package scenetest
+
+import (
+	"bytes"
+	"flag"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+func guid(local uint) *fig.GUID {
+	return &fig.GUID{SessionId: 1, LocalId: local}
+}
+
+func translate(x, y float64) *fig.Matrix {
+	return &fig.Matrix{M00: 1, M11: 1, M02: x, M12: y}
+}
+
+func solid(r, g, b float64) []*fig.Paint {
+	return []*fig.Paint{{Type: fig.PaintTypeSolid, Visible: true, Opacity: 1, Color: &fig.Color{R: r, G: g, B: b, A: 1}}}
+}
+
+// Page returns a page holding a section with two stickies, one of them
+// voted on, a diamond connected to the first sticky by a labelled
+// connector, a text node and a code block.
+func Page() *fig.NodeChange {
+	sticky := func(local uint, x float64, text string) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: guid(local), Type: fig.NodeTypeSticky, Name: "Sticky", Visible: true, Opacity: 1,
+			Transform: translate(x, 40), Size: &fig.Vector{X: 120, Y: 120},
+			TextData:   &fig.TextData{Characters: text},
+			FillPaints: solid(1, 0.85, 0.4),
+		}
+	}
+	section := &fig.NodeChange{
+		Guid: guid(10), Type: fig.NodeTypeSection, Name: "Ideas", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 320, Y: 200},
+		FillPaints: solid(0.9, 0.95, 1),
+		Children: []*fig.NodeChange{
+			sticky(11, 20, "Ship it"),
+			sticky(12, 180, "Write \"tests\" & docs"),
+			{
+				Guid: guid(13), Type: fig.NodeTypeStamp, Visible: true, Opacity: 1,
+				Transform: translate(30, 50), Size: &fig.Vector{X: 20, Y: 20},
+				StampData: &fig.StampData{UserId: "42", VotingSessionId: "7"},
+			},
+		},
+	}
+	diamond := &fig.NodeChange{
+		Guid: guid(20), Type: fig.NodeTypeShapeWithText, Name: "Decide", Visible: true, Opacity: 1,
+		Transform: translate(500, 60), Size: &fig.Vector{X: 120, Y: 80},
+		ShapeWithTextType: fig.ShapeWithTextTypeDiamond,
+		FillPaints:        solid(0.8, 0.9, 0.8),
+	}
+	connector := &fig.NodeChange{
+		Guid: guid(30), Type: fig.NodeTypeConnector, Name: "yes", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{},
+		ConnectorStart:        &fig.ConnectorEndpoint{EndpointNodeId: guid(20), Magnet: fig.ConnectorMagnetLeft},
+		ConnectorEnd:          &fig.ConnectorEndpoint{EndpointNodeId: guid(11), Magnet: fig.ConnectorMagnetTop},
+		ConnectorEndCap:       fig.StrokeCapArrowLines,
+		ConnectorTextMidpoint: &fig.ConnectorTextMidpoint{},
+		StrokePaints:          solid(0, 0, 0),
+		StrokeWeight:          2,
+	}
+	text := &fig.NodeChange{
+		Guid: guid(40), Type: fig.NodeTypeText, Name: "Title", Visible: true, Opacity: 1,
+		Transform: translate(0, -60), Size: &fig.Vector{X: 200, Y: 30},
+		TextData:   &fig.TextData{Characters: "Retro <2024>"},
+		FillPaints: solid(0, 0, 0),
+	}
+	code := &fig.NodeChange{
+		Guid: guid(50), Type: fig.NodeTypeCodeBlock, Visible: true, Opacity: 1,
+		Transform: translate(0, 260), Size: &fig.Vector{X: 300, Y: 80},
+		CodeBlockLanguage: fig.CodeBlockLanguageGo,
+		TextData:          &fig.TextData{Characters: "fmt.Println(\"hi\")\n"},
+	}
+	return &fig.NodeChange{
+		Guid: &fig.GUID{LocalId: 1}, Type: fig.NodeTypeCanvas, Name: "Board", Visible: true,
+		Children: []*fig.NodeChange{text, section, diamond, connector, code},
+	}
+}
+
+// Scene builds Page into a scene.
+func Scene(t testing.TB) *scene.Scene {
+	t.Helper()
+	s, err := scene.Build(nil, &scene.Options{Page: Page()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// Golden compares got with the file named name within testdata, and
+// rewrites the file instead when tests run with -update.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s; run go test -update to rewrite it\ngot:\n%s", path, got)
+	}
+}
@@ -0,0 +1,180 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+)
+
+// Options defines which part of a document a scene is built from.
+type Options struct {
+	// Page is the canvas the scene is built from.
+	Page *fig.NodeChange
+
+	// Root, when set, is a node within Page whose children are drawn
+	// instead of the page's. Connectors with both ends among them are
+	// included, and the scene is bounded by Root's frame, to which it is
+	// clipped when Clip is set.
+	Root *fig.NodeChange
+	Clip bool
+
+	// Region, when set, restricts the scene to the elements intersecting
+	// it, given in canvas units within Root's coordinate space, and bounds
+	// the scene by it. RegionConnectors defines how connectors crossing its
+	// edge are handled, and defaults to ConnectorsKeep.
+	Region           *Rect
+	RegionConnectors ConnectorMode
+
+	// Mentions maps user IDs to the names displayed for their mentions.
+	Mentions map[string]string
+}
+
+// walk calls fn for each descendant of n, along with the transform mapping
+// the coordinate space the descendant is positioned in (its parent's) into
+// the one given by space. Returning false from fn skips the descendant's
+// children.
+func walk(n *fig.NodeChange, space Transform, fn func(v *fig.NodeChange, space Transform) bool) {
+	for _, v := range n.Children {
+		if fn(v, space) {
+			walk(v, space.Compose(TransformFrom(v.Transform)), fn)
+		}
+	}
+}
+
+// FindNode returns the node identified by guid, formatted as
+// "SESSION:LOCAL", within root's subtree.
+func FindNode(root *fig.NodeChange, guid string) (found *fig.NodeChange) {
+	walk(root, Identity(), func(v *fig.NodeChange, _ Transform) bool {
+		if found == nil && v.Guid != nil && GUIDKey(v.Guid) == guid {
+			found = v
+		}
+		return found == nil
+	})
+	return
+}
+
+// FindNamed returns the first node of the given type and name within
+// root's subtree.
+func FindNamed(root *fig.NodeChange, kind fig.NodeType, name string) (found *fig.NodeChange) {
+	walk(root, Identity(), func(v *fig.NodeChange, _ Transform) bool {
+		if found == nil && v.Type == kind && v.Name == name {
+			found = v
+		}
+		return found == nil
+	})
+	return
+}
+
+// outerConnector is a connector lying outside the selected root, along
+// with the transform mapping its parent's coordinate space into root's.
+type outerConnector struct {
+	node  *fig.NodeChange
+	space Transform
+}
+
+// subtree returns the IDs of the nodes within root's subtree.
+func subtree(root *fig.NodeChange) map[string]bool {
+	res := map[string]bool{}
+	walk(root, Identity(), func(v *fig.NodeChange, _ Transform) bool {
+		if v.Guid != nil {
+			res[GUIDKey(v.Guid)] = true
+		}
+		return true
+	})
+	return res
+}
+
+// outerConnectors returns the connectors of page lying outside root whose
+// ends are both attached to nodes within root's subtree.
+func outerConnectors(page, root *fig.NodeChange) []outerConnector {
+	if root == page {
+		return nil
+	}
+	inside := subtree(root)
+	attached := func(e *fig.ConnectorEndpoint) bool {
+		return e != nil && e.EndpointNodeId != nil && inside[GUIDKey(e.EndpointNodeId)]
+	}
+
+	var rootSpace Transform
+	var res []outerConnector
+	walk(page, Identity(), func(v *fig.NodeChange, space Transform) bool {
+		if v == root {
+			rootSpace = space.Compose(TransformFrom(v.Transform))
+			return false
+		}
+		if v.Type == fig.NodeTypeConnector && attached(v.ConnectorStart) && attached(v.ConnectorEnd) {
+			res = append(res, outerConnector{v, space})
+		}
+		return true
+	})
+	for i := range res {
+		res[i].space = rootSpace.Inverse().Compose(res[i].space)
+	}
+	return res
+}
+
+// strayConnectors returns the connectors within root's subtree with an end
+// attached to a node outside of it. Detached ends are kept, as they lie
+// where they were drawn.
+func strayConnectors(page, root *fig.NodeChange) map[*fig.NodeChange]bool {
+	res := map[*fig.NodeChange]bool{}
+	if root == page {
+		return res
+	}
+	inside := subtree(root)
+	outside := func(e *fig.ConnectorEndpoint) bool {
+		return e != nil && e.EndpointNodeId != nil && !inside[GUIDKey(e.EndpointNodeId)]
+	}
+	walk(root, Identity(), func(v *fig.NodeChange, _ Transform) bool {
+		if v.Type == fig.NodeTypeConnector && (outside(v.ConnectorStart) || outside(v.ConnectorEnd)) {
+			res[v] = true
+		}
+		return true
+	})
+	return res
+}
+
+// ConnectorMode defines how connectors crossing the edge of a region are
+// handled.
+type ConnectorMode string
+
+const (
+	ConnectorsKeep ConnectorMode = "keep"
+	ConnectorsDrop ConnectorMode = "drop"
+	ConnectorsClip ConnectorMode = "clip"
+)
+
+// regionSelection returns the top level elements of s intersecting region,
+// along with the connectors attached to them as defined by mode. Connector
+// ends are matched against every element intersecting region, so that
+// connectors attached to nested elements are handled as the ones attached to
+// their containers. crossing holds the connectors with a single end inside
+// the region, when mode is ConnectorsClip.
+func regionSelection(s *Scene, region Rect, mode ConnectorMode) (drawn []*Element, crossing map[*Element]bool) {
+	inside := map[string]bool{}
+	for _, e := range s.Intersecting(region) {
+		inside[e.ID] = true
+	}
+	attached := func(e *fig.ConnectorEndpoint) bool {
+		return e != nil && e.EndpointNodeId != nil && inside[GUIDKey(e.EndpointNodeId)]
+	}
+
+	crossing = map[*Element]bool{}
+	for _, e := range s.Elements {
+		if e.Kind != KindConnector {
+			if inside[e.ID] {
+				drawn = append(drawn, e)
+			}
+			continue
+		}
+		start, end := attached(e.Node.ConnectorStart), attached(e.Node.ConnectorEnd)
+		switch {
+		case start && end:
+		case (start || end) && mode == ConnectorsKeep:
+		case (start || end) && mode == ConnectorsClip:
+			crossing[e] = true
+		default:
+			continue
+		}
+		drawn = append(drawn, e)
+	}
+	return
+}
@@ -0,0 +1,151 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"testing"
+)
+
+func guid(local uint) *fig.GUID {
+	return &fig.GUID{SessionId: 1, LocalId: local}
+}
+
+func translate(x, y float64) *fig.Matrix {
+	return &fig.Matrix{M00: 1, M11: 1, M02: x, M12: y}
+}
+
+func box(local uint, x, y float64, name string) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeShapeWithText, Name: name, Visible: true, Opacity: 1,
+		Transform: translate(x, y), Size: &fig.Vector{X: 100, Y: 100},
+	}
+}
+
+func link(local, from, to uint) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeConnector, Visible: true, Opacity: 1,
+		Transform:      translate(0, 0),
+		ConnectorStart: &fig.ConnectorEndpoint{EndpointNodeId: guid(from), Magnet: fig.ConnectorMagnetRight},
+		ConnectorEnd:   &fig.ConnectorEndpoint{EndpointNodeId: guid(to), Magnet: fig.ConnectorMagnetLeft},
+	}
+}
+
+// board returns a page holding section 1:10, offset by (1000, 500), with
+// boxes 1:11 and 1:12 and a connector from 1:11 to box 1:20 outside of it,
+// plus box 1:20 and page-level connectors 1:21 (1:11 to 1:12) and 1:30
+// (1:20 to 1:11).
+func board() (page, section *fig.NodeChange) {
+	section = &fig.NodeChange{
+		Guid: guid(10), Type: fig.NodeTypeSection, Name: "Flow", Visible: true, Opacity: 1,
+		Transform: translate(1000, 500), Size: &fig.Vector{X: 500, Y: 200},
+		Children: []*fig.NodeChange{
+			box(11, 20, 20, "A"),
+			box(12, 300, 20, "B"),
+			link(13, 11, 20),
+		},
+	}
+	page = &fig.NodeChange{Guid: guid(0), Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{
+		section,
+		box(20, 0, 0, "Outside"),
+		link(21, 11, 12),
+		link(30, 20, 11),
+	}}
+	return
+}
+
+func ids(els []*Element) map[string]bool {
+	res := map[string]bool{}
+	for _, e := range els {
+		res[e.ID] = true
+	}
+	return res
+}
+
+func TestFindNodes(t *testing.T) {
+	page, section := board()
+
+	if got := FindNode(page, "1:12"); got == nil || got.Name != "B" {
+		t.Errorf("expected FindNode to find nested nodes, got %v", got)
+	}
+	if got := FindNode(page, "1:99"); got != nil {
+		t.Errorf("expected FindNode to return nil for unknown IDs, got %v", got)
+	}
+	if got := FindNamed(page, fig.NodeTypeSection, "Flow"); got != section {
+		t.Errorf("expected FindNamed to find the section, got %v", got)
+	}
+	if got := FindNamed(page, fig.NodeTypeFrame, "Flow"); got != nil {
+		t.Errorf("expected FindNamed to match the node type, got %v", got)
+	}
+}
+
+func TestBuildRoot(t *testing.T) {
+	page, section := board()
+	s, err := Build(nil, &Options{Page: page, Root: section})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := ids(s.Elements)
+	// 1:13 reaches outside the section, while 1:21 lies outside of it with
+	// both ends inside.
+	for id, want := range map[string]bool{"1:11": true, "1:12": true, "1:13": false, "1:21": true, "1:30": false, "1:20": false} {
+		if got[id] != want {
+			t.Errorf("%s: expected selected to be %v", id, want)
+		}
+	}
+	if !s.Bounded || s.Bounds != rect(0, 0, 500, 200) {
+		t.Errorf("expected the scene to be bounded by the section, got %v", s.Bounds)
+	}
+	// Elements are placed within the section's coordinate space.
+	if b := s.Element("1:11").Bounds; b != rect(20, 20, 120, 120) {
+		t.Errorf("expected 1:11 in section space, got %v", b)
+	}
+	c := s.Element("1:21").Connector
+	if c == nil || c.Start.Point != (Point{X: 120, Y: 70}) || c.End.Point != (Point{X: 300, Y: 70}) {
+		t.Errorf("expected 1:21 to be resolved in section space, got %+v", c)
+	}
+}
+
+func TestRegionSelectionNested(t *testing.T) {
+	page, _ := board()
+	for _, tc := range []struct {
+		name   string
+		region Rect
+		mode   ConnectorMode
+		want   map[string]bool
+	}{
+		// 1:11 lies within section 1:10, so 1:30 has both ends inside.
+		{"both ends", rect(0, 0, 1200, 600), ConnectorsDrop, map[string]bool{"1:10": true, "1:20": true, "1:30": true}},
+		// Only 1:20 is inside, and the connector crosses the edge.
+		{"drop", rect(0, 0, 200, 200), ConnectorsDrop, map[string]bool{"1:20": true, "1:30": false}},
+		{"keep", rect(0, 0, 200, 200), ConnectorsKeep, map[string]bool{"1:20": true, "1:30": true}},
+		{"clip", rect(0, 0, 200, 200), ConnectorsClip, map[string]bool{"1:20": true, "1:30": true}},
+	} {
+		region := tc.region
+		s, err := Build(nil, &Options{Page: page, Region: &region, RegionConnectors: tc.mode})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ids(s.Elements)
+		for id, want := range tc.want {
+			if got[id] != want {
+				t.Errorf("%s: %s: expected selected to be %v", tc.name, id, want)
+			}
+		}
+		if c := s.Element("1:30"); c != nil && c.Connector.Clipped != (tc.mode == ConnectorsClip) {
+			t.Errorf("%s: unexpected clipping of 1:30", tc.name)
+		}
+	}
+}
+
+func TestIntersectingIndexesNestedElements(t *testing.T) {
+	page, _ := board()
+	s, err := Build(nil, &Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1:12 sits at (1300, 520) once its section's transform is applied.
+	got := ids(s.Intersecting(rect(1350, 550, 1360, 560)))
+	if !got["1:12"] || !got["1:10"] || got["1:11"] || got["1:20"] {
+		t.Errorf("expected the section and 1:12, got %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package scene
+
+import (
+	"math"
+	"sort"
+)
+
+// spatialCellSize is the side, in canvas units, of the cells used by
+// SpatialIndex.
+const spatialCellSize = 1024
+
+// SpatialIndex buckets bounding boxes into a uniform grid, so that the
+// boxes intersecting a region can be found without visiting every one of
+// them.
+type SpatialIndex struct {
+	bounds []Rect
+	cells  map[[2]int][]int
+}
+
+func cellOf(p Point) [2]int {
+	return [2]int{
+		int(math.Floor(p.X / spatialCellSize)),
+		int(math.Floor(p.Y / spatialCellSize)),
+	}
+}
+
+// NewSpatialIndex indexes bounds.
+func NewSpatialIndex(bounds []Rect) *SpatialIndex {
+	idx := &SpatialIndex{bounds: bounds, cells: map[[2]int][]int{}}
+	for i, b := range bounds {
+		if b.IsEmpty() {
+			continue
+		}
+		c1, c2 := cellOf(b.Min), cellOf(b.Max)
+		for x := c1[0]; x <= c2[0]; x++ {
+			for y := c1[1]; y <= c2[1]; y++ {
+				idx.cells[[2]int{x, y}] = append(idx.cells[[2]int{x, y}], i)
+			}
+		}
+	}
+	return idx
+}
+
+// Query returns, in ascending order, the indices of the boxes intersecting
+// region.
+func (s *SpatialIndex) Query(region Rect) []int {
+	seen := map[int]bool{}
+	c1, c2 := cellOf(region.Min), cellOf(region.Max)
+	if (c2[0]-c1[0]+1)*(c2[1]-c1[1]+1) > len(s.cells) {
+		// The region covers more cells than are populated; iterating over
+		// them is cheaper.
+		for cell, items := range s.cells {
+			if cell[0] < c1[0] || cell[0] > c2[0] || cell[1] < c1[1] || cell[1] > c2[1] {
+				continue
+			}
+			for _, i := range items {
+				seen[i] = true
+			}
+		}
+	} else {
+		for x := c1[0]; x <= c2[0]; x++ {
+			for y := c1[1]; y <= c2[1]; y++ {
+				for _, i := range s.cells[[2]int{x, y}] {
+					seen[i] = true
+				}
+			}
+		}
+	}
+
+	res := make([]int, 0, len(seen))
+	for i := range seen {
+		if s.bounds[i].Intersects(region) {
+			res = append(res, i)
+		}
+	}
+	sort.Ints(res)
+	return res
+}
@@ -0,0 +1,34 @@
+package scene
+
+import (
+	"slices"
+	"testing"
+)
+
+func rect(x1, y1, x2, y2 float64) Rect {
+	return Rect{Min: Point{X: x1, Y: y1}, Max: Point{X: x2, Y: y2}}
+}
+
+func TestSpatialIndexQuery(t *testing.T) {
+	idx := NewSpatialIndex([]Rect{
+		rect(0, 0, 100, 100),
+		rect(3000, 3000, 3100, 3100),
+		rect(-2000, 500, 2000, 600),
+		rect(90, 90, 95, 95),
+	})
+	for _, tc := range []struct {
+		region Rect
+		want   []int
+	}{
+		{rect(50, 50, 60, 60), []int{0}},
+		{rect(80, 80, 1500, 550), []int{0, 2, 3}},
+		{rect(-1e6, -1e6, 1e6, 1e6), []int{0, 1, 2, 3}},
+		{rect(5000, 5000, 6000, 6000), []int{}},
+		// Boxes touching the region's edge intersect it.
+		{rect(100, 0, 200, 10), []int{0}},
+	} {
+		if got := idx.Query(tc.region); !slices.Equal(got, tc.want) {
+			t.Errorf("Query(%v): expected %v, got %v", tc.region, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package scene
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"math"
+)
+
+// Color is an RGBA color with components between 0 and 1.
+type Color struct {
+	R, G, B, A float64
+}
+
+// Hex returns c as a hexadecimal RGB color, such as #ffcc00.
+func (c *Color) Hex() string {
+	channel := func(v float64) int {
+		return int(max(min(v, 1), 0)*255 + 0.5)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(c.R), channel(c.G), channel(c.B))
+}
+
+// IsDark reports whether c is dark enough for text drawn over it to be
+// white. A nil color is not dark.
+func (c *Color) IsDark() bool {
+	return c != nil && 0.2126*c.R+0.7152*c.G+0.0722*c.B < 0.5
+}
+
+// Style holds the resolved paint of an element. Widths and dash lengths are
+// in canvas units.
+type Style struct {
+	Fill        *Color
+	FillOpacity float64
+	Stroke      *Color
+	StrokeWidth float64
+	Dash        []float64
+	Join        fig.StrokeJoin
+	Cap         fig.StrokeCap
+	Opacity     float64
+}
+
+// SolidPaint returns the first visible solid paint among paints.
+func SolidPaint(paints []*fig.Paint) *fig.Paint {
+	for _, p := range paints {
+		if p == nil || !p.Visible || p.Type != fig.PaintTypeSolid || p.Color == nil {
+			continue
+		}
+		return p
+	}
+	return nil
+}
+
+func colorOf(c *fig.Color) *Color {
+	return &Color{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+// StyleOf resolves the paint of n.
+func StyleOf(n *fig.NodeChange) Style {
+	s := Style{Join: n.StrokeJoin, Cap: n.StrokeCap, Opacity: 1, FillOpacity: 1}
+	if p := SolidPaint(n.FillPaints); p != nil {
+		s.Fill = colorOf(p.Color)
+		if p.Opacity > 0 && p.Opacity < 1 {
+			s.FillOpacity = p.Opacity
+		}
+	}
+	if p := SolidPaint(n.StrokePaints); p != nil {
+		s.Stroke = colorOf(p.Color)
+		s.StrokeWidth = n.StrokeWeight
+		s.Dash = n.DashPattern
+	}
+	if n.Opacity > 0 && n.Opacity < 1 {
+		s.Opacity = n.Opacity
+	}
+	return s
+}
+
+// Stroked reports whether the element's strokes are drawn.
+func (s Style) Stroked() bool {
+	return s.Stroke != nil && s.StrokeWidth > 0
+}
+
+// EmojiPaint returns the first visible paint carrying emoji code points.
+func EmojiPaint(paints []*fig.Paint) *fig.Paint {
+	for _, p := range paints {
+		if p != nil && p.Visible && len(p.EmojiCodePoints) > 0 {
+			return p
+		}
+	}
+	return nil
+}
+
+// IsVote reports whether the stamp n was placed during a voting session.
+func IsVote(n *fig.NodeChange) bool {
+	return n.StampData != nil && n.StampData.VotingSessionId != ""
+}
+
+// DescribeStamp describes the stamp n and who placed it, such as "Vote by
+// user 42".
+func DescribeStamp(n *fig.NodeChange) string {
+	kind := "Stamp"
+	if IsVote(n) {
+		kind = "Vote"
+	}
+	d := n.StampData
+	if d == nil {
+		return kind
+	}
+	by := d.StampedByUserId
+	if by == "" {
+		by = d.UserId
+	}
+	if by == "" {
+		return kind
+	}
+	return kind + " by user " + by
+}
+
+var stickyColors = []struct {
+	Name    string
+	R, G, B float64
+}{
+	{"gray", 0.902, 0.902, 0.902},
+	{"red", 1.000, 0.686, 0.639},
+	{"orange", 1.000, 0.769, 0.439},
+	{"yellow", 1.000, 0.851, 0.400},
+	{"green", 0.702, 0.937, 0.741},
+	{"teal", 0.639, 0.941, 0.910},
+	{"blue", 0.659, 0.855, 1.000},
+	{"violet", 0.827, 0.741, 1.000},
+	{"pink", 1.000, 0.741, 0.949},
+	{"white", 1.000, 1.000, 1.000},
+	{"black", 0.118, 0.118, 0.118},
+}
+
+// StickyColorName returns the name of the FigJam sticky color closest to
+// fill.
+func StickyColorName(fill *Color) string {
+	if fill == nil {
+		return "yellow"
+	}
+	name, best := "", math.MaxFloat64
+	for _, v := range stickyColors {
+		d := math.Pow(fill.R-v.R, 2) + math.Pow(fill.G-v.G, 2) + math.Pow(fill.B-v.B, 2)
+		if d < best {
+			name, best = v.Name, d
+		}
+	}
+	return name
+}
@@ -0,0 +1,73 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"testing"
+)
+
+func TestDescribeStamp(t *testing.T) {
+	for _, tc := range []struct {
+		data *fig.StampData
+		want string
+	}{
+		{nil, "Stamp"},
+		{&fig.StampData{UserId: "42", VotingSessionId: "7"}, "Vote by user 42"},
+		{&fig.StampData{UserId: "42", StampedByUserId: "43"}, "Stamp by user 43"},
+	} {
+		if got := DescribeStamp(&fig.NodeChange{Type: fig.NodeTypeStamp, StampData: tc.data}); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+	}
+}
+
+func TestStyleOf(t *testing.T) {
+	n := &fig.NodeChange{
+		Opacity:      0.5,
+		FillPaints:   []*fig.Paint{{Type: fig.PaintTypeSolid, Visible: false, Color: &fig.Color{R: 1, A: 1}}, {Type: fig.PaintTypeSolid, Visible: true, Opacity: 0.25, Color: &fig.Color{G: 1, A: 1}}},
+		StrokePaints: []*fig.Paint{{Type: fig.PaintTypeSolid, Visible: true, Opacity: 1, Color: &fig.Color{B: 1, A: 1}}},
+		StrokeWeight: 2,
+		DashPattern:  []float64{4, 2},
+	}
+	s := StyleOf(n)
+	if s.Fill == nil || *s.Fill != (Color{G: 1, A: 1}) || s.FillOpacity != 0.25 {
+		t.Errorf("expected the first visible fill, got %v at %v", s.Fill, s.FillOpacity)
+	}
+	if !s.Stroked() || *s.Stroke != (Color{B: 1, A: 1}) || len(s.Dash) != 2 {
+		t.Errorf("expected a dashed blue stroke, got %+v", s)
+	}
+	if s.Opacity != 0.5 {
+		t.Errorf("expected the node's opacity, got %v", s.Opacity)
+	}
+	if StyleOf(&fig.NodeChange{}).Stroked() {
+		t.Error("expected nodes without strokes not to be stroked")
+	}
+}
+
+func TestColorHex(t *testing.T) {
+	c := &Color{R: 1, G: 0.8, B: -0.5, A: 1}
+	if got := c.Hex(); got != "#ffcc00" {
+		t.Errorf("expected #ffcc00, got %s", got)
+	}
+	if c.IsDark() || !(&Color{R: 0.1, G: 0.1, B: 0.1}).IsDark() {
+		t.Error("expected only the near-black color to be dark")
+	}
+	if (*Color)(nil).IsDark() {
+		t.Error("expected a nil color not to be dark")
+	}
+}
+
+func TestStickyColorName(t *testing.T) {
+	for _, tc := range []struct {
+		fill *Color
+		want string
+	}{
+		{nil, "yellow"},
+		{&Color{R: 1, G: 0.85, B: 0.4, A: 1}, "yellow"},
+		{&Color{R: 0.6, G: 0.85, B: 1, A: 1}, "blue"},
+		{&Color{R: 0.1, G: 0.1, B: 0.1, A: 1}, "black"},
+	} {
+		if got := StickyColorName(tc.fill); got != tc.want {
+			t.Errorf("expected %q, got %q", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package scene
+
+import (
+	"cmp"
+	"github.com/heyvito/figz/fig"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Table is the grid of a table node. Sizes are in canvas units, and cells
+// absent from the grid are nil.
+type Table struct {
+	RowHeights   []float64
+	ColumnWidths []float64
+	Cells        [][]*Cell
+}
+
+type Cell struct {
+	Node *fig.NodeChange
+	Text *Text
+	Fill *Color
+}
+
+func (t *Table) Size() Point {
+	var s Point
+	for _, v := range t.ColumnWidths {
+		s.X += v
+	}
+	for _, v := range t.RowHeights {
+		s.Y += v
+	}
+	return s
+}
+
+// orderedSizes sorts the entries of positions by their fractional index and
+// returns the size of each one, as defined by sizes.
+func orderedSizes(positions *fig.TableRowColumnPositionMap, sizes *fig.TableRowColumnSizeMap) []float64 {
+	if positions == nil {
+		return nil
+	}
+	entries := slices.Clone(positions.Entries)
+	slices.SortFunc(entries, func(a, b *fig.TableRowColumnPositionMapEntry) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+
+	sizeOf := map[string]float64{}
+	if sizes != nil {
+		for _, v := range sizes.Entries {
+			sizeOf[GUIDKey(v.Id)] = v.Size
+		}
+	}
+
+	res := make([]float64, len(entries))
+	for i, v := range entries {
+		res[i] = sizeOf[GUIDKey(v.Id)]
+	}
+	return res
+}
+
+// MakeTable resolves the grid of a table node, assigning each cell to the
+// row and column its position is closest to.
+func MakeTable(table *fig.NodeChange) *Table {
+	t := &Table{
+		RowHeights:   orderedSizes(table.TableRowPositions, table.TableRowHeights),
+		ColumnWidths: orderedSizes(table.TableColumnPositions, table.TableColumnWidths),
+	}
+	if len(t.RowHeights) == 0 || len(t.ColumnWidths) == 0 {
+		return t
+	}
+
+	rowStarts := make([]float64, len(t.RowHeights))
+	for i := 1; i < len(rowStarts); i++ {
+		rowStarts[i] = rowStarts[i-1] + t.RowHeights[i-1]
+	}
+	colStarts := make([]float64, len(t.ColumnWidths))
+	for i := 1; i < len(colStarts); i++ {
+		colStarts[i] = colStarts[i-1] + t.ColumnWidths[i-1]
+	}
+
+	t.Cells = make([][]*Cell, len(t.RowHeights))
+	for i := range t.Cells {
+		t.Cells[i] = make([]*Cell, len(t.ColumnWidths))
+	}
+
+	for _, cell := range table.Children {
+		if cell.Type != fig.NodeTypeTableCell || cell.Transform == nil {
+			continue
+		}
+		row := nearestIndex(rowStarts, cell.Transform.M12)
+		col := nearestIndex(colStarts, cell.Transform.M02)
+		c := &Cell{Node: cell, Text: PlainText(CleanupText(strings.TrimRight(NodeText(cell), "\n")))}
+		if p := SolidPaint(cell.FillPaints); p != nil {
+			c.Fill = colorOf(p.Color)
+		}
+		t.Cells[row][col] = c
+	}
+
+	return t
+}
+
+func nearestIndex(starts []float64, v float64) int {
+	idx, best := 0, math.MaxFloat64
+	for i, s := range starts {
+		if d := math.Abs(v - s); d < best {
+			idx, best = i, d
+		}
+	}
+	return idx
+}
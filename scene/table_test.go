@@ -0,0 +1,73 @@
+package scene
+
+import (
+	"github.com/heyvito/figz/fig"
+	"testing"
+)
+
+// table builds a table whose rows and columns are listed out of order, so
+// that their fractional positions decide where each cell goes.
+func table() *fig.NodeChange {
+	cell := func(local uint, x, y float64, text string) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: guid(local), Type: fig.NodeTypeTableCell, Visible: true, Opacity: 1,
+			Transform: translate(x, y), Size: &fig.Vector{X: 100, Y: 40},
+			TextData: &fig.TextData{Characters: text},
+		}
+	}
+	return &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeTable, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 1, Y: 1},
+		TableRowPositions: &fig.TableRowColumnPositionMap{Entries: []*fig.TableRowColumnPositionMapEntry{
+			{Id: guid(101), Position: "b"},
+			{Id: guid(100), Position: "a"},
+		}},
+		TableRowHeights: &fig.TableRowColumnSizeMap{Entries: []*fig.TableRowColumnSizeMapEntry{
+			{Id: guid(100), Size: 40},
+			{Id: guid(101), Size: 60},
+		}},
+		TableColumnPositions: &fig.TableRowColumnPositionMap{Entries: []*fig.TableRowColumnPositionMapEntry{
+			{Id: guid(201), Position: "b"},
+			{Id: guid(200), Position: "a"},
+		}},
+		TableColumnWidths: &fig.TableRowColumnSizeMap{Entries: []*fig.TableRowColumnSizeMapEntry{
+			{Id: guid(200), Size: 100},
+			{Id: guid(201), Size: 200},
+		}},
+		Children: []*fig.NodeChange{
+			cell(2, 100, 40, "50% done"),
+			cell(3, 0, 0, "Name"),
+			cell(4, 100, 0, "Status"),
+		},
+	}
+}
+
+func TestMakeTable(t *testing.T) {
+	grid := MakeTable(table())
+
+	if len(grid.RowHeights) != 2 || grid.RowHeights[0] != 40 || grid.RowHeights[1] != 60 {
+		t.Errorf("unexpected row heights %v", grid.RowHeights)
+	}
+	if len(grid.ColumnWidths) != 2 || grid.ColumnWidths[0] != 100 || grid.ColumnWidths[1] != 200 {
+		t.Errorf("unexpected column widths %v", grid.ColumnWidths)
+	}
+	if size := grid.Size(); size.X != 300 || size.Y != 100 {
+		t.Errorf("unexpected size %v", size)
+	}
+
+	texts := [][]string{{"Name", "Status"}, {"", "50% done"}}
+	for i, row := range texts {
+		for j, want := range row {
+			cell := grid.Cells[i][j]
+			if want == "" {
+				if cell != nil {
+					t.Errorf("cell %d,%d: expected no cell, got %q", i, j, cell.Text.String())
+				}
+				continue
+			}
+			if cell == nil || cell.Text.String() != want {
+				t.Errorf("cell %d,%d: expected %q", i, j, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package scene
+
+import (
+	"cmp"
+	"github.com/heyvito/figz/fig"
+	"slices"
+	"strings"
+)
+
+// TextRun is a span of text sharing the same semantics. The Text of
+// mentions is already resolved to the user's name when known.
+type TextRun struct {
+	Text          string
+	Mention       bool
+	MentionUserID string
+}
+
+// Text is the content of an element, split into runs.
+type Text struct {
+	Runs []TextRun
+}
+
+// PlainText returns a Text with a single run.
+func PlainText(s string) *Text {
+	return &Text{Runs: []TextRun{{Text: s}}}
+}
+
+func (t *Text) String() string {
+	if t == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// LinkArea is a linked region of an element, in its local coordinates.
+type LinkArea struct {
+	URL    string
+	Bounds Rect
+}
+
+// SafeURL reports whether url may be linked to from exported documents,
+// which is only the case for http, https and mailto URLs. Other schemes,
+// such as javascript, would run code where the document is opened.
+func SafeURL(url string) bool {
+	scheme, _, ok := strings.Cut(url, ":")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(scheme)) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+var emptyComponentNames = map[string]struct{}{
+	"Connector Name":  {},
+	"Shape with text": {},
+	"Connector line":  {},
+}
+
+// CleanupText drops the placeholder names FigJam gives to components
+// without text.
+func CleanupText(t string) string {
+	if _, ok := emptyComponentNames[t]; ok {
+		return ""
+	}
+	return t
+}
+
+// NodeText returns the characters of n, falling back to its name.
+func NodeText(n *fig.NodeChange) string {
+	if n.TextData != nil && n.TextData.Characters != "" {
+		return n.TextData.Characters
+	}
+	return n.Name
+}
+
+// textOf splits the text of n into runs, resolving the names of mentioned
+// users through mentions.
+func textOf(n *fig.NodeChange, mentions map[string]string) *Text {
+	text := []rune(strings.TrimRight(NodeText(n), "\n"))
+	mentionName := func(fallback string) string {
+		if n.Mention == nil {
+			return fallback
+		}
+		if name, ok := mentions[n.Mention.MentionedUserId]; ok {
+			return "@" + name
+		}
+		return fallback
+	}
+	mentionID := ""
+	if n.Mention != nil {
+		mentionID = n.Mention.MentionedUserId
+	}
+
+	var boxes []*fig.MentionBox
+	if n.TextData != nil {
+		boxes = slices.Clone(n.TextData.MentionBoxes)
+		slices.SortFunc(boxes, func(a, b *fig.MentionBox) int {
+			return cmp.Compare(a.StartIndex, b.StartIndex)
+		})
+	}
+	if len(boxes) == 0 && n.Mention != nil {
+		return &Text{Runs: []TextRun{{Text: mentionName(string(text)), Mention: true, MentionUserID: mentionID}}}
+	}
+
+	res := &Text{}
+	last := 0
+	for _, box := range boxes {
+		start, end := int(box.StartIndex), int(box.EndIndex)
+		if start < last || end > len(text) || start >= end {
+			continue
+		}
+		if start > last {
+			res.Runs = append(res.Runs, TextRun{Text: string(text[last:start])})
+		}
+		res.Runs = append(res.Runs, TextRun{Text: mentionName(string(text[start:end])), Mention: true, MentionUserID: mentionID})
+		last = end
+	}
+	if last < len(text) || len(res.Runs) == 0 {
+		res.Runs = append(res.Runs, TextRun{Text: string(text[last:])})
+	}
+	return res
+}
@@ -0,0 +1,104 @@
+// Command enumnames writes fig/names.go, which maps the values of the
+// enumerations declared in fig/fig.go to the names of their constants.
+//
+// Run it from the repository's root after regenerating fig/fig.go:
+//
+//	go run ./script/enumnames
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+)
+
+const (
+	input  = "fig/fig.go"
+	output = "fig/names.go"
+)
+
+type enum struct {
+	name      string
+	constants []string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, input, nil, 0)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed parsing %s: %v\n", input, err)
+		os.Exit(1)
+	}
+
+	var enums []*enum
+	byName := map[string]*enum{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				if ident, ok := spec.Type.(*ast.Ident); ok && ident.Name == "int" {
+					e := &enum{name: spec.Name.Name}
+					enums = append(enums, e)
+					byName[e.name] = e
+				}
+			case *ast.ValueSpec:
+				ident, ok := spec.Type.(*ast.Ident)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				if e, ok := byName[ident.Name]; ok {
+					for _, name := range spec.Names {
+						e.constants = append(e.constants, name.Name)
+					}
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by script/enumnames. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package fig\n\n")
+	for _, e := range enums {
+		fmt.Fprintf(&b, "var %sNames = map[%s]string{\n", unexported(e.name), e.name)
+		for _, c := range e.constants {
+			fmt.Fprintf(&b, "%s: %q,\n", c, c)
+		}
+		fmt.Fprintf(&b, "}\n\n")
+	}
+	fmt.Fprintf(&b, "// EnumName returns the name of the constant v holds, such as\n")
+	fmt.Fprintf(&b, "// \"ShapeWithTextTypeDiamond\". It reports false when v is not a value of\n")
+	fmt.Fprintf(&b, "// an enumeration, or has no constant.\n")
+	fmt.Fprintf(&b, "func EnumName(v any) (string, bool) {\n")
+	fmt.Fprintf(&b, "switch v := v.(type) {\n")
+	for _, e := range enums {
+		fmt.Fprintf(&b, "case %s:\n", e.name)
+		fmt.Fprintf(&b, "name, ok := %sNames[v]\n", unexported(e.name))
+		fmt.Fprintf(&b, "return name, ok\n")
+	}
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "return \"\", false\n")
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed formatting %s: %v\n", output, err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(output, src, 0644); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed writing %s: %v\n", output, err)
+		os.Exit(1)
+	}
+}
+
+func unexported(name string) string {
+	return strings.ToLower(name[:1]) + name[1:]
+}
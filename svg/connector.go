@@ -0,0 +1,155 @@
+package svg
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+const (
+	connectorWidth = 1.5
+	// endpointGap is the distance left between connectors and the outline
+	// of the nodes they are attached to.
+	endpointGap = 5
+	// cornerRadius is the radius connectors turn their corners with.
+	cornerRadius = 10
+)
+
+// markerShape describes the tip drawn for a stroke cap, pointing right
+// within a 10×10 box. Tips are drawn so that refX lies on the end of the
+// path.
+type markerShape struct {
+	name    string
+	element string
+	refX    int
+}
+
+var markerShapes = map[fig.StrokeCap]markerShape{
+	fig.StrokeCapArrowLines:       {"arrow-lines", `<path d="M1,1 L9,5 L1,9" fill="none" stroke="%s" stroke-width="1.5" stroke-linecap="round" stroke-linejoin="round"/>`, 9},
+	fig.StrokeCapArrowEquilateral: {"arrow-equilateral", `<path d="M0,0 L10,5 L0,10 Z" fill="%s"/>`, 7},
+	fig.StrokeCapTriangleFilled:   {"triangle-filled", `<path d="M0,1 L10,5 L0,9 Z" fill="%s"/>`, 7},
+	fig.StrokeCapDiamondFilled:    {"diamond-filled", `<path d="M0,5 L5,0 L10,5 L5,10 Z" fill="%s"/>`, 5},
+	fig.StrokeCapCircleFilled:     {"circle-filled", `<circle cx="5" cy="5" r="4" fill="%s"/>`, 5},
+}
+
+// marker returns a reference to the marker drawing cap in the given color,
+// or an empty string when the cap is not drawn as a tip.
+func (c *Compiler) marker(cap fig.StrokeCap, color string) string {
+	shape, ok := markerShapes[cap]
+	if !ok {
+		return ""
+	}
+	id := fmt.Sprintf("marker-%s-%s", shape.name, strings.TrimPrefix(color, "#"))
+	if _, ok := c.defs[id]; !ok {
+		c.defs[id] = fmt.Sprintf(`<marker id="%s" viewBox="0 0 10 10" refX="%d" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse">%s</marker>`,
+			id, shape.refX, fmt.Sprintf(shape.element, color))
+	}
+	return "url(#" + id + ")"
+}
+
+// boundsClip returns a reference to the clip path restricting drawing to
+// the scene's bounds.
+func (c *Compiler) boundsClip() string {
+	if c.clipID == "" {
+		c.clipID = c.define("clip", fmt.Sprintf(`<clipPath id="%%s"><rect %s/></clipPath>`, c.rectAttrs(c.scene.Bounds)))
+	}
+	return "url(#" + c.clipID + ")"
+}
+
+// endpointPosition returns the point a connector starts or ends at,
+// slightly away from the outline of its node.
+func endpointPosition(e scene.Endpoint) scene.Point {
+	if e.ID == "" {
+		return e.Point
+	}
+	return e.Point.Add(e.Direction.Normal().Mul(endpointGap))
+}
+
+// roundedPath returns the d attribute of a path through points, rounding
+// its corners.
+func (c *Compiler) roundedPath(points []scene.Point) string {
+	parts := []string{"M" + c.point(points[0])}
+	for i := 1; i < len(points)-1; i++ {
+		prev, cur, next := points[i-1], points[i], points[i+1]
+		r := min(cornerRadius, cur.Distance(prev)/2, cur.Distance(next)/2)
+		if r == 0 {
+			parts = append(parts, "L"+c.point(cur))
+			continue
+		}
+		before := cur.Add(prev.Sub(cur).Mul(r / cur.Distance(prev)))
+		after := cur.Add(next.Sub(cur).Mul(r / cur.Distance(next)))
+		parts = append(parts, "L"+c.point(before), "Q"+c.point(cur)+" "+c.point(after))
+	}
+	parts = append(parts, "L"+c.point(points[len(points)-1]))
+	return strings.Join(parts, " ")
+}
+
+func (c *Compiler) drawConnector(e *scene.Element) {
+	conn := e.Connector
+	points := append([]scene.Point{}, conn.Points...)
+	points[0] = endpointPosition(conn.Start)
+	points[len(points)-1] = endpointPosition(conn.End)
+
+	color := "#000000"
+	if e.Style.Stroke != nil {
+		color = e.Style.Stroke.Hex()
+	}
+	width := connectorWidth
+	if e.Style.StrokeWidth > 0 {
+		width = e.Style.StrokeWidth
+	}
+	style := scene.Style{Stroke: e.Style.Stroke, StrokeWidth: width, Dash: e.Style.Dash, Join: e.Style.Join}
+	if style.Stroke == nil {
+		style.Stroke = &scene.Color{A: 1}
+	}
+	attrs := append([]attribute{attr("d", c.roundedPath(points)), attr("fill", "none")}, c.strokeAttributes(style)...)
+	attrs = append(attrs,
+		attr("marker-start", c.marker(conn.StartCap, color)),
+		attr("marker-end", c.marker(conn.EndCap, color)),
+	)
+	if e.Style.Opacity < 1 {
+		attrs = append(attrs, attr("opacity", c.num(e.Style.Opacity)))
+	}
+
+	group := []attribute{attr("id", NodeName(e.Node))}
+	if conn.Clipped && c.scene.Bounded {
+		group = append(group, attr("clip-path", c.boundsClip()))
+	}
+	c.open("g", group...)
+	c.leaf("path", attrs...)
+	if conn.Label != nil {
+		c.drawLabel(e, conn.LabelPoint())
+	}
+	c.close("g")
+}
+
+// drawLabel draws the label of connector e centered at p, over a white
+// background.
+func (c *Compiler) drawLabel(e *scene.Element, p scene.Point) {
+	size := fontSize(e.Node)
+	lines := wrapText(e.Connector.Label, 0, size)
+	longest := 0
+	for _, runs := range lines {
+		n := 0
+		for _, run := range runs {
+			n += len([]rune(run.Text))
+		}
+		longest = max(longest, n)
+	}
+	half := scene.Point{
+		X: float64(longest)*size*charWidth/2 + 4,
+		Y: float64(len(lines))*size*lineHeight/2 + 2,
+	}
+	box := scene.Rect{Min: p.Sub(half), Max: p.Add(half)}
+	c.leaf("rect", attr("x", c.num(box.Min.X)), attr("y", c.num(box.Min.Y)),
+		attr("width", c.num(box.Size().X)), attr("height", c.num(box.Size().Y)), attr("fill", "#ffffff"))
+	c.drawTextBlock(&textBlock{
+		lines:  lines,
+		box:    box,
+		size:   size,
+		align:  fig.TextAlignHorizontalCenter,
+		middle: true,
+		color:  "#000000",
+	})
+}
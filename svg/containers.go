@@ -0,0 +1,46 @@
+package svg
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+// drawContainer draws the background and title of sections and frames.
+// Groups have neither, and only their children are drawn.
+func (c *Compiler) drawContainer(e *scene.Element) {
+	if e.Kind == scene.KindGroup {
+		return
+	}
+	attrs := []attribute{attr("width", c.num(e.Size.X)), attr("height", c.num(e.Size.Y))}
+	if e.Kind == scene.KindSection {
+		attrs = append(attrs, attr("rx", "8"))
+	}
+	style := e.Style
+	if style.Fill == nil && !style.Stroked() {
+		attrs = append(attrs, attr("fill", "none"), attr("stroke", "#808080"), attr("stroke-width", "1"))
+	} else {
+		attrs = append(attrs, c.paintAttributes(style)...)
+	}
+
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	c.leaf("rect", attrs...)
+	if name := e.Node.Name; name != "" {
+		// Section titles are placed within their top left corner, and
+		// frame titles right above it.
+		b := &textBlock{
+			lines:  wrapText(scene.PlainText(name), 0, defaultFontSize),
+			box:    scene.Rect{Min: scene.Point{X: textPadding, Y: textPadding}, Max: e.Size},
+			size:   defaultFontSize,
+			align:  fig.TextAlignHorizontalLeft,
+			color:  textColor(style.Fill),
+			weight: "bold",
+		}
+		if e.Kind == scene.KindFrame {
+			b.box.Min = scene.Point{Y: -defaultFontSize * lineHeight}
+			b.color = "#000000"
+			b.weight = ""
+		}
+		c.drawTextBlock(b)
+	}
+	c.close("g")
+}
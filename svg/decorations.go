@@ -0,0 +1,113 @@
+package svg
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+const (
+	highlightOpacity = 0.4
+	washiTapeOpacity = 0.6
+	badgeRadius      = 10
+	badgeSpacing     = 25
+)
+
+// washiTapePatterns holds the tiles drawn over washi tapes, in a 8×8 box.
+var washiTapePatterns = map[fig.StrokeCap]string{
+	fig.StrokeCapWashiTape1: `<path d="M0,8 L8,0" stroke="#ffffff" stroke-width="1.5"/>`,
+	fig.StrokeCapWashiTape2: `<circle cx="4" cy="4" r="1.5" fill="#ffffff"/>`,
+	fig.StrokeCapWashiTape3: `<path d="M0,8 L8,0 M0,0 L8,8" stroke="#ffffff" stroke-width="1"/>`,
+	fig.StrokeCapWashiTape4: `<path d="M0,4 H8" stroke="#ffffff" stroke-width="1.5"/>`,
+	fig.StrokeCapWashiTape5: `<path d="M0,4 H8 M4,0 V8" stroke="#ffffff" stroke-width="1"/>`,
+	fig.StrokeCapWashiTape6: `<circle cx="2" cy="2" r="1" fill="#ffffff"/><circle cx="6" cy="6" r="1" fill="#ffffff"/>`,
+}
+
+func decorationColor(style scene.Style, fallback string) string {
+	if style.Stroke != nil {
+		return style.Stroke.Hex()
+	}
+	if style.Fill != nil {
+		return style.Fill.Hex()
+	}
+	return fallback
+}
+
+func (c *Compiler) drawHighlight(e *scene.Element) {
+	color := decorationColor(e.Style, "#ffff00")
+	c.open("g", c.transform(e.Transform), attr("opacity", c.num(highlightOpacity)))
+	if len(e.Paths) == 0 {
+		// Without geometry, approximate the marker stroke by a line
+		// crossing the node's bounds.
+		y := c.num(e.Size.Y / 2)
+		c.leaf("path", attr("d", "M0,"+y+" H"+c.num(e.Size.X)), attr("stroke", color),
+			attr("stroke-width", c.num(e.Size.Y)), attr("stroke-linecap", "round"))
+	}
+	for _, cmds := range e.Paths {
+		c.leaf("path", attr("d", c.pathData(cmds)), attr("fill", color))
+	}
+	c.close("g")
+}
+
+func (c *Compiler) drawWashiTape(e *scene.Element) {
+	color := decorationColor(e.Style, "#808080")
+	c.open("g", c.transform(e.Transform), attr("opacity", c.num(washiTapeOpacity)))
+	strip := []attribute{attr("width", c.num(e.Size.X)), attr("height", c.num(e.Size.Y))}
+	c.leaf("rect", append(strip, attr("fill", color))...)
+	if tile, ok := washiTapePatterns[e.Node.StrokeCap]; ok {
+		id := fmt.Sprintf("pattern-washi-tape-%d", e.Node.StrokeCap)
+		c.defs[id] = `<pattern id="` + id + `" patternUnits="userSpaceOnUse" width="8" height="8">` + tile + `</pattern>`
+		c.leaf("rect", append(strip, attr("fill", "url(#"+id+")"))...)
+	}
+	c.close("g")
+}
+
+func (c *Compiler) drawBadge(e *scene.Element, label string) {
+	pos := e.Bounds.Center()
+	if e.Host != nil {
+		// Badges are stacked along the top edge of their host, starting
+		// from its right corner.
+		key := e.Host.ID
+		pos = scene.Point{X: e.Host.Bounds.Max.X - float64(c.badges[key])*badgeSpacing, Y: e.Host.Bounds.Min.Y}
+		c.badges[key]++
+	}
+	c.open("g", attr("transform", "translate("+c.num(pos.X)+" "+c.num(pos.Y)+")"))
+	c.leaf("circle", attr("r", c.num(badgeRadius)), attr("fill", "#ffffff"),
+		attr("stroke", decorationColor(e.Style, "#000000")), attr("stroke-width", "1"))
+	c.drawTextBlock(&textBlock{
+		lines:  [][]scene.TextRun{{{Text: label}}},
+		box:    scene.Rect{Min: scene.Point{X: -badgeRadius, Y: -badgeRadius}, Max: scene.Point{X: badgeRadius, Y: badgeRadius}},
+		size:   badgeRadius,
+		align:  fig.TextAlignHorizontalCenter,
+		middle: true,
+		color:  "#000000",
+	})
+	c.close("g")
+}
+
+// drawStamp draws the badge of a stamp, labelled with its text or emoji.
+// Other stamps are labelled "+1" when they are votes, or with a star.
+func (c *Compiler) drawStamp(e *scene.Element) {
+	c.comment("%s", scene.DescribeStamp(e.Node))
+	label := e.Text.String()
+	if e.Emoji != nil {
+		label = emojiText(e.Emoji)
+	}
+	if label == "" && scene.IsVote(e.Node) {
+		label = "+1"
+	} else if label == "" {
+		label = "★"
+	}
+	c.drawBadge(e, label)
+}
+
+func (c *Compiler) drawDecoration(e *scene.Element) {
+	switch e.Kind {
+	case scene.KindHighlight:
+		c.drawHighlight(e)
+	case scene.KindWashiTape:
+		c.drawWashiTape(e)
+	case scene.KindStamp:
+		c.drawStamp(e)
+	}
+}
@@ -0,0 +1,110 @@
+package svg
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// ResolveImage returns the reference img is drawn from: a data URI, or the
+// name of the file it has been written to when images are linked.
+func (c *Compiler) ResolveImage(img *scene.Image) (string, error) {
+	if href, ok := c.images[img.Hash]; ok {
+		return href, nil
+	}
+	mime := img.MIMEType()
+	if mime == "" {
+		return "", fmt.Errorf("image %s has an unsupported format", img.Hash)
+	}
+
+	href := "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(img.Data)
+	if c.opts.LinkImages {
+		href = img.Hash + "." + img.Format
+		if err := os.WriteFile(filepath.Join(c.opts.ImageDir, href), img.Data, 0644); err != nil {
+			return "", fmt.Errorf("unable to write image %s: %w", href, err)
+		}
+	}
+	c.images[img.Hash] = href
+	return href, nil
+}
+
+func (c *Compiler) drawImageFills(e *scene.Element) {
+	for _, fill := range e.Images {
+		c.drawImagePaint(e, fill)
+	}
+}
+
+func (c *Compiler) drawImagePaint(e *scene.Element, fill scene.ImageFill) {
+	p := fill.Paint
+	err := fill.Err
+	var href string
+	if err == nil {
+		href, err = c.ResolveImage(fill.Image)
+	}
+	if err != nil {
+		c.comment("Skipping image fill of %s: %s", e.Node.Name, err)
+		return
+	}
+
+	size := e.Size
+	rotation := math.Mod(p.Rotation, 360)
+	rotated := math.Mod(math.Abs(p.Rotation), 180) == 90
+
+	// Natural image size, as displayed within the node's bounds.
+	imgSize := scene.Point{X: float64(p.OriginalImageWidth), Y: float64(p.OriginalImageHeight)}
+	if imgSize.X == 0 || imgSize.Y == 0 {
+		imgSize = size
+	}
+
+	clip := c.define("clip", fmt.Sprintf(`<clipPath id="%%s"><rect width="%s" height="%s"/></clipPath>`, c.num(size.X), c.num(size.Y)))
+	c.open("g", c.transform(e.Transform), attr("clip-path", "url(#"+clip+")"))
+	place := func(corner, display scene.Point, aspect string) {
+		center := corner.Add(display.Mul(0.5))
+		if rotated {
+			display = scene.Point{X: display.Y, Y: display.X}
+			corner = center.Sub(display.Mul(0.5))
+		}
+		var transform string
+		if rotation != 0 {
+			transform = fmt.Sprintf("rotate(%s %s %s)", c.num(-rotation), c.num(center.X), c.num(center.Y))
+		}
+		c.leaf("image",
+			attr("x", c.num(corner.X)), attr("y", c.num(corner.Y)),
+			attr("width", c.num(display.X)), attr("height", c.num(display.Y)),
+			attr("preserveAspectRatio", aspect), attr("transform", transform),
+			attr("href", href))
+	}
+
+	switch p.ImageScaleMode {
+	case fig.ImageScaleModeFit:
+		place(scene.Point{}, size, "xMidYMid meet")
+	case fig.ImageScaleModeFill:
+		place(scene.Point{}, size, "xMidYMid slice")
+	case fig.ImageScaleModeTile:
+		tileScale := p.Scale
+		if tileScale == 0 {
+			tileScale = 1
+		}
+		tile := imgSize.Mul(tileScale)
+		if rotated {
+			tile = scene.Point{X: tile.Y, Y: tile.X}
+		}
+		pattern := c.define("pattern", fmt.Sprintf(`<pattern id="%%s" patternUnits="userSpaceOnUse" width="%s" height="%s"><image width="%s" height="%s" preserveAspectRatio="none" href="%s"/></pattern>`,
+			c.num(tile.X), c.num(tile.Y), c.num(tile.X), c.num(tile.Y), textEscaper.Replace(href)))
+		c.leaf("rect", attr("width", c.num(size.X)), attr("height", c.num(size.Y)), attr("fill", "url(#"+pattern+")"))
+	default:
+		// Stretch; a non-identity paint transform means the image was
+		// cropped, and maps the node's bounds into the image's unit square.
+		display, corner := size, scene.Point{}
+		if t := p.Transform; t != nil && t.M00 > 0 && t.M11 > 0 {
+			display = scene.Point{X: size.X / t.M00, Y: size.Y / t.M11}
+			corner = scene.Point{X: -t.M02 * display.X, Y: -t.M12 * display.Y}
+		}
+		place(corner, display, "none")
+	}
+	c.close("g")
+}
@@ -0,0 +1,74 @@
+package svg
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+// drawLinkAreas makes the linked spans of an element's text clickable.
+func (c *Compiler) drawLinkAreas(e *scene.Element) {
+	if len(e.LinkAreas) == 0 {
+		return
+	}
+	c.open("g", c.transform(e.Transform))
+	for _, area := range e.LinkAreas {
+		if !scene.SafeURL(area.URL) {
+			continue
+		}
+		size := area.Bounds.Size()
+		c.open("a", attr("href", area.URL))
+		c.leaf("rect", attr("x", c.num(area.Bounds.Min.X)), attr("y", c.num(area.Bounds.Min.Y)),
+			attr("width", c.num(size.X)), attr("height", c.num(size.Y)), attr("fill", "transparent"))
+		c.close("a")
+	}
+	c.close("g")
+}
+
+func (c *Compiler) drawLinkPreview(e *scene.Element) {
+	data := e.Node.LinkPreviewData
+	title := data.Title
+	if title == "" {
+		title = data.Url
+	}
+	box := padded(e.Size)
+	width := box.Size().X
+
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	link := scene.SafeURL(data.Url)
+	if link {
+		c.open("a", attr("href", data.Url))
+	}
+	c.leaf("rect", attr("width", c.num(e.Size.X)), attr("height", c.num(e.Size.Y)), attr("rx", "4"),
+		attr("fill", "#ffffff"), attr("stroke", "#808080"), attr("stroke-width", "1"))
+	blocks := []*textBlock{{
+		lines:  wrapText(scene.PlainText(title), width, defaultFontSize),
+		size:   defaultFontSize,
+		color:  "#000000",
+		weight: "bold",
+	}}
+	if data.Provider != "" {
+		blocks = append(blocks, &textBlock{
+			lines: wrapText(scene.PlainText(data.Provider), width, defaultFontSize*0.75),
+			size:  defaultFontSize * 0.75,
+			color: "#808080",
+		})
+	}
+	if data.Description != "" {
+		blocks = append(blocks, &textBlock{
+			lines: wrapText(scene.PlainText(data.Description), width, defaultFontSize*0.875),
+			size:  defaultFontSize * 0.875,
+			color: "#000000",
+		})
+	}
+	top := box.Min.Y
+	for _, b := range blocks {
+		b.box = scene.Rect{Min: scene.Point{X: box.Min.X, Y: top}, Max: box.Max}
+		b.align = fig.TextAlignHorizontalLeft
+		c.drawTextBlock(b)
+		top += float64(len(b.lines)) * b.size * lineHeight
+	}
+	if link {
+		c.close("a")
+	}
+	c.close("g")
+}
@@ -0,0 +1,168 @@
+package svg
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+// shapeStrokeWidth is the width of the outline of shapes with text.
+const shapeStrokeWidth = 1
+
+// padded returns the area of an element of the given size text is laid out
+// in.
+func padded(size scene.Point) scene.Rect {
+	p := scene.Point{X: min(textPadding, size.X/2), Y: min(textPadding, size.Y/2)}
+	return scene.Rect{Min: p, Max: size.Sub(p)}
+}
+
+func (c *Compiler) drawShapeWithText(e *scene.Element) {
+	attrs := []attribute{attr("d", c.pathData(scene.Outline(e))), attr("fill", "none")}
+	if fill := e.Style.Fill; fill != nil {
+		attrs[1] = attr("fill", fill.Hex())
+	}
+	attrs = append(attrs, attr("stroke", "#000000"), attr("stroke-width", c.num(shapeStrokeWidth)))
+	if e.Style.Stroke != nil {
+		attrs[2] = attr("stroke", e.Style.Stroke.Hex())
+	}
+
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	c.leaf("path", attrs...)
+	b := newTextBlock(e, padded(e.Size), true)
+	b.color = textColor(e.Style.Fill)
+	c.drawTextBlock(b)
+	c.close("g")
+}
+
+func (c *Compiler) drawSticky(e *scene.Element) {
+	fill := "#ffffff"
+	if e.Style.Fill != nil {
+		fill = e.Style.Fill.Hex()
+	}
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	c.leaf("rect", attr("width", c.num(e.Size.X)), attr("height", c.num(e.Size.Y)), attr("fill", fill))
+	b := newTextBlock(e, padded(e.Size), true)
+	b.color = textColor(e.Style.Fill)
+	c.drawTextBlock(b)
+	c.close("g")
+}
+
+func (c *Compiler) drawPrimitive(e *scene.Element) {
+	n := e.Node
+	outline := scene.Outline(e)
+	if len(outline) == 0 {
+		c.comment("Skipping %s: no geometry available", n.Name)
+		return
+	}
+
+	attrs := append([]attribute{attr("d", c.pathData(outline))}, c.paintAttributes(e.Style)...)
+	switch e.Kind {
+	case scene.KindEllipse:
+		if n.ArcData != nil && n.ArcData.InnerRadius > 0 {
+			attrs = append(attrs, attr("fill-rule", "evenodd"))
+		}
+	case scene.KindLine:
+		attrs[1] = attr("fill", "none")
+		if e.Style.Stroke == nil {
+			attrs = append(attrs, attr("stroke", "#000000"), attr("stroke-width", c.num(max(n.StrokeWeight, 1))))
+		}
+		switch n.StrokeCap {
+		case fig.StrokeCapRound:
+			attrs = append(attrs, attr("stroke-linecap", "round"))
+		case fig.StrokeCapSquare:
+			attrs = append(attrs, attr("stroke-linecap", "square"))
+		default:
+			color := "#000000"
+			if e.Style.Stroke != nil {
+				color = e.Style.Stroke.Hex()
+			}
+			attrs = append(attrs, attr("marker-end", c.marker(n.StrokeCap, color)))
+		}
+	}
+
+	c.open("g", attr("id", NodeName(n)), c.transform(e.Transform))
+	c.leaf("path", attrs...)
+	c.close("g")
+}
+
+func (c *Compiler) drawTable(e *scene.Element) {
+	grid := e.Table
+	size := grid.Size()
+	fill := "#ffffff"
+	if e.Style.Fill != nil {
+		fill = e.Style.Fill.Hex()
+	}
+
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	c.leaf("rect", attr("width", c.num(size.X)), attr("height", c.num(size.Y)), attr("fill", fill),
+		attr("stroke", "#000000"), attr("stroke-width", "1"))
+	y := 0.0
+	for i, row := range grid.Cells {
+		x := 0.0
+		for j, cell := range row {
+			box := scene.Rect{
+				Min: scene.Point{X: x, Y: y},
+				Max: scene.Point{X: x + grid.ColumnWidths[j], Y: y + grid.RowHeights[i]},
+			}
+			x = box.Max.X
+			if cell == nil {
+				continue
+			}
+			if cell.Fill != nil {
+				c.leaf("rect", attr("x", c.num(box.Min.X)), attr("y", c.num(box.Min.Y)),
+					attr("width", c.num(box.Size().X)), attr("height", c.num(box.Size().Y)),
+					attr("fill", cell.Fill.Hex()), attr("stroke", "#000000"), attr("stroke-width", "1"))
+			}
+			inner := padded(box.Size())
+			inner = scene.Rect{Min: box.Min.Add(inner.Min), Max: box.Min.Add(inner.Max)}
+			b := &textBlock{
+				lines:  wrapText(cell.Text, inner.Size().X, defaultFontSize),
+				box:    inner,
+				size:   defaultFontSize,
+				align:  fig.TextAlignHorizontalCenter,
+				middle: true,
+				color:  textColor(cell.Fill),
+			}
+			c.drawTextBlock(b)
+		}
+		y += grid.RowHeights[i]
+	}
+
+	// Grid lines between rows and columns.
+	var segments []string
+	y = 0
+	for _, h := range grid.RowHeights[:max(len(grid.RowHeights)-1, 0)] {
+		y += h
+		segments = append(segments, "M"+c.point(scene.Point{Y: y})+" H"+c.num(size.X))
+	}
+	x := 0.0
+	for _, w := range grid.ColumnWidths[:max(len(grid.ColumnWidths)-1, 0)] {
+		x += w
+		segments = append(segments, "M"+c.point(scene.Point{X: x})+" V"+c.num(size.Y))
+	}
+	if len(segments) > 0 {
+		c.leaf("path", attr("d", strings.Join(segments, " ")), attr("stroke", "#000000"), attr("stroke-width", "1"))
+	}
+	c.close("g")
+}
+
+func (c *Compiler) drawCodeBlock(e *scene.Element) {
+	fill := &scene.Color{R: 0.96, G: 0.96, B: 0.96, A: 1}
+	if e.Style.Fill != nil {
+		fill = e.Style.Fill
+	}
+	c.open("g", attr("id", NodeName(e.Node)), c.transform(e.Transform))
+	c.leaf("rect", attr("width", c.num(e.Size.X)), attr("height", c.num(e.Size.Y)), attr("rx", "4"), attr("fill", fill.Hex()))
+	size := fontSize(e.Node)
+	b := &textBlock{
+		lines:    wrapText(e.Text, 0, size),
+		box:      padded(e.Size),
+		size:     size,
+		align:    fig.TextAlignHorizontalLeft,
+		color:    textColor(fill),
+		font:     "monospace",
+		preserve: true,
+	}
+	c.drawTextBlock(b)
+	c.close("g")
+}
@@ -0,0 +1,187 @@
+package svg
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"slices"
+	"strings"
+)
+
+// DefaultPrecision is the amount of decimal places numbers are printed
+// with.
+const DefaultPrecision = 2
+
+// margin is the space left around the content when the scene does not
+// define its own bounds, in canvas units.
+const margin = 10
+
+type Options struct {
+	FilePath string
+
+	// LinkImages writes image fills to ImageDir and references them,
+	// instead of embedding them as data URIs.
+	LinkImages bool
+	ImageDir   string
+
+	// OmitDecorations skips highlights, washi tapes, stamps and emoji
+	// reactions.
+	OmitDecorations bool
+
+	// Precision is the amount of decimal places numbers are printed with,
+	// and defaults to DefaultPrecision.
+	Precision int
+}
+
+// Renderer renders scenes as SVG documents, in canvas units.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{
+		scene:     s,
+		opts:      opts,
+		precision: DefaultPrecision,
+		defs:      map[string]string{},
+		images:    map[string]string{},
+		badges:    map[string]int{},
+	}
+	if opts.Precision > 0 {
+		c.precision = opts.Precision
+	}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	scene     *scene.Scene
+	opts      *Options
+	precision int
+	b         strings.Builder
+	indent    int
+
+	// defs holds the definitions referenced by the drawing, by id.
+	defs   map[string]string
+	ids    int
+	clipID string
+	images map[string]string
+	badges map[string]int
+}
+
+// Compile returns the SVG document for the scene.
+func (c *Compiler) Compile() string {
+	c.indent = 1
+	bounds := c.viewBox()
+	if c.scene.Clip && c.scene.Bounded {
+		c.open("g", attr("clip-path", c.boundsClip()))
+	}
+	for _, e := range c.scene.Elements {
+		c.drawElement(e)
+	}
+	if c.scene.Clip && c.scene.Bounded {
+		c.close("g")
+	}
+
+	var out strings.Builder
+	size := bounds.Size()
+	fmt.Fprintf(&out, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&out, "<!-- This file was generated automatically by figz. https://github.com/heyvito/figz -->\n")
+	fmt.Fprintf(&out, "<!-- Input file: %s -->\n", scene.EscapeComment(c.opts.FilePath))
+	if root := c.scene.Root; root != c.scene.Page {
+		fmt.Fprintf(&out, "<!-- Root: %s (%s) -->\n", scene.EscapeComment(root.Name), scene.GUIDKey(root.Guid))
+	}
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %s %s" font-family="sans-serif">`+"\n",
+		c.num(size.X), c.num(size.Y), c.num(bounds.Min.X), c.num(bounds.Min.Y), c.num(size.X), c.num(size.Y))
+	if len(c.defs) > 0 {
+		out.WriteString("  <defs>\n")
+		keys := make([]string, 0, len(c.defs))
+		for k := range c.defs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&out, "    %s\n", c.defs[k])
+		}
+		out.WriteString("  </defs>\n")
+	}
+	out.WriteString(c.b.String())
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// viewBox returns the area of the scene the document shows.
+func (c *Compiler) viewBox() scene.Rect {
+	if c.scene.Bounded {
+		return c.scene.Bounds
+	}
+	r := c.scene.Bounds
+	for _, e := range c.scene.Elements {
+		if !e.Bounds.IsEmpty() {
+			r = r.Union(e.Bounds)
+		}
+	}
+	return scene.Rect{
+		Min: r.Min.Sub(scene.Point{X: margin, Y: margin}),
+		Max: r.Max.Add(scene.Point{X: margin, Y: margin}),
+	}
+}
+
+// define registers a definition under an id derived from prefix, returning
+// it. def is a format string receiving the id.
+func (c *Compiler) define(prefix, def string) string {
+	c.ids++
+	id := fmt.Sprintf("%s-%d", prefix, c.ids)
+	c.defs[id] = fmt.Sprintf(def, id)
+	return id
+}
+
+func (c *Compiler) drawElement(e *scene.Element) {
+	if e.Kind.IsDecoration() {
+		if !c.opts.OmitDecorations {
+			c.drawDecoration(e)
+		}
+		return
+	}
+
+	link := scene.SafeURL(e.Link) && e.Kind != scene.KindLinkPreview
+	if link {
+		c.open("a", attr("href", e.Link))
+	}
+	c.drawImageFills(e)
+	switch e.Kind {
+	case scene.KindLinkPreview:
+		c.drawLinkPreview(e)
+	case scene.KindText:
+		c.drawText(e)
+	case scene.KindShape:
+		c.drawShapeWithText(e)
+	case scene.KindSticky:
+		c.drawSticky(e)
+	case scene.KindConnector:
+		c.drawConnector(e)
+	case scene.KindTable:
+		c.drawTable(e)
+	case scene.KindCodeBlock:
+		c.drawCodeBlock(e)
+	case scene.KindRectangle, scene.KindEllipse, scene.KindLine, scene.KindStar,
+		scene.KindPolygon, scene.KindVector:
+		c.drawPrimitive(e)
+	case scene.KindSection, scene.KindFrame, scene.KindGroup:
+		c.drawContainer(e)
+	}
+	if link {
+		c.close("a")
+	}
+	c.drawLinkAreas(e)
+	if e.Emoji != nil && !c.opts.OmitDecorations {
+		c.drawBadge(e, emojiText(e.Emoji))
+	}
+	for _, child := range e.Children {
+		c.drawElement(child)
+	}
+}
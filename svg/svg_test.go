@@ -0,0 +1,71 @@
+package svg
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, s *scene.Scene, opts *Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: opts}).Render(s, &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, scenetest.Scene(t), &Options{FilePath: "board.jam"})
+	scenetest.Golden(t, "board.svg", []byte(out))
+
+	// Stickies are drawn within the group of their section.
+	section := strings.Index(out, `<g id="n-1-10">`)
+	sticky := strings.Index(out, `<g id="n-1-11"`)
+	if section < 0 || sticky < section {
+		t.Errorf("expected the sticky to be drawn within its section, got:\n%s", out)
+	}
+	for _, want := range []string{
+		"Retro &lt;2024&gt;",
+		"<!-- Vote by user 42 -->",
+		`marker-end="url(#marker-arrow-lines-000000)"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+
+	out = render(t, scenetest.Scene(t), &Options{OmitDecorations: true})
+	if strings.Contains(out, "Vote by user 42") {
+		t.Errorf("expected decorations to be omitted, got:\n%s", out)
+	}
+}
+
+func TestOnlySafeURLsAreLinked(t *testing.T) {
+	text := func(local uint, url string) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: &fig.GUID{SessionId: 1, LocalId: local}, Type: fig.NodeTypeText, Visible: true, Opacity: 1,
+			Transform: &fig.Matrix{M00: 1, M11: 1, M12: float64(local) * 40}, Size: &fig.Vector{X: 200, Y: 30},
+			TextData:  &fig.TextData{Characters: "Link"},
+			Hyperlink: &fig.Hyperlink{Url: url},
+		}
+	}
+	page := &fig.NodeChange{Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{
+		text(1, "https://example.com"),
+		text(2, "javascript:alert(1)"),
+	}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := render(t, s, nil)
+	if !strings.Contains(out, `href="https://example.com"`) {
+		t.Errorf("expected the https link, got:\n%s", out)
+	}
+	if strings.Contains(out, "javascript:") {
+		t.Errorf("expected the javascript URL not to be linked, got:\n%s", out)
+	}
+}
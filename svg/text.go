@@ -0,0 +1,208 @@
+package svg
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+const (
+	defaultFontSize = 16
+	lineHeight      = 1.2
+	// charWidth approximates the average advance of a character, as a
+	// fraction of the font size, for wrapping text without font metrics.
+	charWidth = 0.55
+	// textPadding is the space left between text and the edges of the
+	// element holding it.
+	textPadding = 10
+)
+
+// textBlock is text laid out within a box, in the coordinates of the
+// element holding it.
+type textBlock struct {
+	lines  [][]scene.TextRun
+	box    scene.Rect
+	size   float64
+	align  fig.TextAlignHorizontal
+	middle bool
+	color  string
+	font   string
+	weight string
+	style  string
+	// preserve keeps runs of spaces, which are otherwise collapsed.
+	preserve bool
+}
+
+// NodeName returns the id an element is emitted with, derived from its
+// GUID.
+func NodeName(n *fig.NodeChange) string {
+	return fmt.Sprintf("n-%d-%d", n.Guid.SessionId, n.Guid.LocalId)
+}
+
+func fontSize(n *fig.NodeChange) float64 {
+	if n.FontSize > 0 {
+		return n.FontSize
+	}
+	return defaultFontSize
+}
+
+// textColor returns the color of text drawn over fill.
+func textColor(fill *scene.Color) string {
+	if fill.IsDark() {
+		return "#ffffff"
+	}
+	return "#000000"
+}
+
+// wrapText splits t into lines at its line breaks, and wherever a line would
+// be wider than width. Text is not wrapped when width is zero.
+func wrapText(t *scene.Text, width, size float64) [][]scene.TextRun {
+	if t == nil {
+		return nil
+	}
+	limit := 0
+	if width > 0 {
+		limit = max(int(width/(size*charWidth)), 1)
+	}
+
+	lines := [][]scene.TextRun{nil}
+	length := 0
+	add := func(run scene.TextRun, s string) {
+		cur := lines[len(lines)-1]
+		if n := len(cur); n > 0 && cur[n-1].Mention == run.Mention && cur[n-1].MentionUserID == run.MentionUserID {
+			cur[n-1].Text += s
+		} else {
+			run.Text = s
+			cur = append(cur, run)
+		}
+		lines[len(lines)-1] = cur
+		length += len([]rune(s))
+	}
+	for _, run := range t.Runs {
+		for i, para := range strings.Split(run.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+				length = 0
+			}
+			for j, word := range strings.Split(para, " ") {
+				n := len([]rune(word))
+				if j > 0 {
+					if limit > 0 && length > 0 && length+1+n > limit {
+						lines = append(lines, nil)
+						length = 0
+					} else {
+						add(run, " ")
+					}
+				}
+				if word != "" {
+					add(run, word)
+				}
+			}
+		}
+	}
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// newTextBlock lays the text of e out within box.
+func newTextBlock(e *scene.Element, box scene.Rect, wrap bool) *textBlock {
+	n := e.Node
+	size := fontSize(n)
+	width := 0.0
+	if wrap {
+		width = box.Size().X
+	}
+	b := &textBlock{
+		lines:  wrapText(e.Text, width, size),
+		box:    box,
+		size:   size,
+		align:  fig.TextAlignHorizontalCenter,
+		middle: true,
+		color:  "#000000",
+	}
+	if f := n.FontName; f != nil {
+		b.font = f.Family + ", sans-serif"
+		style := strings.ToLower(f.Style)
+		if strings.Contains(style, "bold") {
+			b.weight = "bold"
+		}
+		if strings.Contains(style, "italic") {
+			b.style = "italic"
+		}
+	}
+	return b
+}
+
+func (c *Compiler) drawTextBlock(b *textBlock) {
+	if len(b.lines) == 0 {
+		return
+	}
+	step := b.size * lineHeight
+	top := b.box.Min.Y
+	if b.middle {
+		top = b.box.Center().Y - step*float64(len(b.lines))/2
+	}
+
+	x, anchor := b.box.Min.X, ""
+	switch b.align {
+	case fig.TextAlignHorizontalCenter:
+		x, anchor = b.box.Center().X, "middle"
+	case fig.TextAlignHorizontalRight:
+		x, anchor = b.box.Max.X, "end"
+	}
+
+	attrs := []attribute{
+		attr("font-size", c.num(b.size)),
+		attr("text-anchor", anchor),
+		attr("fill", b.color),
+	}
+	if b.font != "" {
+		attrs = append([]attribute{attr("font-family", b.font)}, attrs...)
+	}
+	attrs = append(attrs, attr("font-weight", b.weight), attr("font-style", b.style))
+	if b.preserve {
+		attrs = append(attrs, attr("xml:space", "preserve"))
+	}
+	c.open("text", attrs...)
+	for i, runs := range b.lines {
+		y := top + step*float64(i) + step*0.75
+		var content strings.Builder
+		for _, run := range runs {
+			if run.Mention {
+				fmt.Fprintf(&content, `<tspan fill="#0000ff">%s</tspan>`, textEscaper.Replace(run.Text))
+			} else {
+				content.WriteString(textEscaper.Replace(run.Text))
+			}
+		}
+		c.line(`<tspan x="%s" y="%s">%s</tspan>`, c.num(x), c.num(y), content.String())
+	}
+	c.close("text")
+}
+
+// emojiText returns the characters of the given code points.
+func emojiText(codePoints []uint) string {
+	chars := make([]rune, len(codePoints))
+	for i, v := range codePoints {
+		chars[i] = rune(v)
+	}
+	return string(chars)
+}
+
+func (c *Compiler) drawText(e *scene.Element) {
+	n := e.Node
+	b := newTextBlock(e, scene.Rect{Max: e.Size}, n.TextAutoResize != fig.TextAutoResizeWidthAndHeight)
+	b.align = n.TextAlignHorizontal
+	if b.align == fig.TextAlignHorizontalJustified {
+		b.align = fig.TextAlignHorizontalLeft
+	}
+	b.middle = n.TextAlignVertical == fig.TextAlignVerticalCenter
+	if fill := e.Style.Fill; fill != nil {
+		b.color = fill.Hex()
+	}
+	c.open("g", attr("id", NodeName(n)), c.transform(e.Transform))
+	c.drawTextBlock(b)
+	c.close("g")
+}
@@ -0,0 +1,158 @@
+package svg
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+var textEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+	`"`, `&quot;`,
+)
+
+// attribute is an XML attribute. Attributes with an empty value are
+// omitted.
+type attribute struct {
+	name, value string
+}
+
+func attr(name, value string) attribute {
+	return attribute{name, value}
+}
+
+func attrString(attrs []attribute) string {
+	var b strings.Builder
+	for _, a := range attrs {
+		if a.value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, ` %s="%s"`, a.name, textEscaper.Replace(a.value))
+	}
+	return b.String()
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+// open starts an element whose children are written until the matching
+// call to close.
+func (c *Compiler) open(name string, attrs ...attribute) {
+	c.line("<%s%s>", name, attrString(attrs))
+	c.indent++
+}
+
+func (c *Compiler) close(name string) {
+	c.indent--
+	c.line("</%s>", name)
+}
+
+// leaf writes an element without children.
+func (c *Compiler) leaf(name string, attrs ...attribute) {
+	c.line("<%s%s/>", name, attrString(attrs))
+}
+
+// comment writes a note about content that could not be converted.
+func (c *Compiler) comment(format string, args ...any) {
+	c.line("<!-- %s -->", scene.EscapeComment(fmt.Sprintf(format, args...)))
+}
+
+// num prints v with the configured precision, omitting trailing zeros.
+func (c *Compiler) num(v float64) string {
+	return scene.FormatNumber(v, c.precision)
+}
+
+func (c *Compiler) point(p scene.Point) string {
+	return c.num(p.X) + "," + c.num(p.Y)
+}
+
+func (c *Compiler) rectAttrs(r scene.Rect) string {
+	size := r.Size()
+	return fmt.Sprintf(`x="%s" y="%s" width="%s" height="%s"`, c.num(r.Min.X), c.num(r.Min.Y), c.num(size.X), c.num(size.Y))
+}
+
+// transform returns the transform attribute mapping an element's
+// coordinates into the scene's.
+func (c *Compiler) transform(t scene.Transform) attribute {
+	if t.IsTranslation() {
+		if t.M02 == 0 && t.M12 == 0 {
+			return attribute{}
+		}
+		return attr("transform", fmt.Sprintf("translate(%s %s)", c.num(t.M02), c.num(t.M12)))
+	}
+	return attr("transform", fmt.Sprintf("matrix(%s %s %s %s %s %s)",
+		c.num(t.M00), c.num(t.M10), c.num(t.M01), c.num(t.M11), c.num(t.M02), c.num(t.M12)))
+}
+
+// pathData returns the d attribute of a path following cmds.
+func (c *Compiler) pathData(cmds []scene.PathCommand) string {
+	var parts []string
+	for _, cmd := range cmds {
+		var op string
+		switch cmd.Kind {
+		case scene.PathClose:
+			parts = append(parts, "Z")
+			continue
+		case scene.PathMoveTo:
+			op = "M"
+		case scene.PathLineTo:
+			op = "L"
+		case scene.PathQuadTo:
+			op = "Q"
+		case scene.PathCubicTo:
+			op = "C"
+		}
+		points := make([]string, len(cmd.Points))
+		for i, p := range cmd.Points {
+			points[i] = c.point(p)
+		}
+		parts = append(parts, op+strings.Join(points, " "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// paintAttributes returns the presentation attributes of style. Elements
+// without a fill are left unfilled.
+func (c *Compiler) paintAttributes(style scene.Style) []attribute {
+	attrs := []attribute{attr("fill", "none")}
+	if style.Fill != nil {
+		attrs[0] = attr("fill", style.Fill.Hex())
+		if style.FillOpacity < 1 {
+			attrs = append(attrs, attr("fill-opacity", c.num(style.FillOpacity)))
+		}
+	}
+	if style.Stroked() {
+		attrs = append(attrs, c.strokeAttributes(style)...)
+	}
+	if style.Opacity < 1 {
+		attrs = append(attrs, attr("opacity", c.num(style.Opacity)))
+	}
+	return attrs
+}
+
+func (c *Compiler) strokeAttributes(style scene.Style) []attribute {
+	attrs := []attribute{
+		attr("stroke", style.Stroke.Hex()),
+		attr("stroke-width", c.num(style.StrokeWidth)),
+	}
+	if len(style.Dash) > 0 {
+		dash := make([]string, len(style.Dash))
+		for i, v := range style.Dash {
+			dash[i] = c.num(v)
+		}
+		attrs = append(attrs, attr("stroke-dasharray", strings.Join(dash, " ")))
+	}
+	switch style.Join {
+	case fig.StrokeJoinBevel:
+		attrs = append(attrs, attr("stroke-linejoin", "bevel"))
+	case fig.StrokeJoinRound:
+		attrs = append(attrs, attr("stroke-linejoin", "round"))
+	}
+	return attrs
+}
@@ -0,0 +1,232 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+	"unicode/utf8"
+)
+
+type CodeHighlighter string
+
+const (
+	HighlighterListings CodeHighlighter = "listings"
+	HighlighterMinted   CodeHighlighter = "minted"
+)
+
+var listingsLanguages = map[fig.CodeBlockLanguage]string{
+	fig.CodeBlockLanguageCpp:        "C++",
+	fig.CodeBlockLanguageRuby:       "Ruby",
+	fig.CodeBlockLanguageHtml:       "HTML",
+	fig.CodeBlockLanguagePython:     "Python",
+	fig.CodeBlockLanguageSql:        "SQL",
+	fig.CodeBlockLanguageBash:       "bash",
+	fig.CodeBlockLanguageGo:         "Go",
+	fig.CodeBlockLanguageTypescript: "TypeScript",
+}
+
+// listingsDefinitions holds the definitions of languages listings does not
+// provide, which are added to the preamble of pictures using them.
+var listingsDefinitions = map[string]string{
+	"Go": `\lstdefinelanguage{Go}{sensitive=true,` +
+		`morekeywords={break,case,chan,const,continue,default,defer,else,fallthrough,for,func,go,goto,if,import,` +
+		`interface,map,package,range,return,select,struct,switch,type,var},` +
+		`morekeywords=[2]{bool,byte,complex64,complex128,error,float32,float64,int,int8,int16,int32,int64,rune,` +
+		`string,uint,uint8,uint16,uint32,uint64,uintptr,any,true,false,iota,nil},` +
+		`morecomment=[l]{//},morecomment=[s]{/*}{*/},morestring=[b]",morestring=[b]',morestring=[s]{` + "`}{`}}",
+	"TypeScript": `\lstdefinelanguage{TypeScript}{sensitive=true,` +
+		`morekeywords={abstract,any,as,async,await,boolean,break,case,catch,class,const,constructor,continue,` +
+		`declare,default,delete,do,else,enum,export,extends,false,finally,for,from,function,if,implements,import,` +
+		`in,instanceof,interface,keyof,let,namespace,never,new,null,number,of,private,protected,public,readonly,` +
+		`return,static,string,super,switch,this,throw,true,try,type,typeof,undefined,unknown,var,void,while,yield},` +
+		`morecomment=[l]{//},morecomment=[s]{/*}{*/},morestring=[b]",morestring=[b]',morestring=[s]{` + "`}{`}}",
+}
+
+var mintedLanguages = map[fig.CodeBlockLanguage]string{
+	fig.CodeBlockLanguageTypescript: "typescript",
+	fig.CodeBlockLanguageCpp:        "cpp",
+	fig.CodeBlockLanguageRuby:       "ruby",
+	fig.CodeBlockLanguageCss:        "css",
+	fig.CodeBlockLanguageJavascript: "javascript",
+	fig.CodeBlockLanguageHtml:       "html",
+	fig.CodeBlockLanguageJson:       "json",
+	fig.CodeBlockLanguageGraphql:    "graphql",
+	fig.CodeBlockLanguagePython:     "python",
+	fig.CodeBlockLanguageGo:         "go",
+	fig.CodeBlockLanguageSql:        "sql",
+	fig.CodeBlockLanguageSwift:      "swift",
+	fig.CodeBlockLanguageKotlin:     "kotlin",
+	fig.CodeBlockLanguageRust:       "rust",
+	fig.CodeBlockLanguageBash:       "bash",
+	fig.CodeBlockLanguagePlaintext:  "text",
+}
+
+// boxName returns a name suitable for a TeX control sequence, which cannot
+// contain digits.
+func boxName(prefix string, idx int) string {
+	var name []byte
+	for idx >= 0 {
+		name = append([]byte{byte('a' + idx%26)}, name...)
+		idx = idx/26 - 1
+	}
+	return prefix + string(name)
+}
+
+func isDark(c *scene.Color) bool {
+	return 0.2126*c.R+0.7152*c.G+0.0722*c.B < 0.5
+}
+
+// listingsLiterals maps non-ASCII characters to their TeX equivalents, as
+// listings reads its input byte by byte and cannot typeset them under
+// pdflatex otherwise.
+var listingsLiterals = map[rune]string{
+	'á': `\'a`, 'é': `\'e`, 'í': `\'i`, 'ó': `\'o`, 'ú': `\'u`,
+	'Á': `\'A`, 'É': `\'E`, 'Í': `\'I`, 'Ó': `\'O`, 'Ú': `\'U`,
+	'à': "\\`a", 'è': "\\`e", 'ì': "\\`i", 'ò': "\\`o", 'ù': "\\`u",
+	'À': "\\`A", 'È': "\\`E", 'Ì': "\\`I", 'Ò': "\\`O", 'Ù': "\\`U",
+	'â': `\^a`, 'ê': `\^e`, 'î': `\^i`, 'ô': `\^o`, 'û': `\^u`,
+	'Â': `\^A`, 'Ê': `\^E`, 'Î': `\^I`, 'Ô': `\^O`, 'Û': `\^U`,
+	'ä': `\"a`, 'ë': `\"e`, 'ï': `\"i`, 'ö': `\"o`, 'ü': `\"u`,
+	'Ä': `\"A`, 'Ë': `\"E`, 'Ï': `\"I`, 'Ö': `\"O`, 'Ü': `\"U`,
+	'ã': `\~a`, 'õ': `\~o`, 'ñ': `\~n`, 'Ã': `\~A`, 'Õ': `\~O`, 'Ñ': `\~N`,
+	'ç': `\c{c}`, 'Ç': `\c{C}`, 'ß': `\ss{}`, 'ø': `\o{}`, 'Ø': `\O{}`,
+	'å': `\aa{}`, 'Å': `\AA{}`, 'æ': `\ae{}`, 'Æ': `\AE{}`, 'œ': `\oe{}`, 'Œ': `\OE{}`,
+	'‘': "`", '’': "'", '“': "``", '”': "''", '–': "--", '—': "---", '…': `\ldots{}`,
+	'«': `\guillemotleft{}`, '»': `\guillemotright{}`, '¿': "?`", '¡': "!`",
+	'°': `\ensuremath{^\circ}`, '×': `\ensuremath{\times}`, '÷': `\ensuremath{\div}`,
+	'±': `\ensuremath{\pm}`, '→': `\ensuremath{\rightarrow}`, '←': `\ensuremath{\leftarrow}`,
+	'€': `\texteuro{}`, '£': `\pounds{}`, '§': `\S{}`, '©': `\copyright{}`,
+}
+
+// literate returns the listings option typesetting the non-ASCII characters
+// in code, replacing the ones without an equivalent by a question mark.
+func literate(code string) string {
+	seen := map[rune]bool{}
+	var entries []string
+	for _, r := range code {
+		if r < utf8.RuneSelf || seen[r] {
+			continue
+		}
+		seen[r] = true
+		tex, ok := listingsLiterals[r]
+		if !ok {
+			tex = "?"
+		}
+		entries = append(entries, fmt.Sprintf("{%c}{{%s}}1", r, tex))
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+	return "literate=" + strings.Join(entries, " ")
+}
+
+// escapeEnd keeps code from ending its environment early, by breaking
+// occurrences of end with an empty escape to LaTeX. It returns the escape
+// character used, which is empty when code does not contain end.
+func escapeEnd(code, end string) (string, string) {
+	if !strings.Contains(code, end) {
+		return code, ""
+	}
+	for _, ch := range []string{"@", "|", "!", "`", "^", "~", "?", ";"} {
+		if strings.Contains(code, ch) {
+			continue
+		}
+		return strings.ReplaceAll(code, end, end[:1]+ch+ch+end[1:]), ch
+	}
+	// Every candidate is taken; a space still breaks the sequence, at the
+	// cost of showing up in the listing.
+	return strings.ReplaceAll(code, end, end[:1]+" "+end[1:]), ""
+}
+
+func (c *Compiler) codeEnvironment(lang fig.CodeBlockLanguage, dark bool, code string) (begin, body, end string) {
+	color := "black"
+	if dark {
+		color = "white"
+	}
+	switch c.opts.CodeHighlighter {
+	case HighlighterMinted:
+		c.RequirePackage("minted")
+		language, ok := mintedLanguages[lang]
+		if !ok {
+			language = "text"
+		}
+		opts := "fontsize=\\small"
+		if dark {
+			opts += ", style=monokai"
+		}
+		end = `\end{minted}`
+		body, esc := escapeEnd(code, end)
+		if esc != "" {
+			opts += ", escapeinside=" + esc + esc
+		}
+		return fmt.Sprintf(`\begin{minted}[%s]{%s}`, opts, language), body, end
+	default:
+		c.RequirePackage("listings")
+		c.RequirePackage("xcolor")
+		opts := []string{
+			fmt.Sprintf(`basicstyle=\ttfamily\small\color{%s}`, color),
+			"columns=fullflexible",
+			"keepspaces=true",
+			"showstringspaces=false",
+			"tabsize=4",
+		}
+		if language, ok := listingsLanguages[lang]; ok {
+			if def, ok := listingsDefinitions[language]; ok && !c.languages[language] {
+				c.AddPreamble(def)
+				c.languages[language] = true
+			}
+			opts = append(opts, "language="+language)
+		}
+		if lit := literate(code); lit != "" {
+			opts = append(opts, lit)
+		}
+		end = `\end{lstlisting}`
+		body, esc := escapeEnd(code, end)
+		if esc != "" {
+			opts = append(opts, "escapechar="+esc)
+		}
+		return fmt.Sprintf(`\begin{lstlisting}[%s]`, strings.Join(opts, ", ")), body, end
+	}
+}
+
+func (c *Compiler) drawCodeBlock(w DrawingNode) {
+	code := w.Element.Text.String()
+	q1, q2 := w.Frame()
+	size := q2.Diff(q1)
+	attrs := AttributeList{
+		AnchorAttribute("north west"),
+		InnerSepAttribute("0pt"),
+		&MinimumSizeAttribute{size.X, size.Y},
+		&RoundedCornersAttribute{4},
+	}
+
+	fill := &scene.Color{R: 0.96, G: 0.96, B: 0.96, A: 1}
+	if w.Element.Style.Fill != nil {
+		fill = w.Element.Style.Fill
+	}
+	dark := isDark(fill)
+	attrs = append(attrs, &FillAttribute{ColorSpec(fill)})
+
+	// Verbatim environments cannot be used as a node's argument, so the
+	// listing is typeset into a box before the picture starts, and the node
+	// only references it.
+	name := boxName(`\figzcode`, c.codeBlocks)
+	c.codeBlocks++
+	begin, body, end := c.codeEnvironment(w.Node.CodeBlockLanguage, dark, strings.TrimRight(code, "\n"))
+
+	c.AddPreamble(fmt.Sprintf(`\newsavebox{%s}`, name))
+	c.AddPreamble(fmt.Sprintf(`\begin{lrbox}{%s}\begin{minipage}{%scm}`, name, formatNumber(max(size.X-0.3, 0), c.precision)))
+	c.AddPreamble(begin)
+	c.AddPreamble(body)
+	c.AddPreamble(end)
+	c.AddPreamble(`\end{minipage}\end{lrbox}`)
+
+	text := fmt.Sprintf(`\usebox{%s}`, name)
+	c.AddFramed(w, &Node{
+		Attributes: c.Styled("figz/code", append(attrs, w.TextAttributes()...)...),
+		Name:       NodeName(w.Node),
+		Position:   q1,
+		Text:       &text,
+	})
+}
@@ -0,0 +1,93 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func codeBlock(lang fig.CodeBlockLanguage, code string) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeCodeBlock, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 300, Y: 80},
+		CodeBlockLanguage: lang,
+		TextData:          &fig.TextData{Characters: code},
+	}
+}
+
+func TestCodeBlockListings(t *testing.T) {
+	out := compile(t, nil, codeBlock(fig.CodeBlockLanguageGo, "func main() {}\n"))
+
+	for _, want := range []string{
+		`\lstdefinelanguage{Go}`,
+		"language=Go",
+		"func main() {}\n\\end{lstlisting}",
+		`\usebox{\figzcodea}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "literate=") || strings.Contains(out, "escapechar=") {
+		t.Errorf("expected no literate or escape options for plain code, got:\n%s", out)
+	}
+}
+
+func TestCodeBlockDefinesLanguagesOnce(t *testing.T) {
+	out := compile(t, nil,
+		codeBlock(fig.CodeBlockLanguageGo, "a := 1"),
+		codeBlock(fig.CodeBlockLanguageGo, "b := 2"))
+
+	if n := strings.Count(out, `\lstdefinelanguage{Go}`); n != 1 {
+		t.Errorf("expected Go to be defined once, got %d definitions", n)
+	}
+	if !strings.Contains(out, `\usebox{\figzcodeb}`) {
+		t.Errorf("expected a second box, got:\n%s", out)
+	}
+}
+
+func TestCodeBlockDefaultFill(t *testing.T) {
+	out := compile(t, nil, codeBlock(fig.CodeBlockLanguagePlaintext, "x"))
+	if !strings.Contains(out, "fill={rgb,1:red,0.960;green,0.960;blue,0.960}") {
+		t.Errorf("expected the default light fill, got:\n%s", out)
+	}
+	if !strings.Contains(out, `\color{black}`) {
+		t.Errorf("expected dark text on the default fill, got:\n%s", out)
+	}
+}
+
+func TestCodeBlockLiterate(t *testing.T) {
+	out := compile(t, nil, codeBlock(fig.CodeBlockLanguagePlaintext, "// café → 😀 é"))
+	want := `literate={é}{{\'e}}1 {→}{{\ensuremath{\rightarrow}}}1 {😀}{{?}}1`
+	if !strings.Contains(out, want) {
+		t.Errorf("expected output to contain %q, got:\n%s", want, out)
+	}
+}
+
+func TestCodeBlockEscapesEnvironmentEnd(t *testing.T) {
+	code := `s := "\end{lstlisting}"`
+	out := compile(t, nil, codeBlock(fig.CodeBlockLanguagePlaintext, code))
+
+	if !strings.Contains(out, "escapechar=@") {
+		t.Errorf("expected an escape character, got:\n%s", out)
+	}
+	if !strings.Contains(out, `s := "\@@end{lstlisting}"`) {
+		t.Errorf("expected the environment end to be broken, got:\n%s", out)
+	}
+	if n := strings.Count(out, `\end{lstlisting}`); n != 1 {
+		t.Errorf("expected a single environment end, got %d", n)
+	}
+
+	out = compile(t, &CompilerOpts{CodeHighlighter: HighlighterMinted},
+		codeBlock(fig.CodeBlockLanguagePlaintext, `@ \end{minted}`))
+	if !strings.Contains(out, "escapeinside=||") || !strings.Contains(out, `@ \||end{minted}`) {
+		t.Errorf("expected minted to escape its environment end, got:\n%s", out)
+	}
+}
+
+func TestCodeBlockMinted(t *testing.T) {
+	out := compile(t, &CompilerOpts{CodeHighlighter: HighlighterMinted}, codeBlock(fig.CodeBlockLanguageRust, "fn main() {}"))
+	if !strings.Contains(out, `\begin{minted}[fontsize=\small]{rust}`) {
+		t.Errorf("expected a minted environment, got:\n%s", out)
+	}
+}
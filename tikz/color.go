@@ -0,0 +1,10 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+)
+
+func ColorSpec(c *scene.Color) string {
+	return fmt.Sprintf("{rgb,1:red,%.3f;green,%.3f;blue,%.3f}", c.R, c.G, c.B)
+}
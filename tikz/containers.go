@@ -0,0 +1,57 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/scene"
+)
+
+// drawContainer draws the background and title of sections and frames.
+// Groups have neither, and only their children are drawn.
+func (c *Compiler) drawContainer(w DrawingNode) {
+	if w.Element.Kind == scene.KindGroup {
+		return
+	}
+	q1, q2 := w.Frame()
+	size := q2.Sub(q1)
+
+	attrs := AttributeList{
+		AnchorAttribute("north west"),
+		InnerSepAttribute("0pt"),
+		&MinimumSizeAttribute{size.X, size.Y},
+	}
+	if fill := w.Element.Style.Fill; fill != nil {
+		attrs = append(attrs, &FillAttribute{ColorSpec(fill)})
+	}
+	if stroke := w.Element.Style.Stroke; stroke != nil && w.Element.Style.Stroked() {
+		attrs = append(attrs, DrawAttribute(ColorSpec(stroke)))
+	} else if w.Element.Style.Fill == nil {
+		attrs = append(attrs, DrawAttribute("gray"))
+	}
+	style := "figz/frame"
+	if w.Element.Kind == scene.KindSection {
+		style = "figz/section"
+		attrs = append(attrs, &RoundedCornersAttribute{4})
+	}
+	empty := ""
+	els := []Element{&Node{
+		Attributes: c.Styled(style, attrs...),
+		Name:       NodeName(w.Node),
+		Position:   q1,
+		Text:       &empty,
+	}}
+
+	// Section titles are placed within their top left corner, and frame
+	// titles right above it.
+	if name := w.Node.Name; name != "" {
+		title := EscapeText(name)
+		titleAttrs := AttributeList{AnchorAttribute("south west"), InnerSepAttribute("2pt"), FontAttribute(`\small`)}
+		if w.Element.Kind == scene.KindSection {
+			titleAttrs = AttributeList{AnchorAttribute("north west"), InnerSepAttribute("4pt"), FontAttribute(`\small\bfseries`)}
+		}
+		els = append(els, &Node{
+			Attributes: c.Styled(style+"/title", titleAttrs...),
+			Position:   q1,
+			Text:       &title,
+		})
+	}
+	c.AddFramed(w, els...)
+}
@@ -0,0 +1,143 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+const (
+	highlightOpacity = 0.4
+	washiTapeOpacity = 0.6
+	badgeSpacing     = 0.45
+)
+
+var washiTapePatterns = map[fig.StrokeCap]string{
+	fig.StrokeCapWashiTape1: "north east lines",
+	fig.StrokeCapWashiTape2: "dots",
+	fig.StrokeCapWashiTape3: "crosshatch",
+	fig.StrokeCapWashiTape4: "horizontal lines",
+	fig.StrokeCapWashiTape5: "grid",
+	fig.StrokeCapWashiTape6: "crosshatch dots",
+}
+
+// EmojiText returns the TeX representation of the given code points. Unless
+// UnicodeEmoji is set, a star is returned in their place, and the code
+// points are noted in a comment.
+func (c *Compiler) EmojiText(codePoints []uint) string {
+	chars := make([]string, len(codePoints))
+	for i, v := range codePoints {
+		chars[i] = fmt.Sprintf(`%X`, v)
+	}
+	if !c.opts.UnicodeEmoji {
+		c.AddElement(Comment("Emoji U+" + strings.Join(chars, " U+") + " drawn as a star, as typesetting it requires XeLaTeX or LuaLaTeX"))
+		return `$\star$`
+	}
+	c.RequirePackage("fontspec")
+	return `\char"` + strings.Join(chars, `\char"`)
+}
+
+func decorationColor(style scene.Style, fallback string) string {
+	if style.Stroke != nil {
+		return ColorSpec(style.Stroke)
+	}
+	if style.Fill != nil {
+		return ColorSpec(style.Fill)
+	}
+	return fallback
+}
+
+func (c *Compiler) drawHighlight(w DrawingNode) {
+	color := decorationColor(w.Element.Style, "yellow")
+	attrs := AttributeList{w.Transform.Attribute(), &FillAttribute{color}, FillOpacityAttribute(highlightOpacity)}
+
+	var segments []string
+	for _, cmds := range w.Element.Paths {
+		segments = append(segments, PathCommandsString(cmds, c.scale, c.precision))
+	}
+	if len(segments) == 0 {
+		// Without geometry, approximate the marker stroke by a line
+		// crossing the node's bounds.
+		attrs = AttributeList{
+			w.Transform.Attribute(),
+			DrawAttribute(color),
+			DrawOpacityAttribute(highlightOpacity),
+			LineWidthAttribute(w.Size.Y),
+			LineCapAttribute("round"),
+		}
+		segments = []string{fmt.Sprintf("(0, %s) -- (%s, %s)", formatNumber(w.Size.Y/2.0, c.precision), formatNumber(w.Size.X, c.precision), formatNumber(w.Size.Y/2.0, c.precision))}
+	}
+
+	c.AddElement(&Path{
+		Attributes: c.Styled("figz/highlight", attrs...),
+		Segments:   strings.Join(segments, " "),
+	})
+}
+
+func (c *Compiler) drawWashiTape(w DrawingNode) {
+	color := decorationColor(w.Element.Style, "gray")
+	strip := fmt.Sprintf("(0, 0) rectangle (%s)", w.Size.Emit(c.precision))
+	c.AddElement(&Path{
+		Attributes: c.Styled("figz/washi-tape", w.Transform.Attribute(), &FillAttribute{color}, FillOpacityAttribute(washiTapeOpacity)),
+		Segments:   strip,
+	})
+	if pattern, ok := washiTapePatterns[w.Node.StrokeCap]; ok {
+		c.RequireLibrary("patterns")
+		c.AddElement(&Path{
+			Attributes: c.Styled("figz/washi-tape/pattern", w.Transform.Attribute(), &PatternAttribute{pattern, "white"}, FillOpacityAttribute(washiTapeOpacity)),
+			Segments:   strip,
+		})
+	}
+}
+
+func (c *Compiler) drawBadge(w DrawingNode, label string) {
+	pos := w.Q1.MiddleWith(w.Q2)
+	attrs := AttributeList{
+		NodeShapeAttribute("circle"),
+		DrawAttribute(decorationColor(w.Element.Style, "black")),
+		&FillAttribute{"white"},
+		InnerSepAttribute("1pt"),
+		FontAttribute(`\scriptsize`),
+	}
+	if w.Element.Host != nil {
+		// Badges are stacked along the top edge of their host, starting
+		// from its right corner.
+		host := MakeDrawingNode(w.Element.Host, c.scale)
+		key := w.Element.Host.ID
+		pos = Position{X: host.Q2.X - float32(c.badges[key])*badgeSpacing, Y: host.Q1.Y}
+		c.badges[key]++
+	}
+	c.AddElement(&Node{
+		Attributes: c.Styled("figz/badge", attrs...),
+		Position:   pos,
+		Text:       &label,
+	})
+}
+
+// drawStamp draws the badge of a stamp, labelled with its text or emoji.
+// Other stamps are labelled "+1" when they are votes, or with a star.
+func (c *Compiler) drawStamp(w DrawingNode) {
+	c.AddElement(Comment(scene.DescribeStamp(w.Node)))
+	label := EscapeText(w.Element.Text.String())
+	if w.Element.Emoji != nil {
+		label = c.EmojiText(w.Element.Emoji)
+	}
+	if label == "" && scene.IsVote(w.Node) {
+		label = "+1"
+	} else if label == "" {
+		label = `$\star$`
+	}
+	c.drawBadge(w, label)
+}
+
+func (c *Compiler) drawDecoration(w DrawingNode) {
+	switch w.Element.Kind {
+	case scene.KindHighlight:
+		c.drawHighlight(w)
+	case scene.KindWashiTape:
+		c.drawWashiTape(w)
+	case scene.KindStamp:
+		c.drawStamp(w)
+	}
+}
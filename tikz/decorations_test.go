@@ -0,0 +1,71 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func stamp(local uint, data *fig.StampData, paints []*fig.Paint) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeStamp, Visible: true, Opacity: 1,
+		Transform: translate(40, 40), Size: &fig.Vector{X: 20, Y: 20},
+		StampData: data, FillPaints: paints,
+	}
+}
+
+func sticky(local uint) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeRectangle, Name: "Sticky", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 100, Y: 100},
+		FillPaints: solid(1, 0.85, 0.4),
+	}
+}
+
+func TestStampBadges(t *testing.T) {
+	vote := stamp(2, &fig.StampData{UserId: "42", VotingSessionId: "7"}, nil)
+	other := stamp(3, &fig.StampData{UserId: "43"}, nil)
+	out := compile(t, nil, sticky(1), vote, other)
+
+	for _, want := range []string{
+		"% Vote by user 42",
+		"% Stamp by user 43",
+		// Badges stack from the host's top right corner.
+		"at (1.8, 0) {+1};",
+		`at (1.35, 0) {$\star$};`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	out = compile(t, &CompilerOpts{OmitDecorations: true}, sticky(1), vote)
+	if strings.Contains(out, "+1") {
+		t.Errorf("expected decorations to be omitted, got:\n%s", out)
+	}
+}
+
+func TestEmojiReactions(t *testing.T) {
+	emoji := []*fig.Paint{{Type: fig.PaintTypeEmoji, Visible: true, Opacity: 1, EmojiCodePoints: []uint{0x1F44D}}}
+
+	out := compile(t, nil, sticky(1), stamp(2, nil, emoji))
+	if !strings.Contains(out, `{$\star$};`) || !strings.Contains(out, "% Emoji U+1F44D drawn as a star") {
+		t.Errorf("expected emoji to be drawn as a star, got:\n%s", out)
+	}
+
+	out = compile(t, &CompilerOpts{UnicodeEmoji: true}, sticky(1), stamp(2, nil, emoji))
+	if !strings.Contains(out, `{\char"1F44D};`) {
+		t.Errorf("expected emoji to be typeset, got:\n%s", out)
+	}
+}
+
+func TestHighlightWithoutGeometry(t *testing.T) {
+	highlight := &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeHighlight, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 100, Y: 20},
+	}
+	out := compile(t, nil, highlight)
+	if !strings.Contains(out, "draw=yellow") || !strings.Contains(out, "(0, 0.18) -- (1.8, 0.18)") {
+		t.Errorf("expected a yellow marker stroke, got:\n%s", out)
+	}
+}
@@ -1,12 +1,19 @@
 package tikz
 
-import "github.com/heyvito/figz/fig"
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+)
 
 type DrawingNode struct {
-	Node *fig.NodeChange
-	Q1   Position
-	Q2   Position
-	Size Position
+	Node    *fig.NodeChange
+	Element *scene.Element
+	// Q1 and Q2 are the corners of the node's bounding box.
+	Q1        Position
+	Q2        Position
+	Size      Position
+	Transform Transform
 }
 
 type Direction int
@@ -50,24 +57,99 @@ func (d DrawingNode) RelativeSideOf(other DrawingNode) (s Side) {
 	return
 }
 
-func MakeDrawingNode(v *fig.NodeChange) DrawingNode {
-	var (
-		m00 = float32(v.Transform.M00)
-		m01 = float32(v.Transform.M01)
-		m02 = float32(v.Transform.M02) * scale
-		m10 = float32(v.Transform.M10)
-		m11 = float32(v.Transform.M11)
-		m12 = float32(v.Transform.M12) * scale
-	)
+// Transform is a node's 2x3 affine matrix, with its translation already
+// scaled to the picture's coordinate space.
+type Transform struct {
+	M00, M01, M02 float32
+	M10, M11, M12 float32
+}
+
+// MakeTransform converts t, scaling its translation.
+func MakeTransform(t scene.Transform, scale float32) Transform {
+	return Transform{
+		M00: float32(t.M00),
+		M01: float32(t.M01),
+		M02: float32(t.M02) * scale,
+		M10: float32(t.M10),
+		M11: float32(t.M11),
+		M12: float32(t.M12) * scale,
+	}
+}
+
+func (t Transform) Apply(p Position) Position {
+	return Position{
+		X: t.M00*p.X + t.M01*p.Y + t.M02,
+		Y: t.M10*p.X + t.M11*p.Y + t.M12,
+	}
+}
+
+// ApplyLinear applies the transform to p, ignoring its translation.
+func (t Transform) ApplyLinear(p Position) Position {
+	return Position{
+		X: t.M00*p.X + t.M01*p.Y,
+		Y: t.M10*p.X + t.M11*p.Y,
+	}
+}
+
+func (t Transform) IsTranslation() bool {
+	return t.M00 == 1 && t.M11 == 1 && t.M01 == 0 && t.M10 == 0
+}
+
+// Rotation returns the clockwise rotation, in degrees, applied by the
+// transform.
+func (t Transform) Rotation() float32 {
+	return float32(math.Atan2(float64(t.M10), float64(t.M00)) * 180 / math.Pi)
+}
 
-	var p2 = Position{X: float32(v.Size.X) * scale, Y: float32(v.Size.Y) * scale}
-	var q1 = Position{X: m00*0 + m01*0 + m02, Y: m10*0 + m11*0 + m12}
-	var q2 = Position{X: m00*p2.X + m01*p2.Y + m02, Y: m10*p2.X + m11*p2.Y + m12}
+func (t Transform) Attribute() *TransformAttribute {
+	return &TransformAttribute{
+		M00:      t.M00,
+		M01:      t.M01,
+		M10:      t.M10,
+		M11:      t.M11,
+		Position: Position{X: t.M02, Y: t.M12},
+	}
+}
+
+func MakeDrawingNode(e *scene.Element, scale float32) DrawingNode {
+	p2 := Position{X: float32(e.Size.X) * scale, Y: float32(e.Size.Y) * scale}
+	t := MakeTransform(e.Transform, scale)
+	q1 := Position{X: math.MaxFloat32, Y: math.MaxFloat32}
+	q2 := Position{X: -math.MaxFloat32, Y: -math.MaxFloat32}
+	for _, corner := range []Position{{0, 0}, {p2.X, 0}, p2, {0, p2.Y}} {
+		p := t.Apply(corner)
+		q1 = Position{X: min(q1.X, p.X), Y: min(q1.Y, p.Y)}
+		q2 = Position{X: max(q2.X, p.X), Y: max(q2.Y, p.Y)}
+	}
 
 	return DrawingNode{
-		Node: v,
-		Q1:   q1,
-		Q2:   q2,
-		Size: p2,
+		Node:      e.Node,
+		Element:   e,
+		Q1:        q1,
+		Q2:        q2,
+		Size:      p2,
+		Transform: t,
+	}
+}
+
+// Frame returns the corners elements of the node should be drawn between.
+// Nodes that are rotated, skewed or flipped are drawn in their own
+// coordinate space, and must be added through Compiler.AddFramed.
+func (d DrawingNode) Frame() (Position, Position) {
+	if d.Transform.IsTranslation() {
+		return d.Q1, d.Q2
+	}
+	return Position{}, d.Size
+}
+
+// TextAttributes returns the attributes required to keep text aligned with
+// the node's rotation.
+func (d DrawingNode) TextAttributes() AttributeList {
+	if d.Transform.IsTranslation() {
+		return nil
+	}
+	if r := d.Transform.Rotation(); r != 0 {
+		return AttributeList{RotateAttribute(-r)}
 	}
+	return nil
 }
@@ -0,0 +1,65 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"strings"
+	"testing"
+)
+
+func near(a, b Position) bool {
+	return math.Abs(float64(a.X-b.X)) < 1e-4 && math.Abs(float64(a.Y-b.Y)) < 1e-4
+}
+
+// rotated returns a 100x50 node rotated 90 degrees clockwise around its
+// top-left corner, which sits at (200, 0).
+func rotated(typ fig.NodeType) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(1), Type: typ, Name: "Rotated", Visible: true, Opacity: 1,
+		Transform: &fig.Matrix{M00: 0, M01: -1, M02: 200, M10: 1, M11: 0, M12: 0},
+		Size:      &fig.Vector{X: 100, Y: 50},
+	}
+}
+
+// element builds n into a scene and returns its element.
+func element(t *testing.T, n *fig.NodeChange) *scene.Element {
+	t.Helper()
+	page := &fig.NodeChange{Guid: guid(0), Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{n}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s.Elements[0]
+}
+
+func TestMakeDrawingNodeBounds(t *testing.T) {
+	w := MakeDrawingNode(element(t, rotated(fig.NodeTypeRectangle)), DefaultScale)
+
+	if !near(w.Q1, Position{X: 150 * DefaultScale, Y: 0}) || !near(w.Q2, Position{X: 200 * DefaultScale, Y: 100 * DefaultScale}) {
+		t.Errorf("unexpected bounds %v, %v", w.Q1, w.Q2)
+	}
+	if !near(w.Size, Position{X: 100 * DefaultScale, Y: 50 * DefaultScale}) {
+		t.Errorf("expected the size to stay in local space, got %v", w.Size)
+	}
+	if r := w.Transform.Rotation(); math.Abs(float64(r-90)) > 1e-4 {
+		t.Errorf("expected a 90 degree rotation, got %v", r)
+	}
+	if q1, q2 := w.Frame(); q1 != (Position{}) || q2 != w.Size {
+		t.Errorf("expected rotated nodes to be framed in local space, got %v, %v", q1, q2)
+	}
+}
+
+func TestRotatedNodesCarryTheirTransform(t *testing.T) {
+	out := compile(t, nil, rotated(fig.NodeTypeRectangle))
+	if !strings.Contains(out, "cm={0, 1, -1, 0, (") {
+		t.Errorf("expected the primitive to carry its transform, got:\n%s", out)
+	}
+
+	text := rotated(fig.NodeTypeText)
+	text.TextData = &fig.TextData{Characters: "Hello"}
+	out = compile(t, nil, text)
+	if !strings.Contains(out, `\begin{scope}[cm=`) || !strings.Contains(out, "rotate=-90") {
+		t.Errorf("expected rotated text to be drawn in a transformed scope, got:\n%s", out)
+	}
+}
@@ -0,0 +1,42 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+// PathCommandsString converts commands into a TikZ path, scaling each point.
+func PathCommandsString(cmds []scene.PathCommand, scale float32, precision int) string {
+	var data []string
+	var current, start Position
+	for _, cmd := range cmds {
+		pts := make([]Position, len(cmd.Points))
+		for i, p := range cmd.Points {
+			pts[i] = Position{X: float32(p.X) * scale, Y: float32(p.Y) * scale}
+		}
+		switch cmd.Kind {
+		case scene.PathMoveTo:
+			data = append(data, fmt.Sprintf("(%s)", pts[0].Emit(precision)))
+			start = pts[0]
+			current = pts[0]
+		case scene.PathLineTo:
+			data = append(data, fmt.Sprintf("-- (%s)", pts[0].Emit(precision)))
+			current = pts[0]
+		case scene.PathQuadTo:
+			// TikZ has no quadratic curves, so we elevate them to cubic
+			// ones.
+			cp1 := current.Sum(pts[0].Sub(current).Mul(2.0 / 3.0))
+			cp2 := pts[1].Sum(pts[0].Sub(pts[1]).Mul(2.0 / 3.0))
+			data = append(data, fmt.Sprintf(".. controls (%s) and (%s) .. (%s)", cp1.Emit(precision), cp2.Emit(precision), pts[1].Emit(precision)))
+			current = pts[1]
+		case scene.PathCubicTo:
+			data = append(data, fmt.Sprintf(".. controls (%s) and (%s) .. (%s)", pts[0].Emit(precision), pts[1].Emit(precision), pts[2].Emit(precision)))
+			current = pts[2]
+		case scene.PathClose:
+			data = append(data, "-- cycle")
+			current = start
+		}
+	}
+	return strings.Join(data, " ")
+}
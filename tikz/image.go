@@ -0,0 +1,128 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// maxTiles limits how many copies of an image are emitted for tiled fills.
+const maxTiles = 400
+
+// ResolveImage writes img to the configured image directory, and returns
+// the file name to be used by \includegraphics.
+func (c *Compiler) ResolveImage(img *scene.Image) (string, error) {
+	if name, ok := c.images[img.Hash]; ok {
+		return name, nil
+	}
+
+	if img.Format == "" || img.Format == "gif" {
+		return "", fmt.Errorf("image %s has an unsupported format", img.Hash)
+	}
+	name := img.Hash + "." + img.Format
+	if c.opts.DryRun {
+		c.images[img.Hash] = name
+		return name, nil
+	}
+	if err := os.WriteFile(filepath.Join(c.opts.ImageDir, name), img.Data, 0644); err != nil {
+		return "", fmt.Errorf("unable to write image %s: %w", name, err)
+	}
+	c.images[img.Hash] = name
+	return name, nil
+}
+
+func (c *Compiler) drawImageFills(w DrawingNode) {
+	for _, fill := range w.Element.Images {
+		c.drawImagePaint(w, fill)
+	}
+}
+
+func (c *Compiler) drawImagePaint(w DrawingNode, fill scene.ImageFill) {
+	p := fill.Paint
+	err := fill.Err
+	var name string
+	if err == nil {
+		name, err = c.ResolveImage(fill.Image)
+	}
+	if err != nil {
+		c.AddElement(Comment(fmt.Sprintf("Skipping image fill of %s: %s", w.Node.Name, err)))
+		return
+	}
+	c.RequirePackage("graphicx")
+
+	q1, q2 := w.Frame()
+	size := q2.Diff(q1)
+	rotation := float32(math.Mod(p.Rotation, 360)) + w.Transform.Rotation()
+	rotated := math.Mod(math.Abs(p.Rotation), 180) == 90
+
+	// Natural image size, as displayed within the node's bounds.
+	imgSize := Position{X: float32(p.OriginalImageWidth), Y: float32(p.OriginalImageHeight)}
+	if imgSize.X == 0 || imgSize.Y == 0 {
+		imgSize = size
+	}
+	if rotated {
+		imgSize = Position{X: imgSize.Y, Y: imgSize.X}
+	}
+
+	scope := &Scope{Clip: &[2]Position{q1, q2}}
+	place := func(center, display Position) {
+		if rotated {
+			display = Position{X: display.Y, Y: display.X}
+		}
+		text := fmt.Sprintf(`\includegraphics[width=%scm,height=%scm]{%s}`, formatNumber(display.X, c.precision), formatNumber(display.Y, c.precision), name)
+		attrs := AttributeList{InnerSepAttribute("0pt"), AnchorAttribute("center")}
+		if rotation != 0 {
+			attrs = append(attrs, RotateAttribute(-rotation))
+		}
+		scope.Elements = append(scope.Elements, &Node{
+			Attributes: c.Styled("figz/image", attrs...),
+			Position:   center,
+			Text:       &text,
+		})
+	}
+
+	switch p.ImageScaleMode {
+	case fig.ImageScaleModeFit, fig.ImageScaleModeFill:
+		ratio := min(size.X/imgSize.X, size.Y/imgSize.Y)
+		if p.ImageScaleMode == fig.ImageScaleModeFill {
+			ratio = max(size.X/imgSize.X, size.Y/imgSize.Y)
+		}
+		place(q1.MiddleWith(q2), Position{X: imgSize.X * ratio, Y: imgSize.Y * ratio})
+	case fig.ImageScaleModeTile:
+		tileScale := float32(p.Scale)
+		if tileScale == 0 {
+			tileScale = 1
+		}
+		tile := Position{X: imgSize.X * tileScale * c.scale, Y: imgSize.Y * tileScale * c.scale}
+		if p.OriginalImageWidth == 0 || p.OriginalImageHeight == 0 {
+			tile = size
+		}
+		cols := int(math.Ceil(float64(size.X/tile.X) - 1e-4))
+		rows := int(math.Ceil(float64(size.Y/tile.Y) - 1e-4))
+		if cols*rows > maxTiles {
+			c.AddElement(Comment(fmt.Sprintf("Tiled image fill of %s truncated to %d tiles", w.Node.Name, maxTiles)))
+		}
+		count := 0
+		for i := 0; i < rows && count < maxTiles; i++ {
+			for j := 0; j < cols && count < maxTiles; j++ {
+				corner := q1.Sum(Position{X: float32(j) * tile.X, Y: float32(i) * tile.Y})
+				place(corner.MiddleWith(corner.Sum(tile)), tile)
+				count++
+			}
+		}
+	default:
+		// Stretch; a non-identity paint transform means the image was
+		// cropped, and maps the node's bounds into the image's unit square.
+		display, corner := size, q1
+		if t := p.Transform; t != nil && t.M00 > 0 && t.M11 > 0 {
+			display = Position{X: size.X / float32(t.M00), Y: size.Y / float32(t.M11)}
+			corner = q1.Sub(Position{X: float32(t.M02) * display.X, Y: float32(t.M12) * display.Y})
+		}
+		place(corner.MiddleWith(corner.Sum(display)), display)
+	}
+
+	c.AddFramed(w, scope)
+}
@@ -0,0 +1,80 @@
+package tikz
+
+import (
+	"crypto/sha1"
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var pngData = []byte("\x89PNG\r\n\x1a\nfake")
+
+func imageNode(local uint, img *fig.Image, mode fig.ImageScaleMode) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeRectangle, Name: "Photo", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 200, Y: 100},
+		FillPaints: []*fig.Paint{{
+			Type: fig.PaintTypeImage, Visible: true, Opacity: 1, Image: img,
+			ImageScaleMode: mode, OriginalImageWidth: 100, OriginalImageHeight: 100,
+		}},
+	}
+}
+
+func TestImageFromArchive(t *testing.T) {
+	dir := t.TempDir()
+	doc := &decoder.Document{Images: map[string][]byte{"aabb": pngData}}
+	out := render(t, doc, scene.Options{}, &CompilerOpts{ImageDir: dir},
+		imageNode(1, &fig.Image{Hash: []byte{0xaa, 0xbb}}, fig.ImageScaleModeFit),
+		imageNode(2, &fig.Image{Hash: []byte{0xaa, 0xbb}}, fig.ImageScaleModeFit))
+
+	if n := strings.Count(out, `]{aabb.png}`); n != 2 {
+		t.Errorf("expected both nodes to include aabb.png, got %d:\n%s", n, out)
+	}
+	// Fitting a square into 200x100 leaves a 100x100 image.
+	if !strings.Contains(out, `\includegraphics[width=1.8cm,height=1.8cm]`) {
+		t.Errorf("expected the image to fit the node, got:\n%s", out)
+	}
+	if !strings.Contains(out, `\clip`) {
+		t.Errorf("expected the image to be clipped to the node, got:\n%s", out)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "aabb.png"))
+	if err != nil || string(data) != string(pngData) {
+		t.Errorf("expected the image to be written to the image directory, got %q, %v", data, err)
+	}
+}
+
+func TestImageFromBlob(t *testing.T) {
+	sum := sha1.Sum(pngData)
+	doc := &decoder.Document{Blobs: []*fig.Blob{{Bytes: []byte("unrelated")}, {Bytes: pngData}}}
+	opts := &CompilerOpts{ImageDir: t.TempDir()}
+
+	out := render(t, doc, scene.Options{}, opts, imageNode(1, &fig.Image{Hash: sum[:], DataBlob: 1}, fig.ImageScaleModeStretch))
+	if !strings.Contains(out, "includegraphics") {
+		t.Errorf("expected the blob to be used, got:\n%s", out)
+	}
+
+	// A hash without a matching image must not pick blob 0, which is what
+	// an unset DataBlob refers to.
+	out = render(t, doc, scene.Options{}, opts, imageNode(1, &fig.Image{Hash: sum[:]}, fig.ImageScaleModeStretch))
+	if strings.Contains(out, "includegraphics") || !strings.Contains(out, "% Skipping image fill of Photo: image not found in document") {
+		t.Errorf("expected the image fill to be skipped, got:\n%s", out)
+	}
+}
+
+func TestImageDryRun(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+	doc := &decoder.Document{Images: map[string][]byte{"aabb": pngData}}
+	out := render(t, doc, scene.Options{}, &CompilerOpts{ImageDir: dir, DryRun: true},
+		imageNode(1, &fig.Image{Hash: []byte{0xaa, 0xbb}}, fig.ImageScaleModeFill))
+
+	if !strings.Contains(out, `]{aabb.png}`) {
+		t.Errorf("expected the image to be referenced, got:\n%s", out)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be written, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+var urlEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`{`, `\{`,
+	`}`, `\}`,
+)
+
+// Href links text to url. URLs are escaped as they will usually end up in a
+// node's text, which is itself a macro argument.
+func (c *Compiler) Href(url, text string) string {
+	c.RequirePackage("hyperref")
+	return fmt.Sprintf(`\href{%s}{%s}`, urlEscaper.Replace(url), text)
+}
+
+func (c *Compiler) mentionSpan(text string) string {
+	c.RequirePackage("xcolor")
+	return fmt.Sprintf(`\textcolor{blue}{%s}`, text)
+}
+
+// TextString returns t escaped, with its mentions styled.
+func (c *Compiler) TextString(t *scene.Text) string {
+	if t == nil {
+		return ""
+	}
+	var data []string
+	for _, run := range t.Runs {
+		if run.Mention {
+			data = append(data, c.mentionSpan(EscapeText(run.Text)))
+		} else {
+			data = append(data, EscapeText(run.Text))
+		}
+	}
+	return strings.Join(data, "")
+}
+
+// RichText returns the escaped text of e, with its mentions styled, and
+// linked to the element's hyperlink, if any.
+func (c *Compiler) RichText(e *scene.Element) string {
+	res := c.TextString(e.Text)
+	if e.Link != "" {
+		res = c.Href(e.Link, res)
+	}
+	return res
+}
+
+func (c *Compiler) linkArea(url string, size Position) string {
+	return c.Href(url, fmt.Sprintf(`\phantom{\rule{%scm}{%scm}}`, formatNumber(size.X, c.precision), formatNumber(size.Y, c.precision)))
+}
+
+// drawLinks makes whole nodes with a hyperlink clickable, along with the
+// linked spans of their text. Text nodes link their text directly.
+func (c *Compiler) drawLinks(w DrawingNode) {
+	e := w.Element
+	q1, q2 := w.Frame()
+	var els []Element
+
+	if e.Link != "" && e.Kind != scene.KindText {
+		text := c.linkArea(e.Link, q2.Sub(q1))
+		els = append(els, &Node{
+			Attributes: c.Styled("figz/link", append(AttributeList{InnerSepAttribute("0pt")}, w.TextAttributes()...)...),
+			Position:   q1.MiddleWith(q2),
+			Text:       &text,
+		})
+	}
+
+	for _, area := range e.LinkAreas {
+		b1 := q1.Sum(c.Point(area.Bounds.Min))
+		size := c.Point(area.Bounds.Size())
+		text := c.linkArea(area.URL, size)
+		els = append(els, &Node{
+			Attributes: c.Styled("figz/link", append(AttributeList{InnerSepAttribute("0pt")}, w.TextAttributes()...)...),
+			Position:   b1.MiddleWith(b1.Sum(size)),
+			Text:       &text,
+		})
+	}
+
+	if len(els) > 0 {
+		c.AddFramed(w, els...)
+	}
+}
+
+func (c *Compiler) drawLinkPreview(w DrawingNode) {
+	data := w.Node.LinkPreviewData
+	q1, q2 := w.Frame()
+	size := q2.Sub(q1)
+
+	title := data.Title
+	if title == "" {
+		title = data.Url
+	}
+	lines := []string{fmt.Sprintf(`\textbf{%s}`, EscapeText(title))}
+	if data.Provider != "" {
+		lines = append(lines, fmt.Sprintf(`{\scriptsize\color{gray}%s}`, EscapeText(data.Provider)))
+	}
+	if data.Description != "" {
+		lines = append(lines, fmt.Sprintf(`{\footnotesize %s}`, EscapeText(data.Description)))
+	}
+	c.RequirePackage("xcolor")
+	text := strings.Join(lines, `\\`)
+	if data.Url != "" {
+		text = c.Href(data.Url, fmt.Sprintf(`\parbox[t]{%scm}{%s}`, formatNumber(max(size.X-0.4, 0), c.precision), text))
+	}
+
+	c.AddFramed(w, &Node{
+		Attributes: c.Styled("figz/link-preview", append(AttributeList{
+			AnchorAttribute("north west"),
+			DrawAttribute("gray"),
+			&FillAttribute{"white"},
+			&RoundedCornersAttribute{4},
+			InnerSepAttribute("0.2cm"),
+			AlignAttribute("left"),
+			TextWidthAttribute(max(size.X-0.4, 0)),
+			&MinimumSizeAttribute{size.X, size.Y},
+		}, w.TextAttributes()...)...),
+		Name:     NodeName(w.Node),
+		Position: q1,
+		Text:     &text,
+	})
+}
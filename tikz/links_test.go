@@ -0,0 +1,83 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+	"testing"
+)
+
+func textNode(local uint, text string) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeText, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 200, Y: 40},
+		TextData: &fig.TextData{Characters: text},
+	}
+}
+
+func TestRichText(t *testing.T) {
+	mention := textNode(1, "Ask @vito & co")
+	mention.TextData.MentionBoxes = []*fig.MentionBox{{StartIndex: 4, EndIndex: 9}}
+	mention.Mention = &fig.Mention{MentionedUserId: "42"}
+	link := textNode(2, "50% off")
+	link.Hyperlink = &fig.Hyperlink{Url: "https://example.com/a%20b#c"}
+
+	out := render(t, nil, scene.Options{Mentions: map[string]string{"42": "Victor"}}, nil, mention, link)
+	for _, want := range []string{
+		`{Ask \textcolor{blue}{@Victor} \& co};`,
+		`{\href{https://example.com/a\%20b\#c}{50\% off}};`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	out = compile(t, nil, mention)
+	if !strings.Contains(out, `{Ask \textcolor{blue}{@vito} \& co};`) {
+		t.Errorf("expected unknown users to keep their text, got:\n%s", out)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	text := textNode(1, "Docs")
+	text.Hyperlink = &fig.Hyperlink{Url: "https://example.com"}
+	rect := &fig.NodeChange{
+		Guid: guid(2), Type: fig.NodeTypeRectangle, Name: "Card", Visible: true, Opacity: 1,
+		Transform: translate(0, 100), Size: &fig.Vector{X: 100, Y: 50},
+		Hyperlink: &fig.Hyperlink{Url: "https://example.org"},
+	}
+	out := compile(t, nil, text, rect)
+
+	for _, want := range []string{
+		`{\href{https://example.com}{Docs}};`,
+		`\href{https://example.org}{\phantom{\rule{1.8cm}{0.9cm}}}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestMultilineTextIsAligned(t *testing.T) {
+	n := textNode(1, "first\nsecond")
+	n.TextAlignHorizontal = fig.TextAlignHorizontalRight
+	out := compile(t, nil, n)
+	if !strings.Contains(out, "align=right") || !strings.Contains(out, `{first\\second}`) {
+		t.Errorf("expected aligned multi-line text, got:\n%s", out)
+	}
+}
+
+func TestLinkPreview(t *testing.T) {
+	preview := &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeRectangle, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 300, Y: 100},
+		LinkPreviewData: &fig.LinkPreviewData{Url: "https://example.com", Title: "Example_Site", Provider: "example.com"},
+	}
+	out := compile(t, nil, preview)
+	if !strings.Contains(out, `\textbf{Example\_Site}\\{\scriptsize\color{gray}example.com}`) {
+		t.Errorf("expected the preview's title and provider, got:\n%s", out)
+	}
+	if !strings.Contains(out, `\href{https://example.com}{\parbox[t]`) {
+		t.Errorf("expected the preview to be linked, got:\n%s", out)
+	}
+}
@@ -6,37 +6,42 @@ import (
 	"strings"
 )
 
-type XAdjuster interface {
-	AdjustX(offset float32)
+type Adjuster interface {
+	Adjust(offset Position)
+}
+
+// Element is a statement of a picture. Emit returns its code, printing
+// numbers with the given amount of decimal places.
+type Element interface {
+	Adjuster
+	Emit(precision int) string
 }
 
 type Attribute interface {
 	HasPosition() bool
 	GetPosition() Position
 	SetPosition(p Position)
-	String() string
+	Emit(precision int) string
 }
 type AttributeList []Attribute
 
-func (a AttributeList) String() string {
+func (a AttributeList) Emit(precision int) string {
 	allAttributes := make([]string, len(a))
 	for i, v := range a {
-		allAttributes[i] = v.String()
+		allAttributes[i] = v.Emit(precision)
 	}
 	return strings.Join(allAttributes, ", ")
 }
 
-func (a AttributeList) MinX() float32 {
-	minX := float32(math.MaxFloat32)
+// Min returns the smallest coordinates among the positioned attributes.
+func (a AttributeList) Min() Position {
+	m := Position{X: math.MaxFloat32, Y: math.MaxFloat32}
 	for _, v := range a {
-		if !v.HasPosition() {
-			continue
-		}
-		if v.GetPosition().X < minX {
-			minX = v.GetPosition().X
+		if v.HasPosition() {
+			m = m.Min(v.GetPosition())
 		}
 	}
-	return minX
+	return m
 }
 
 type ToAttribute struct{}
@@ -51,7 +56,7 @@ func (t *ToAttribute) SetPosition(p Position) {
 	panic("ToAttribute has no position")
 }
 
-func (t *ToAttribute) String() string { return "-To" }
+func (t *ToAttribute) Emit(precision int) string { return "-To" }
 
 type ThickAttribute struct{}
 
@@ -65,7 +70,7 @@ func (t *ThickAttribute) SetPosition(p Position) {
 	panic("ThickAttribute has no position")
 }
 
-func (t *ThickAttribute) String() string { return "thick" }
+func (t *ThickAttribute) Emit(precision int) string { return "thick" }
 
 type FillAttribute struct{ Value string }
 
@@ -79,7 +84,7 @@ func (f *FillAttribute) SetPosition(p Position) {
 	panic("FillAttribute has no position")
 }
 
-func (f *FillAttribute) String() string {
+func (f *FillAttribute) Emit(precision int) string {
 	return fmt.Sprintf("fill=%s", f.Value)
 }
 
@@ -95,7 +100,7 @@ func (r *RoundedCornersAttribute) SetPosition(p Position) {
 	panic("RoundedCornersAttribute has no position")
 }
 
-func (r *RoundedCornersAttribute) String() string {
+func (r *RoundedCornersAttribute) Emit(precision int) string {
 	return fmt.Sprintf("rounded corners=%d", r.Value)
 }
 
@@ -110,8 +115,8 @@ func (r *RotateAroundAttribute) GetPosition() Position { return r.Position }
 
 func (r *RotateAroundAttribute) SetPosition(p Position) { r.Position = p }
 
-func (r *RotateAroundAttribute) String() string {
-	return fmt.Sprintf("rotate around={%d:(%s)}", r.Degrees, r.Position)
+func (r *RotateAroundAttribute) Emit(precision int) string {
+	return fmt.Sprintf("rotate around={%d:(%s)}", r.Degrees, r.Position.Emit(precision))
 }
 
 type ScaleAroundAttribute struct {
@@ -125,8 +130,8 @@ func (s *ScaleAroundAttribute) GetPosition() Position { return s.Position }
 
 func (s *ScaleAroundAttribute) SetPosition(p Position) { s.Position = p }
 
-func (s *ScaleAroundAttribute) String() string {
-	return fmt.Sprintf("scale around={%f:(%s)}", s.Scale, s.Position)
+func (s *ScaleAroundAttribute) Emit(precision int) string {
+	return fmt.Sprintf("scale around={%s:(%s)}", formatNumber(s.Scale, precision), s.Position.Emit(precision))
 }
 
 type ColorAttribute string
@@ -141,7 +146,7 @@ func (c ColorAttribute) SetPosition(p Position) {
 	panic("ColorAttribute has no position")
 }
 
-func (c ColorAttribute) String() string { return "color=" + string(c) }
+func (c ColorAttribute) Emit(precision int) string { return "color=" + string(c) }
 
 type DrawAttribute string
 
@@ -155,7 +160,7 @@ func (d DrawAttribute) SetPosition(p Position) {
 	panic("DrawAttribute has no position")
 }
 
-func (d DrawAttribute) String() string { return "draw=" + string(d) }
+func (d DrawAttribute) Emit(precision int) string { return "draw=" + string(d) }
 
 type AnchorAttribute string
 
@@ -169,7 +174,7 @@ func (a AnchorAttribute) SetPosition(p Position) {
 	panic("AnchorAttribute has no position")
 }
 
-func (a AnchorAttribute) String() string {
+func (a AnchorAttribute) Emit(precision int) string {
 	return fmt.Sprintf("anchor=%s", string(a))
 }
 
@@ -185,10 +190,303 @@ func (a AlignAttribute) SetPosition(p Position) {
 	panic("AlignAttribute has no position")
 }
 
-func (a AlignAttribute) String() string {
+func (a AlignAttribute) Emit(precision int) string {
 	return fmt.Sprintf("align=%s", string(a))
 }
 
+type MinimumSizeAttribute struct{ Width, Height float32 }
+
+func (m *MinimumSizeAttribute) HasPosition() bool { return false }
+
+func (m *MinimumSizeAttribute) GetPosition() Position {
+	panic("MinimumSizeAttribute has no position")
+}
+
+func (m *MinimumSizeAttribute) SetPosition(p Position) {
+	panic("MinimumSizeAttribute has no position")
+}
+
+func (m *MinimumSizeAttribute) Emit(precision int) string {
+	return fmt.Sprintf("minimum width=%scm, minimum height=%scm", formatNumber(m.Width, precision), formatNumber(m.Height, precision))
+}
+
+type InnerSepAttribute string
+
+func (i InnerSepAttribute) HasPosition() bool { return false }
+
+func (i InnerSepAttribute) GetPosition() Position {
+	panic("InnerSepAttribute has no position")
+}
+
+func (i InnerSepAttribute) SetPosition(p Position) {
+	panic("InnerSepAttribute has no position")
+}
+
+func (i InnerSepAttribute) Emit(precision int) string { return "inner sep=" + string(i) }
+
+type TextColorAttribute string
+
+func (t TextColorAttribute) HasPosition() bool { return false }
+
+func (t TextColorAttribute) GetPosition() Position {
+	panic("TextColorAttribute has no position")
+}
+
+func (t TextColorAttribute) SetPosition(p Position) {
+	panic("TextColorAttribute has no position")
+}
+
+func (t TextColorAttribute) Emit(precision int) string { return "text=" + string(t) }
+
+type RotateAttribute float32
+
+func (r RotateAttribute) HasPosition() bool { return false }
+
+func (r RotateAttribute) GetPosition() Position {
+	panic("RotateAttribute has no position")
+}
+
+func (r RotateAttribute) SetPosition(p Position) {
+	panic("RotateAttribute has no position")
+}
+
+func (r RotateAttribute) Emit(precision int) string {
+	return "rotate=" + formatNumber(float32(r), precision)
+}
+
+type TransformAttribute struct {
+	M00, M01, M10, M11 float32
+	Position           Position
+}
+
+func (t *TransformAttribute) HasPosition() bool { return true }
+
+func (t *TransformAttribute) GetPosition() Position { return t.Position }
+
+func (t *TransformAttribute) SetPosition(p Position) { t.Position = p }
+
+func (t *TransformAttribute) Emit(precision int) string {
+	return fmt.Sprintf("cm={%s, %s, %s, %s, (%s)}", formatNumber(t.M00, precision), formatNumber(t.M10, precision), formatNumber(t.M01, precision), formatNumber(t.M11, precision), t.Position.Emit(precision))
+}
+
+type LineWidthAttribute float32
+
+func (l LineWidthAttribute) HasPosition() bool { return false }
+
+func (l LineWidthAttribute) GetPosition() Position {
+	panic("LineWidthAttribute has no position")
+}
+
+func (l LineWidthAttribute) SetPosition(p Position) {
+	panic("LineWidthAttribute has no position")
+}
+
+func (l LineWidthAttribute) Emit(precision int) string {
+	return "line width=" + formatNumber(float32(l), precision) + "cm"
+}
+
+type OpacityAttribute float32
+
+func (o OpacityAttribute) HasPosition() bool { return false }
+
+func (o OpacityAttribute) GetPosition() Position {
+	panic("OpacityAttribute has no position")
+}
+
+func (o OpacityAttribute) SetPosition(p Position) {
+	panic("OpacityAttribute has no position")
+}
+
+func (o OpacityAttribute) Emit(precision int) string {
+	return "opacity=" + formatNumber(float32(o), precision)
+}
+
+type DashPatternAttribute []float32
+
+func (d DashPatternAttribute) HasPosition() bool { return false }
+
+func (d DashPatternAttribute) GetPosition() Position {
+	panic("DashPatternAttribute has no position")
+}
+
+func (d DashPatternAttribute) SetPosition(p Position) {
+	panic("DashPatternAttribute has no position")
+}
+
+func (d DashPatternAttribute) Emit(precision int) string {
+	parts := make([]string, len(d))
+	for i, v := range d {
+		if i%2 == 0 {
+			parts[i] = "on " + formatNumber(v, precision) + "cm"
+		} else {
+			parts[i] = "off " + formatNumber(v, precision) + "cm"
+		}
+	}
+	return "dash pattern=" + strings.Join(parts, " ")
+}
+
+type FillOpacityAttribute float32
+
+func (f FillOpacityAttribute) HasPosition() bool { return false }
+
+func (f FillOpacityAttribute) GetPosition() Position {
+	panic("FillOpacityAttribute has no position")
+}
+
+func (f FillOpacityAttribute) SetPosition(p Position) {
+	panic("FillOpacityAttribute has no position")
+}
+
+func (f FillOpacityAttribute) Emit(precision int) string {
+	return "fill opacity=" + formatNumber(float32(f), precision)
+}
+
+type DrawOpacityAttribute float32
+
+func (d DrawOpacityAttribute) HasPosition() bool { return false }
+
+func (d DrawOpacityAttribute) GetPosition() Position {
+	panic("DrawOpacityAttribute has no position")
+}
+
+func (d DrawOpacityAttribute) SetPosition(p Position) {
+	panic("DrawOpacityAttribute has no position")
+}
+
+func (d DrawOpacityAttribute) Emit(precision int) string {
+	return "draw opacity=" + formatNumber(float32(d), precision)
+}
+
+type LineJoinAttribute string
+
+func (l LineJoinAttribute) HasPosition() bool { return false }
+
+func (l LineJoinAttribute) GetPosition() Position {
+	panic("LineJoinAttribute has no position")
+}
+
+func (l LineJoinAttribute) SetPosition(p Position) {
+	panic("LineJoinAttribute has no position")
+}
+
+func (l LineJoinAttribute) Emit(precision int) string { return "line join=" + string(l) }
+
+type LineCapAttribute string
+
+func (l LineCapAttribute) HasPosition() bool { return false }
+
+func (l LineCapAttribute) GetPosition() Position {
+	panic("LineCapAttribute has no position")
+}
+
+func (l LineCapAttribute) SetPosition(p Position) {
+	panic("LineCapAttribute has no position")
+}
+
+func (l LineCapAttribute) Emit(precision int) string { return "line cap=" + string(l) }
+
+type EvenOddRuleAttribute struct{}
+
+func (e EvenOddRuleAttribute) HasPosition() bool { return false }
+
+func (e EvenOddRuleAttribute) GetPosition() Position {
+	panic("EvenOddRuleAttribute has no position")
+}
+
+func (e EvenOddRuleAttribute) SetPosition(p Position) {
+	panic("EvenOddRuleAttribute has no position")
+}
+
+func (e EvenOddRuleAttribute) Emit(precision int) string { return "even odd rule" }
+
+type ArrowsAttribute struct{ Start, End string }
+
+func (a ArrowsAttribute) HasPosition() bool { return false }
+
+func (a ArrowsAttribute) GetPosition() Position {
+	panic("ArrowsAttribute has no position")
+}
+
+func (a ArrowsAttribute) SetPosition(p Position) {
+	panic("ArrowsAttribute has no position")
+}
+
+func (a ArrowsAttribute) Emit(precision int) string { return a.Start + "-" + a.End }
+
+type TextWidthAttribute float32
+
+func (t TextWidthAttribute) HasPosition() bool { return false }
+
+func (t TextWidthAttribute) GetPosition() Position {
+	panic("TextWidthAttribute has no position")
+}
+
+func (t TextWidthAttribute) SetPosition(p Position) {
+	panic("TextWidthAttribute has no position")
+}
+
+func (t TextWidthAttribute) Emit(precision int) string {
+	return "text width=" + formatNumber(float32(t), precision) + "cm"
+}
+
+type PosAttribute float32
+
+func (p PosAttribute) HasPosition() bool { return false }
+
+func (p PosAttribute) GetPosition() Position {
+	panic("PosAttribute has no position")
+}
+
+func (p PosAttribute) SetPosition(pos Position) {
+	panic("PosAttribute has no position")
+}
+
+func (p PosAttribute) Emit(precision int) string { return "pos=" + formatNumber(float32(p), precision) }
+
+type NodeShapeAttribute string
+
+func (n NodeShapeAttribute) HasPosition() bool { return false }
+
+func (n NodeShapeAttribute) GetPosition() Position {
+	panic("NodeShapeAttribute has no position")
+}
+
+func (n NodeShapeAttribute) SetPosition(p Position) {
+	panic("NodeShapeAttribute has no position")
+}
+
+func (n NodeShapeAttribute) Emit(precision int) string { return string(n) }
+
+type FontAttribute string
+
+func (f FontAttribute) HasPosition() bool { return false }
+
+func (f FontAttribute) GetPosition() Position {
+	panic("FontAttribute has no position")
+}
+
+func (f FontAttribute) SetPosition(p Position) {
+	panic("FontAttribute has no position")
+}
+
+func (f FontAttribute) Emit(precision int) string { return "font=" + string(f) }
+
+type PatternAttribute struct{ Name, Color string }
+
+func (p *PatternAttribute) HasPosition() bool { return false }
+
+func (p *PatternAttribute) GetPosition() Position {
+	panic("PatternAttribute has no position")
+}
+
+func (p *PatternAttribute) SetPosition(pos Position) {
+	panic("PatternAttribute has no position")
+}
+
+func (p *PatternAttribute) Emit(precision int) string {
+	return fmt.Sprintf("pattern=%s, pattern color=%s", p.Name, p.Color)
+}
+
 type Draw struct {
 	Attributes AttributeList
 	Points     PositionList
@@ -196,24 +494,24 @@ type Draw struct {
 	Kind       *string
 }
 
-func (d *Draw) AdjustX(offset float32) {
+func (d *Draw) Adjust(offset Position) {
 	for i := range d.Points {
-		d.Points[i].X -= offset
+		d.Points[i] = d.Points[i].Sub(offset)
 	}
 	for _, v := range d.Attributes {
 		if !v.HasPosition() {
 			continue
 		}
 		pos := v.GetPosition()
-		pos.X -= offset
+		pos = pos.Sub(offset)
 		v.SetPosition(pos)
 	}
 }
 
-func (d *Draw) String() string {
+func (d *Draw) Emit(precision int) string {
 	data := []string{`\draw`}
 	if len(d.Attributes) > 0 {
-		data = append(data, fmt.Sprintf("[%s]", d.Attributes.String()))
+		data = append(data, fmt.Sprintf("[%s]", d.Attributes.Emit(precision)))
 	}
 	if d.Kind != nil {
 		data = append(data, fmt.Sprintf(" %s", *d.Kind))
@@ -222,72 +520,79 @@ func (d *Draw) String() string {
 		data = append(data, fmt.Sprintf(" node{%s}", *d.Text))
 	}
 	if len(d.Points) > 0 {
-		data = append(data, fmt.Sprintf(" %s", d.Points.String()))
+		data = append(data, fmt.Sprintf(" %s", d.Points.Emit(precision)))
 	}
 	data = append(data, ";")
 	return strings.Join(data, "")
 }
 
 type Shape struct {
-	Attributes AttributeList
-	P1, P2     Position
-	Text       *string
-	Kind       string
+	Attributes     AttributeList
+	P1, P2         Position
+	Text           *string
+	TextAttributes AttributeList
+	Kind           string
 }
 
-func (s *Shape) AdjustX(offset float32) {
-	s.P1.X -= offset
-	s.P2.X -= offset
+func (s *Shape) Adjust(offset Position) {
+	s.P1 = s.P1.Sub(offset)
+	s.P2 = s.P2.Sub(offset)
 	for _, a := range s.Attributes {
 		if !a.HasPosition() {
 			continue
 		}
 		p := a.GetPosition()
-		p.X -= offset
+		p = p.Sub(offset)
 		a.SetPosition(p)
 	}
 }
 
-func (s *Shape) String() string {
+func (s *Shape) Emit(precision int) string {
 	data := []string{`\draw`}
 	if len(s.Attributes) > 0 {
-		data = append(data, fmt.Sprintf("[%s]", s.Attributes.String()))
+		data = append(data, fmt.Sprintf("[%s]", s.Attributes.Emit(precision)))
 	}
 
-	data = append(data, fmt.Sprintf(" (%s)", s.P1))
+	data = append(data, fmt.Sprintf(" (%s)", s.P1.Emit(precision)))
 	data = append(data, fmt.Sprintf(" %s", s.Kind))
-	if s.Text != nil {
+	if s.Text != nil && len(s.TextAttributes) > 0 {
+		data = append(data, fmt.Sprintf(" node[%s]{%s}", s.TextAttributes.Emit(precision), *s.Text))
+	} else if s.Text != nil {
 		data = append(data, fmt.Sprintf(" node{%s}", *s.Text))
 	}
-	data = append(data, fmt.Sprintf(" (%s)", s.P2))
+	data = append(data, fmt.Sprintf(" (%s)", s.P2.Emit(precision)))
 	data = append(data, ";")
 	return strings.Join(data, "")
 }
 
 type Node struct {
 	Attributes AttributeList
+	Name       string
 	Position   Position
 	Text       *string
 }
 
-func (n *Node) AdjustX(offset float32) {
-	n.Position.X -= offset
+func (n *Node) Adjust(offset Position) {
+	n.Position = n.Position.Sub(offset)
 	for _, a := range n.Attributes {
 		if !a.HasPosition() {
 			continue
 		}
 		pos := a.GetPosition()
-		pos.X -= offset
+		pos = pos.Sub(offset)
 		a.SetPosition(pos)
 	}
 }
 
-func (n *Node) String() string {
+func (n *Node) Emit(precision int) string {
 	data := []string{`\node`}
 	if len(n.Attributes) > 0 {
-		data = append(data, fmt.Sprintf("[%s]", n.Attributes.String()))
+		data = append(data, fmt.Sprintf("[%s]", n.Attributes.Emit(precision)))
+	}
+	if n.Name != "" {
+		data = append(data, fmt.Sprintf(" (%s)", n.Name))
 	}
-	data = append(data, fmt.Sprintf(" at (%s)", n.Position))
+	data = append(data, fmt.Sprintf(" at (%s)", n.Position.Emit(precision)))
 	if n.Text != nil {
 		data = append(data, fmt.Sprintf(" {%s}", *n.Text))
 	}
@@ -302,24 +607,241 @@ type FillDraw struct {
 	Size       string
 }
 
-func (f *FillDraw) AdjustX(offset float32) {
-	f.Position.X -= offset
+func (f *FillDraw) Adjust(offset Position) {
+	f.Position = f.Position.Sub(offset)
 	for _, a := range f.Attributes {
+		if !a.HasPosition() {
+			continue
+		}
 		pos := a.GetPosition()
-		pos.X -= offset
+		pos = pos.Sub(offset)
 		a.SetPosition(pos)
 	}
 }
 
-func (f *FillDraw) String() string {
+func (f *FillDraw) Emit(precision int) string {
 	data := []string{`\filldraw`}
 	if len(f.Attributes) > 0 {
-		data = append(data, fmt.Sprintf("[%s]", f.Attributes.String()))
+		data = append(data, fmt.Sprintf("[%s]", f.Attributes.Emit(precision)))
 	}
 
-	data = append(data, fmt.Sprintf(" (%s)", f.Position))
+	data = append(data, fmt.Sprintf(" (%s)", f.Position.Emit(precision)))
 	data = append(data, fmt.Sprintf(" %s", f.Shape))
 	data = append(data, fmt.Sprintf("(%s)", f.Size))
 	data = append(data, ";")
 	return strings.Join(data, "")
 }
+
+type MatrixCell struct {
+	Attributes AttributeList
+	Text       string
+}
+
+type Matrix struct {
+	Attributes   AttributeList
+	Name         string
+	Position     Position
+	RowHeights   []float32
+	ColumnWidths []float32
+	Cells        [][]MatrixCell
+}
+
+func (m *Matrix) Adjust(offset Position) {
+	m.Position = m.Position.Sub(offset)
+	for _, a := range m.Attributes {
+		if !a.HasPosition() {
+			continue
+		}
+		pos := a.GetPosition()
+		pos = pos.Sub(offset)
+		a.SetPosition(pos)
+	}
+}
+
+func (m *Matrix) Emit(precision int) string {
+	opts := []string{
+		"matrix of nodes",
+		"nodes in empty cells",
+		"row sep=-\\pgflinewidth",
+		"column sep=-\\pgflinewidth",
+		"inner sep=0",
+		"nodes={draw, align=center, anchor=center, inner sep=2pt}",
+	}
+	for i, w := range m.ColumnWidths {
+		opts = append(opts, fmt.Sprintf("column %d/.style={nodes={minimum width=%scm, text width=%scm}}", i+1, formatNumber(w, precision), formatNumber(max(w-0.2, 0), precision)))
+	}
+	for i, h := range m.RowHeights {
+		opts = append(opts, fmt.Sprintf("row %d/.style={nodes={minimum height=%scm}}", i+1, formatNumber(h, precision)))
+	}
+	if len(m.Attributes) > 0 {
+		opts = append(opts, m.Attributes.Emit(precision))
+	}
+
+	name := ""
+	if m.Name != "" {
+		name = fmt.Sprintf(" (%s)", m.Name)
+	}
+	data := []string{fmt.Sprintf(`\matrix[%s]%s at (%s) {`, strings.Join(opts, ", "), name, m.Position.Emit(precision))}
+	for _, row := range m.Cells {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			if len(cell.Attributes) > 0 {
+				cells[i] = fmt.Sprintf("|[%s]| {%s}", cell.Attributes.Emit(precision), cell.Text)
+			} else {
+				cells[i] = fmt.Sprintf("{%s}", cell.Text)
+			}
+		}
+		data = append(data, "  "+strings.Join(cells, " & ")+` \\`)
+	}
+	data = append(data, "};")
+	return strings.Join(data, "\n")
+}
+
+// Scope groups elements, optionally clipping them. When Transform is set,
+// elements are placed in the coordinate space it defines, and are therefore
+// not adjusted.
+type Scope struct {
+	Attributes AttributeList
+	Transform  *TransformAttribute
+	Clip       *[2]Position
+	Elements   []Element
+}
+
+func (s *Scope) Adjust(offset Position) {
+	for _, a := range s.Attributes {
+		if !a.HasPosition() {
+			continue
+		}
+		pos := a.GetPosition()
+		pos = pos.Sub(offset)
+		a.SetPosition(pos)
+	}
+	if s.Transform != nil {
+		s.Transform.Position = s.Transform.Position.Sub(offset)
+		return
+	}
+	if s.Clip != nil {
+		s.Clip[0] = s.Clip[0].Sub(offset)
+		s.Clip[1] = s.Clip[1].Sub(offset)
+	}
+	for _, v := range s.Elements {
+		v.(Adjuster).Adjust(offset)
+	}
+}
+
+func (s *Scope) Emit(precision int) string {
+	attrs := s.Attributes
+	if s.Transform != nil {
+		attrs = append(AttributeList{s.Transform}, attrs...)
+	}
+	data := []string{`\begin{scope}`}
+	if len(attrs) > 0 {
+		data[0] += fmt.Sprintf("[%s]", attrs.Emit(precision))
+	}
+	if s.Clip != nil {
+		data = append(data, fmt.Sprintf(`\clip (%s) rectangle (%s);`, s.Clip[0].Emit(precision), s.Clip[1].Emit(precision)))
+	}
+	for _, v := range s.Elements {
+		data = append(data, v.Emit(precision))
+	}
+	data = append(data, `\end{scope}`)
+	return strings.Join(data, "\n")
+}
+
+type Comment string
+
+func (c Comment) Adjust(Position) {}
+
+func (c Comment) Emit(precision int) string { return "% " + string(c) }
+
+// Path is drawn in the coordinate system defined by its attributes (usually
+// through a TransformAttribute), so only positioned attributes are adjusted.
+type Path struct {
+	Attributes AttributeList
+	Segments   string
+}
+
+func (p *Path) Adjust(offset Position) {
+	for _, a := range p.Attributes {
+		if !a.HasPosition() {
+			continue
+		}
+		pos := a.GetPosition()
+		pos = pos.Sub(offset)
+		a.SetPosition(pos)
+	}
+}
+
+func (p *Path) Emit(precision int) string {
+	data := []string{`\path`}
+	if len(p.Attributes) > 0 {
+		data = append(data, fmt.Sprintf("[%s]", p.Attributes.Emit(precision)))
+	}
+	data = append(data, " "+p.Segments, ";")
+	return strings.Join(data, "")
+}
+
+// PathPoint is a point of a Connector. Coordinate, when set, is emitted
+// verbatim and refers to other nodes, such as "n-1-2.east"; Position is used
+// otherwise. Operator joins the point to the previous one, and defaults to
+// "--".
+type PathPoint struct {
+	Coordinate string
+	Position   Position
+	Operator   string
+}
+
+func (p PathPoint) Emit(precision int) string {
+	if p.Coordinate != "" {
+		return fmt.Sprintf("(%s)", p.Coordinate)
+	}
+	return fmt.Sprintf("(%s)", p.Position.Emit(precision))
+}
+
+// Connector is a path between nodes. When Label is set, it is placed on
+// the segment ending at Points[LabelIndex].
+type Connector struct {
+	Attributes      AttributeList
+	Points          []PathPoint
+	Label           *string
+	LabelAttributes AttributeList
+	LabelIndex      int
+}
+
+func (c *Connector) Adjust(offset Position) {
+	for i := range c.Points {
+		if c.Points[i].Coordinate == "" {
+			c.Points[i].Position = c.Points[i].Position.Sub(offset)
+		}
+	}
+	for _, a := range c.Attributes {
+		if !a.HasPosition() {
+			continue
+		}
+		pos := a.GetPosition()
+		pos = pos.Sub(offset)
+		a.SetPosition(pos)
+	}
+}
+
+func (c *Connector) Emit(precision int) string {
+	data := []string{`\draw`}
+	if len(c.Attributes) > 0 {
+		data = append(data, fmt.Sprintf("[%s]", c.Attributes.Emit(precision)))
+	}
+	for i, p := range c.Points {
+		if i > 0 {
+			op := p.Operator
+			if op == "" {
+				op = "--"
+			}
+			data = append(data, " "+op)
+			if c.Label != nil && c.LabelIndex == i {
+				data = append(data, fmt.Sprintf(" node[%s] {%s}", c.LabelAttributes.Emit(precision), *c.Label))
+			}
+		}
+		data = append(data, " "+p.Emit(precision))
+	}
+	data = append(data, ";")
+	return strings.Join(data, "")
+}
@@ -1,7 +1,6 @@
 package tikz
 
 import (
-	"fmt"
 	"strings"
 )
 
@@ -35,8 +34,8 @@ func (p Position) AxisRelativeTo(p2 Position) Axis {
 	return AxisX
 }
 
-func (p Position) String() string {
-	return fmt.Sprintf("%f, %f", p.X, p.Y)
+func (p Position) Emit(precision int) string {
+	return formatNumber(p.X, precision) + ", " + formatNumber(p.Y, precision)
 }
 
 func (p Position) Diff(of Position) Position {
@@ -68,10 +67,19 @@ func (p Position) Sub(pos Position) Position {
 
 type PositionList []Position
 
-func (p PositionList) String() string {
+func (p PositionList) Emit(precision int) string {
 	positions := make([]string, len(p))
 	for i, pos := range p {
-		positions[i] = "(" + pos.String() + ")"
+		positions[i] = "(" + pos.Emit(precision) + ")"
 	}
 	return strings.Join(positions, " -- ")
 }
+
+func (p Position) Mul(f float32) Position {
+	return Position{X: p.X * f, Y: p.Y * f}
+}
+
+// Min returns the component-wise minimum of p and p2.
+func (p Position) Min(p2 Position) Position {
+	return Position{X: min(p.X, p2.X), Y: min(p.Y, p2.Y)}
+}
@@ -0,0 +1,213 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"strings"
+)
+
+func (c *Compiler) PaintAttributes(style scene.Style) AttributeList {
+	var attrs AttributeList
+	if style.Fill != nil {
+		attrs = append(attrs, &FillAttribute{ColorSpec(style.Fill)})
+		if style.FillOpacity < 1 {
+			attrs = append(attrs, FillOpacityAttribute(style.FillOpacity))
+		}
+	}
+	if style.Stroked() {
+		attrs = append(attrs, DrawAttribute(ColorSpec(style.Stroke)), LineWidthAttribute(float32(style.StrokeWidth)*c.scale))
+		if len(style.Dash) > 0 {
+			attrs = append(attrs, DashPatternAttribute(c.lengths(style.Dash)))
+		}
+		switch style.Join {
+		case fig.StrokeJoinRound:
+			attrs = append(attrs, LineJoinAttribute("round"))
+		case fig.StrokeJoinBevel:
+			attrs = append(attrs, LineJoinAttribute("bevel"))
+		}
+	}
+	if style.Opacity < 1 {
+		attrs = append(attrs, OpacityAttribute(style.Opacity))
+	}
+	return attrs
+}
+
+func capArrow(cap fig.StrokeCap) string {
+	switch cap {
+	case fig.StrokeCapArrowLines:
+		return "To"
+	case fig.StrokeCapArrowEquilateral, fig.StrokeCapTriangleFilled:
+		return "Triangle"
+	case fig.StrokeCapDiamondFilled:
+		return "Diamond"
+	case fig.StrokeCapCircleFilled:
+		return "Circle"
+	default:
+		return ""
+	}
+}
+
+func rectanglePath(n *fig.NodeChange, size Position, scale float32, precision int) string {
+	radius := func(r float64) string {
+		return fmt.Sprintf("[rounded corners=%scm]", formatNumber(float32(r)*scale, precision))
+	}
+	tl, tr, br, bl := n.CornerRadius, n.CornerRadius, n.CornerRadius, n.CornerRadius
+	if n.RectangleCornerRadiiIndependent {
+		tl = n.RectangleTopLeftCornerRadius
+		tr = n.RectangleTopRightCornerRadius
+		br = n.RectangleBottomRightCornerRadius
+		bl = n.RectangleBottomLeftCornerRadius
+	}
+	if tl == 0 && tr == 0 && br == 0 && bl == 0 {
+		return fmt.Sprintf("(0, 0) rectangle (%s)", size.Emit(precision))
+	}
+
+	// The rounding applied to a corner is the one in effect when the
+	// segment reaching it is added, so we start in the middle of the left
+	// edge and set each radius right before its corner.
+	return strings.Join([]string{
+		fmt.Sprintf("(0, %s)", formatNumber(size.Y/2.0, precision)),
+		radius(tl), "-- (0, 0)",
+		radius(tr), fmt.Sprintf("-- (%s, 0)", formatNumber(size.X, precision)),
+		radius(br), fmt.Sprintf("-- (%s)", size.Emit(precision)),
+		radius(bl), fmt.Sprintf("-- (0, %s)", formatNumber(size.Y, precision)),
+		"[sharp corners] -- cycle",
+	}, " ")
+}
+
+func ellipsePath(n *fig.NodeChange, size Position, precision int) string {
+	rx, ry := size.X/2.0, size.Y/2.0
+	center := Position{X: rx, Y: ry}
+	arc := n.ArcData
+	full := arc == nil || (math.Abs(arc.EndingAngle-arc.StartingAngle) >= 2*math.Pi-1e-3)
+	if full && (arc == nil || arc.InnerRadius == 0) {
+		return fmt.Sprintf("(%s) ellipse [%s]", center.Emit(precision), radii(rx, ry, precision))
+	}
+
+	start := float32(arc.StartingAngle * 180 / math.Pi)
+	end := float32(arc.EndingAngle * 180 / math.Pi)
+	inner := float32(arc.InnerRadius)
+	pointAt := func(angle, f float32) Position {
+		rad := float64(angle) * math.Pi / 180
+		return Position{
+			X: center.X + rx*f*float32(math.Cos(rad)),
+			Y: center.Y + ry*f*float32(math.Sin(rad)),
+		}
+	}
+	outer := arcString(start, end, rx, ry, precision)
+
+	if full {
+		// A ring: two full ellipses filled with the even-odd rule.
+		return fmt.Sprintf("(%s) ellipse [%s] (%s) ellipse [%s]",
+			center.Emit(precision), radii(rx, ry, precision), center.Emit(precision), radii(rx*inner, ry*inner, precision))
+	}
+	if inner == 0 {
+		return fmt.Sprintf("(%s) -- (%s) %s -- cycle", center.Emit(precision), pointAt(start, 1).Emit(precision), outer)
+	}
+	return fmt.Sprintf("(%s) %s -- (%s) %s -- cycle",
+		pointAt(start, 1).Emit(precision), outer, pointAt(end, inner).Emit(precision), arcString(end, start, rx*inner, ry*inner, precision))
+}
+
+func radii(rx, ry float32, precision int) string {
+	return fmt.Sprintf("x radius=%s, y radius=%s", formatNumber(rx, precision), formatNumber(ry, precision))
+}
+
+func arcString(start, end, rx, ry float32, precision int) string {
+	return fmt.Sprintf("arc[start angle=%s, end angle=%s, %s]", formatNumber(start, precision), formatNumber(end, precision), radii(rx, ry, precision))
+}
+
+// polygonPath returns a closed path with count vertices, starting at the
+// top. When inner is not zero, each vertex is followed by another one at
+// that fraction of the radius, forming a star.
+func polygonPath(size Position, count int, inner float32, precision int) string {
+	if count < 3 {
+		count = 3
+	}
+	rx, ry := size.X/2.0, size.Y/2.0
+	steps := count
+	if inner > 0 {
+		steps *= 2
+	}
+	points := make(PositionList, steps)
+	for i := range steps {
+		f := float32(1)
+		if inner > 0 && i%2 == 1 {
+			f = inner
+		}
+		angle := -math.Pi/2 + 2*math.Pi*float64(i)/float64(steps)
+		points[i] = Position{
+			X: rx + rx*f*float32(math.Cos(angle)),
+			Y: ry + ry*f*float32(math.Sin(angle)),
+		}
+	}
+	return points.Emit(precision) + " -- cycle"
+}
+
+var primitiveStyles = map[fig.NodeType]string{
+	fig.NodeTypeRectangle:        "figz/rectangle",
+	fig.NodeTypeRoundedRectangle: "figz/rectangle",
+	fig.NodeTypeEllipse:          "figz/ellipse",
+	fig.NodeTypeLine:             "figz/line",
+	fig.NodeTypeStar:             "figz/star",
+	fig.NodeTypeRegularPolygon:   "figz/polygon",
+	fig.NodeTypeVector:           "figz/vector",
+}
+
+func (c *Compiler) drawPrimitive(w DrawingNode) {
+	n := w.Node
+	size := w.Size
+	attrs := append(AttributeList{w.Transform.Attribute()}, c.PaintAttributes(w.Element.Style)...)
+
+	var segments []string
+	switch n.Type {
+	case fig.NodeTypeRectangle, fig.NodeTypeRoundedRectangle:
+		segments = []string{rectanglePath(n, size, c.scale, c.precision)}
+	case fig.NodeTypeEllipse:
+		segments = []string{ellipsePath(n, size, c.precision)}
+		if n.ArcData != nil && n.ArcData.InnerRadius > 0 {
+			attrs = append(attrs, EvenOddRuleAttribute{})
+		}
+	case fig.NodeTypeStar:
+		inner := float32(n.StarInnerScale)
+		if inner == 0 {
+			inner = 0.382
+		}
+		segments = []string{polygonPath(size, int(n.Count), inner, c.precision)}
+	case fig.NodeTypeRegularPolygon:
+		segments = []string{polygonPath(size, int(n.Count), 0, c.precision)}
+	case fig.NodeTypeLine:
+		if w.Element.Style.Stroke == nil {
+			attrs = append(attrs, DrawAttribute("black"), LineWidthAttribute(float32(max(n.StrokeWeight, 1))*c.scale))
+		}
+		switch n.StrokeCap {
+		case fig.StrokeCapRound:
+			attrs = append(attrs, LineCapAttribute("round"))
+		case fig.StrokeCapSquare:
+			attrs = append(attrs, LineCapAttribute("rect"))
+		default:
+			if tip := capArrow(n.StrokeCap); tip != "" {
+				c.RequireLibrary("arrows.meta")
+				attrs = append(attrs, ArrowsAttribute{End: tip})
+			}
+		}
+		segments = []string{fmt.Sprintf("(0, 0) -- (%s, 0)", formatNumber(size.X, c.precision))}
+	}
+
+	if segments == nil || n.Type == fig.NodeTypeVector {
+		segments = nil
+		for _, cmds := range w.Element.Paths {
+			segments = append(segments, PathCommandsString(cmds, c.scale, c.precision))
+		}
+	}
+	if len(segments) == 0 {
+		c.AddElement(Comment(fmt.Sprintf("Skipping %s: no geometry available", n.Name)))
+	} else {
+		c.AddElement(&Path{
+			Attributes: c.Styled(primitiveStyles[n.Type], attrs...),
+			Segments:   strings.Join(segments, " "),
+		})
+	}
+	c.drawAnchor(w)
+}
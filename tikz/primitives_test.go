@@ -0,0 +1,102 @@
+package tikz
+
+import (
+	"encoding/binary"
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"strings"
+	"testing"
+)
+
+// encodePath encodes commands the way geometry blobs store them.
+func encodePath(cmds ...scene.PathCommand) []byte {
+	var data []byte
+	for _, cmd := range cmds {
+		data = append(data, byte(cmd.Kind))
+		for _, p := range cmd.Points {
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(p.X)))
+			data = binary.LittleEndian.AppendUint32(data, math.Float32bits(float32(p.Y)))
+		}
+	}
+	return data
+}
+
+func TestPathCommandsStringElevatesQuads(t *testing.T) {
+	got := PathCommandsString([]scene.PathCommand{
+		{Kind: scene.PathMoveTo, Points: []scene.Point{{X: 0, Y: 0}}},
+		{Kind: scene.PathQuadTo, Points: []scene.Point{{X: 300, Y: 0}, {X: 300, Y: 300}}},
+		{Kind: scene.PathClose},
+	}, DefaultScale, DefaultPrecision)
+	want := "(0, 0) .. controls (3.6, 0) and (5.4, 1.8) .. (5.4, 5.4) -- cycle"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestDrawPrimitives(t *testing.T) {
+	node := func(typ fig.NodeType) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: guid(1), Type: typ, Name: "Shape", Visible: true, Opacity: 1,
+			Transform: translate(0, 0), Size: &fig.Vector{X: 100, Y: 50},
+			FillPaints: solid(1, 0, 0),
+		}
+	}
+
+	rect := node(fig.NodeTypeRectangle)
+	rect.RectangleCornerRadiiIndependent = true
+	rect.RectangleTopLeftCornerRadius = 10
+	rect.RectangleBottomRightCornerRadius = 5
+	ring := node(fig.NodeTypeEllipse)
+	ring.ArcData = &fig.ArcData{EndingAngle: 2 * math.Pi, InnerRadius: 0.5}
+	star := node(fig.NodeTypeStar)
+	star.Count = 5
+	line := node(fig.NodeTypeLine)
+	line.StrokeCap = fig.StrokeCapArrowLines
+
+	for _, tc := range []struct {
+		node *fig.NodeChange
+		want []string
+	}{
+		{rect, []string{"[rounded corners=0.18cm] -- (0, 0)", "[rounded corners=0cm] -- (1.8, 0)", "[sharp corners] -- cycle"}},
+		{node(fig.NodeTypeEllipse), []string{"(0.9, 0.45) ellipse [x radius=0.9, y radius=0.45]"}},
+		{ring, []string{"even odd rule", "ellipse [x radius=0.45, y radius=0.225]"}},
+		{star, []string{"(0.9, 0) -- ", "-- cycle"}},
+		{line, []string{"draw=black", "-To", "(0, 0) -- (1.8, 0)"}},
+	} {
+		out := compile(t, nil, tc.node)
+		for _, want := range tc.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%v: expected output to contain %q, got:\n%s", tc.node.Type, want, out)
+			}
+		}
+	}
+
+	// Stars alternate between the outer and inner radius.
+	if n := strings.Count(polygonPath(Position{X: 1, Y: 1}, 5, 0.5, DefaultPrecision), "--"); n != 10 {
+		t.Errorf("expected a five-pointed star to have 10 vertices, got %d", n)
+	}
+}
+
+func TestDrawVector(t *testing.T) {
+	vector := &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeVector, Name: "Scribble", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 100, Y: 50},
+		StrokeGeometry: []*fig.Path{{CommandsBlob: 0}},
+	}
+	doc := &decoder.Document{Blobs: []*fig.Blob{{Bytes: encodePath(
+		scene.PathCommand{Kind: scene.PathMoveTo, Points: []scene.Point{{X: 0, Y: 0}}},
+		scene.PathCommand{Kind: scene.PathLineTo, Points: []scene.Point{{X: 100, Y: 50}}},
+	)}}}
+
+	out := render(t, doc, scene.Options{}, nil, vector)
+	if !strings.Contains(out, "(0, 0) -- (1.8, 0.9)") {
+		t.Errorf("expected the vector's stroke geometry, got:\n%s", out)
+	}
+
+	out = compile(t, nil, vector)
+	if !strings.Contains(out, "% Skipping Scribble: no geometry available") {
+		t.Errorf("expected vectors without geometry to be skipped, got:\n%s", out)
+	}
+}
@@ -0,0 +1,69 @@
+package tikz
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// requirements tracks the TikZ libraries and LaTeX packages used by the
+// picture, so that standalone documents load exactly what they need.
+type requirements struct {
+	libraries map[string]struct{}
+	packages  map[string]struct{}
+}
+
+func (c *Compiler) RequireLibrary(name string) {
+	c.requirements.libraries[name] = struct{}{}
+}
+
+func (c *Compiler) RequirePackage(name string) {
+	c.requirements.packages[name] = struct{}{}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (c *Compiler) Libraries() []string {
+	return sortedKeys(c.requirements.libraries)
+}
+
+func (c *Compiler) Packages() []string {
+	return sortedKeys(c.requirements.packages)
+}
+
+func (c *Compiler) writeDocumentHeader() {
+	border := c.opts.StandaloneBorder
+	if border == "" {
+		border = "5pt"
+	}
+	if _, ok := c.requirements.packages["minted"]; ok {
+		c.b.Writef("%% minted requires compiling with -shell-escape")
+	}
+	if _, ok := c.requirements.packages["fontspec"]; ok {
+		c.b.Writef("%% Emoji require compiling with XeLaTeX or LuaLaTeX, and a font providing them")
+	}
+	c.b.Writef("\\documentclass[tikz,border=%s]{standalone}", border)
+	for _, v := range c.Packages() {
+		c.b.Writef("\\usepackage{%s}", v)
+	}
+	if libs := c.Libraries(); len(libs) > 0 {
+		c.b.Writef("\\usetikzlibrary{%s}", strings.Join(libs, ","))
+	}
+	c.b.Writef("\\begin{document}")
+}
+
+func (c *Compiler) writeDocumentFooter() {
+	c.b.Writef("%s", `\end{document}`)
+}
+
+func (c *Compiler) packageComment() string {
+	return fmt.Sprintf("Requires packages: %s; TikZ libraries: %s",
+		strings.Join(c.Packages(), ", "), strings.Join(c.Libraries(), ", "))
+}
@@ -0,0 +1,49 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func TestStandalone(t *testing.T) {
+	out := compile(t, &CompilerOpts{Standalone: true, CodeHighlighter: HighlighterMinted},
+		table(), codeBlock(fig.CodeBlockLanguageGo, "x := 1"))
+
+	header := strings.Join([]string{
+		"% minted requires compiling with -shell-escape",
+		`\documentclass[tikz,border=5pt]{standalone}`,
+		`\usepackage{minted}`,
+		`\usetikzlibrary{matrix}`,
+		`\begin{document}`,
+	}, "\n")
+	if !strings.Contains(out, header) {
+		t.Errorf("expected output to contain the header\n%s\ngot:\n%s", header, out)
+	}
+	if !strings.HasSuffix(out, "\\end{tikzpicture}\n\\end{document}\n\n") {
+		t.Errorf("expected the document to end after the picture, got:\n%s", out)
+	}
+	if strings.Index(out, `\begin{document}`) > strings.Index(out, `\newsavebox`) {
+		t.Errorf("expected code boxes to be saved within the document, got:\n%s", out)
+	}
+}
+
+func TestStandaloneBorder(t *testing.T) {
+	out := compile(t, &CompilerOpts{Standalone: true, StandaloneBorder: "1cm"}, textNode(1, "Hi"))
+	if !strings.Contains(out, `\documentclass[tikz,border=1cm]{standalone}`) {
+		t.Errorf("expected the configured border, got:\n%s", out)
+	}
+	if strings.Contains(out, `\usepackage`) {
+		t.Errorf("expected no packages for plain text, got:\n%s", out)
+	}
+}
+
+func TestPictureListsRequirements(t *testing.T) {
+	out := compile(t, nil, table(), codeBlock(fig.CodeBlockLanguagePlaintext, "x"))
+	if !strings.Contains(out, "% Requires packages: listings, xcolor; TikZ libraries: matrix") {
+		t.Errorf("expected a comment listing requirements, got:\n%s", out)
+	}
+	if strings.Contains(out, `\documentclass`) {
+		t.Errorf("expected a bare picture, got:\n%s", out)
+	}
+}
@@ -0,0 +1,101 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+	"testing"
+)
+
+// sectionPage returns a section holding two shapes and a connector into a
+// shape outside of it, plus a page-level connector between the shapes.
+func sectionPage() (section *fig.NodeChange, nodes []*fig.NodeChange) {
+	section = &fig.NodeChange{
+		Guid: guid(10), Type: fig.NodeTypeSection, Name: "Flow", Visible: true, Opacity: 1,
+		Transform: translate(1000, 500), Size: &fig.Vector{X: 500, Y: 200},
+		Children: []*fig.NodeChange{
+			shape(11, 20, 20, fig.ShapeWithTextTypeSquare, "Inside A"),
+			shape(12, 300, 20, fig.ShapeWithTextTypeSquare, "Inside B"),
+			connector(13, 11, fig.ConnectorMagnetBottom, 20, fig.ConnectorMagnetTop, "Leaves"),
+		},
+	}
+	return section, []*fig.NodeChange{
+		section,
+		shape(20, 0, 0, fig.ShapeWithTextTypeSquare, "Outside"),
+		connector(21, 11, fig.ConnectorMagnetRight, 12, fig.ConnectorMagnetLeft, "Across"),
+	}
+}
+
+func TestCompileSelection(t *testing.T) {
+	section, nodes := sectionPage()
+	out := render(t, nil, scene.Options{Root: section, Clip: true}, nil, nodes...)
+
+	for _, want := range []string{
+		"% Root: Flow (1:10)",
+		"{Inside A}",
+		"{Inside B}",
+		"Across",
+		`\clip (0, 0) rectangle (9, 3.6);`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	// Connectors reaching outside the selection are left out along with
+	// the nodes they reach.
+	for _, unwanted := range []string{"{Outside}", "Leaves"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("expected output not to contain %q, got:\n%s", unwanted, out)
+		}
+	}
+}
+
+// boardPage returns a section holding a sticky, and a diamond outside of it
+// connected to the sticky.
+func boardPage() []*fig.NodeChange {
+	section := &fig.NodeChange{
+		Guid: guid(10), Type: fig.NodeTypeSection, Name: "Ideas", Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 320, Y: 200},
+		Children: []*fig.NodeChange{{
+			Guid: guid(11), Type: fig.NodeTypeSticky, Name: "Sticky", Visible: true, Opacity: 1,
+			Transform: translate(20, 40), Size: &fig.Vector{X: 120, Y: 120},
+			TextData: &fig.TextData{Characters: "Ship it"},
+		}},
+	}
+	return []*fig.NodeChange{
+		section,
+		shape(20, 500, 60, fig.ShapeWithTextTypeDiamond, "Decide"),
+		connector(30, 20, fig.ConnectorMagnetLeft, 11, fig.ConnectorMagnetTop, "yes"),
+	}
+}
+
+func TestRegionSelection(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		region   scene.Rect
+		mode     scene.ConnectorMode
+		want     []string
+		unwanted []string
+	}{
+		// The sticky is nested within the section, and the connector
+		// reaching it has both ends inside the region.
+		{"nested", scene.Rect{Min: scene.Point{X: 0, Y: 0}, Max: scene.Point{X: 700, Y: 200}}, scene.ConnectorsDrop, []string{"{Ship it}", "{Decide}", "yes", "(n-1-20.west)", "(n-1-11.north);"}, nil},
+		{"drop", scene.Rect{Min: scene.Point{X: 400, Y: 0}, Max: scene.Point{X: 700, Y: 200}}, scene.ConnectorsDrop, []string{"{Decide}"}, []string{"yes"}},
+		{"keep", scene.Rect{Min: scene.Point{X: 400, Y: 0}, Max: scene.Point{X: 700, Y: 200}}, scene.ConnectorsKeep, []string{"{Decide}", "yes"}, []string{`\clip`}},
+		{"clip", scene.Rect{Min: scene.Point{X: 400, Y: 0}, Max: scene.Point{X: 700, Y: 200}}, scene.ConnectorsClip, []string{"{Decide}", "yes", `\clip (0, 0) rectangle (5.4, 3.6);`}, nil},
+		{"outside", scene.Rect{Min: scene.Point{X: 2000, Y: 2000}, Max: scene.Point{X: 2100, Y: 2100}}, scene.ConnectorsKeep, nil, []string{"{Decide}", "yes"}},
+	} {
+		region := tc.region
+		out := render(t, nil, scene.Options{Region: &region, RegionConnectors: tc.mode}, nil, boardPage()...)
+		for _, want := range tc.want {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: expected output to contain %q, got:\n%s", tc.name, want, out)
+			}
+		}
+		for _, unwanted := range tc.unwanted {
+			if strings.Contains(out, unwanted) {
+				t.Errorf("%s: expected output not to contain %q, got:\n%s", tc.name, unwanted, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+// nodeShape describes how a ShapeWithText is drawn as a TikZ node.
+type nodeShape struct {
+	style      string
+	attributes []Attribute
+	library    string
+}
+
+var nodeShapes = map[fig.ShapeWithTextType]nodeShape{
+	fig.ShapeWithTextTypeSquare:             {"figz/process", nil, ""},
+	fig.ShapeWithTextTypePredefinedProcess:  {"figz/process", nil, ""},
+	fig.ShapeWithTextTypeRoundedRectangle:   {"figz/shape/rounded", []Attribute{&RoundedCornersAttribute{10}}, ""},
+	fig.ShapeWithTextTypeEllipse:            {"figz/shape/ellipse", []Attribute{NodeShapeAttribute("ellipse")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeDiamond:            {"figz/decision", []Attribute{NodeShapeAttribute("diamond")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeTriangleUp:         {"figz/shape/triangle-up", []Attribute{NodeShapeAttribute("isosceles triangle"), NodeShapeAttribute("isosceles triangle stretches"), NodeShapeAttribute("shape border rotate=90")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeTriangleDown:       {"figz/shape/triangle-down", []Attribute{NodeShapeAttribute("isosceles triangle"), NodeShapeAttribute("isosceles triangle stretches"), NodeShapeAttribute("shape border rotate=270")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeParallelogramRight: {"figz/shape/parallelogram-right", []Attribute{NodeShapeAttribute("trapezium"), NodeShapeAttribute("trapezium stretches"), NodeShapeAttribute("trapezium left angle=60"), NodeShapeAttribute("trapezium right angle=120")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeParallelogramLeft:  {"figz/shape/parallelogram-left", []Attribute{NodeShapeAttribute("trapezium"), NodeShapeAttribute("trapezium stretches"), NodeShapeAttribute("trapezium left angle=120"), NodeShapeAttribute("trapezium right angle=60")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeTrapezoid:          {"figz/shape/trapezoid", []Attribute{NodeShapeAttribute("trapezium"), NodeShapeAttribute("trapezium stretches")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeEngDatabase:        {"figz/shape/database", []Attribute{NodeShapeAttribute("cylinder"), NodeShapeAttribute("shape border rotate=90"), NodeShapeAttribute("aspect=0.25")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeEngQueue:           {"figz/shape/queue", []Attribute{NodeShapeAttribute("cylinder"), NodeShapeAttribute("aspect=0.25")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeHexagon:            {"figz/shape/hexagon", []Attribute{NodeShapeAttribute("regular polygon"), NodeShapeAttribute("regular polygon sides=6")}, "shapes.geometric"},
+	fig.ShapeWithTextTypePentagon:           {"figz/shape/pentagon", []Attribute{NodeShapeAttribute("regular polygon"), NodeShapeAttribute("regular polygon sides=5")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeOctagon:            {"figz/shape/octagon", []Attribute{NodeShapeAttribute("regular polygon"), NodeShapeAttribute("regular polygon sides=8")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeStar:               {"figz/shape/star", []Attribute{NodeShapeAttribute("star"), NodeShapeAttribute("star points=5")}, "shapes.geometric"},
+	fig.ShapeWithTextTypeSummingJunction:    {"figz/shape/circle", []Attribute{NodeShapeAttribute("circle")}, ""},
+	fig.ShapeWithTextTypeOr:                 {"figz/shape/circle", []Attribute{NodeShapeAttribute("circle")}, ""},
+	fig.ShapeWithTextTypeChevron:            {"figz/shape/chevron", []Attribute{NodeShapeAttribute("signal"), NodeShapeAttribute("signal to=east"), NodeShapeAttribute("signal from=west")}, "shapes.symbols"},
+	fig.ShapeWithTextTypeArrowLeft:          {"figz/shape/arrow-left", []Attribute{NodeShapeAttribute("single arrow"), NodeShapeAttribute("shape border rotate=180")}, "shapes.arrows"},
+	fig.ShapeWithTextTypeArrowRight:         {"figz/shape/arrow-right", []Attribute{NodeShapeAttribute("single arrow")}, "shapes.arrows"},
+}
+
+// NodeName returns the name a node is emitted with, derived from its GUID.
+func NodeName(n *fig.NodeChange) string {
+	return fmt.Sprintf("n-%d-%d", n.Guid.SessionId, n.Guid.LocalId)
+}
+
+// isNamed reports whether e is emitted as a named node, allowing
+// connectors to reference its anchors.
+func isNamed(e *scene.Element) bool {
+	if e == nil {
+		return false
+	}
+	switch e.Kind {
+	case scene.KindText, scene.KindShape, scene.KindSticky, scene.KindTable,
+		scene.KindCodeBlock, scene.KindLinkPreview, scene.KindSection, scene.KindFrame:
+		return true
+	}
+	return e.Kind.IsPrimitive()
+}
+
+// drawAnchor emits an invisible node covering w's frame, so that nodes not
+// drawn as TikZ nodes can still be referenced by connectors.
+func (c *Compiler) drawAnchor(w DrawingNode) {
+	q1, q2 := w.Frame()
+	size := q2.Sub(q1)
+	c.AddFramed(w, &Node{
+		Attributes: c.Styled("figz/anchor", InnerSepAttribute("0pt"), &MinimumSizeAttribute{size.X, size.Y}),
+		Name:       NodeName(w.Node),
+		Position:   q1.MiddleWith(q2),
+		Text:       new(string),
+	})
+}
+
+func (c *Compiler) drawShapeWithText(w DrawingNode) {
+	text := c.TextString(w.Element.Text)
+	q1, q2 := w.Frame()
+	size := q2.Sub(q1)
+
+	shape, ok := nodeShapes[w.Node.ShapeWithTextType]
+	if !ok {
+		shape = nodeShape{style: "figz/shape"}
+	}
+	if shape.library != "" {
+		c.RequireLibrary(shape.library)
+	}
+
+	attrs := append(AttributeList{DrawAttribute("black")}, shape.attributes...)
+	if fill := w.Element.Style.Fill; fill != nil {
+		attrs = append(attrs, &FillAttribute{ColorSpec(fill)})
+	}
+	attrs = append(attrs,
+		AlignAttribute("center"),
+		&MinimumSizeAttribute{size.X, size.Y},
+		TextWidthAttribute(max(size.X-0.4, 0)),
+	)
+	c.AddFramed(w, &Node{
+		Attributes: c.Styled(shape.style, append(attrs, w.TextAttributes()...)...),
+		Name:       NodeName(w.Node),
+		Position:   q1.MiddleWith(q2),
+		Text:       &text,
+	})
+}
@@ -0,0 +1,83 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func shape(local uint, x, y float64, typ fig.ShapeWithTextType, name string) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeShapeWithText, Name: name, Visible: true, Opacity: 1,
+		Transform: translate(x, y), Size: &fig.Vector{X: 120, Y: 80},
+		ShapeWithTextType: typ,
+	}
+}
+
+func connector(local, from uint, fromMagnet fig.ConnectorMagnet, to uint, toMagnet fig.ConnectorMagnet, label string) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeConnector, Name: label, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{},
+		ConnectorStart: &fig.ConnectorEndpoint{EndpointNodeId: guid(from), Magnet: fromMagnet},
+		ConnectorEnd:   &fig.ConnectorEndpoint{EndpointNodeId: guid(to), Magnet: toMagnet},
+	}
+}
+
+func TestNamedShapes(t *testing.T) {
+	out := compile(t, nil,
+		shape(1, 0, 0, fig.ShapeWithTextTypeDiamond, "Decide"),
+		shape(2, 200, 0, fig.ShapeWithTextTypeEngDatabase, "Store"))
+
+	for _, want := range []string{
+		"figz/decision/.style={draw=black, diamond, align=center}",
+		`(n-1-1) at (`,
+		"figz/shape/database/.style={draw=black, cylinder, shape border rotate=90, aspect=0.25, align=center}",
+		`(n-1-2) at`,
+		"TikZ libraries: shapes.geometric",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestConnectorsUseAnchors(t *testing.T) {
+	out := compile(t, nil,
+		shape(1, 0, 0, fig.ShapeWithTextTypeSquare, "Start"),
+		shape(2, 400, 0, fig.ShapeWithTextTypeSquare, "End"),
+		connector(3, 1, fig.ConnectorMagnetRight, 2, fig.ConnectorMagnetLeft, "next"))
+
+	if !strings.Contains(out, "(n-1-1.east) -- node[figz/connector/label, pos=0.5] {next} (n-1-2.west);") {
+		t.Errorf("expected the connector to reference both anchors, got:\n%s", out)
+	}
+}
+
+func TestPrimitivesGetAnchors(t *testing.T) {
+	rect := &fig.NodeChange{
+		Guid: guid(2), Type: fig.NodeTypeRectangle, Name: "Box", Visible: true, Opacity: 1,
+		Transform: translate(400, 0), Size: &fig.Vector{X: 100, Y: 100},
+		FillPaints: solid(1, 0, 0),
+	}
+	frame := &fig.NodeChange{
+		Guid: guid(4), Type: fig.NodeTypeFrame, Name: "Frame", Visible: true, Opacity: 1,
+		Transform: translate(400, 400), Size: &fig.Vector{X: 100, Y: 100},
+	}
+	out := compile(t, nil,
+		shape(1, 0, 0, fig.ShapeWithTextTypeSquare, "Start"),
+		rect,
+		frame,
+		connector(3, 1, fig.ConnectorMagnetRight, 2, fig.ConnectorMagnetLeft, ""),
+		connector(5, 1, fig.ConnectorMagnetBottom, 4, fig.ConnectorMagnetTop, ""))
+
+	if !strings.Contains(out, `\node[figz/anchor, minimum width=1.8cm, minimum height=1.8cm] (n-1-2) at`) {
+		t.Errorf("expected the rectangle to get an anchor node, got:\n%s", out)
+	}
+	if !strings.Contains(out, "(n-1-2.west);") {
+		t.Errorf("expected the connector to reference the rectangle's anchor, got:\n%s", out)
+	}
+	// Frames are drawn as nodes, which connectors reach through their
+	// anchors as well.
+	if !strings.Contains(out, "(n-1-4.north);") {
+		t.Errorf("expected the connector to reference the frame, got:\n%s", out)
+	}
+}
@@ -0,0 +1,88 @@
+package tikz
+
+import "fmt"
+
+type StyleAttribute string
+
+func (s StyleAttribute) HasPosition() bool { return false }
+
+func (s StyleAttribute) GetPosition() Position {
+	panic("StyleAttribute has no position")
+}
+
+func (s StyleAttribute) SetPosition(p Position) {
+	panic("StyleAttribute has no position")
+}
+
+func (s StyleAttribute) Emit(precision int) string { return string(s) }
+
+// styleSheet holds the named styles collected during compilation, in the
+// order they were first used.
+type styleSheet struct {
+	names []string
+	defs  map[string]string
+	byKey map[string]string
+}
+
+// isInlineAttribute reports whether a is specific to a single element, and
+// therefore must not be part of a shared style.
+func isInlineAttribute(a Attribute) bool {
+	if a.HasPosition() {
+		return true
+	}
+	switch a.(type) {
+	case *MinimumSizeAttribute, TextWidthAttribute, RotateAttribute, PosAttribute, StyleAttribute:
+		return true
+	}
+	return false
+}
+
+// Styled registers the shareable attributes in attrs as a style named after
+// name, and returns a list referencing it, followed by the attributes that
+// must remain inline. Distinct attribute combinations using the same name
+// are numbered.
+func (c *Compiler) Styled(name string, attrs ...Attribute) AttributeList {
+	var shared, inline AttributeList
+	for _, a := range attrs {
+		if isInlineAttribute(a) {
+			inline = append(inline, a)
+		} else {
+			shared = append(shared, a)
+		}
+	}
+
+	def := shared.Emit(c.precision)
+	key := name + "\x00" + def
+	style, ok := c.styles.byKey[key]
+	if !ok {
+		style = name
+		for i := 2; ; i++ {
+			if _, taken := c.styles.defs[style]; !taken {
+				break
+			}
+			style = fmt.Sprintf("%s/%d", name, i)
+		}
+		c.styles.byKey[key] = style
+		c.styles.defs[style] = def
+		c.styles.names = append(c.styles.names, style)
+	}
+
+	return append(AttributeList{StyleAttribute(style)}, inline...)
+}
+
+func (c *Compiler) writeStyles() {
+	if len(c.styles.names) == 0 && c.opts.StyleOverrides == "" {
+		return
+	}
+	if len(c.styles.names) > 0 {
+		c.b.Writef("\\tikzset{")
+		for _, name := range c.styles.names {
+			c.b.Writef("  %s/.style={%s},", name, c.styles.defs[name])
+		}
+		c.b.Writef("}")
+	}
+	if c.opts.StyleOverrides != "" {
+		c.b.Writef("%% Style overrides")
+		c.b.Writef("%s", c.opts.StyleOverrides)
+	}
+}
@@ -0,0 +1,56 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func stickyNote(local uint, x float64, paints []*fig.Paint) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(local), Type: fig.NodeTypeSticky, Name: "Sticky", Visible: true, Opacity: 1,
+		Transform: translate(x, 0), Size: &fig.Vector{X: 100, Y: 100},
+		FillPaints: paints,
+	}
+}
+
+func TestStylesAreShared(t *testing.T) {
+	out := compile(t, nil,
+		stickyNote(1, 0, solid(1, 0.85, 0.4)),
+		stickyNote(2, 200, solid(1, 0.85, 0.4)),
+		stickyNote(3, 400, solid(0.6, 0.85, 1)))
+
+	if n := strings.Count(out, "figz/sticky/yellow/.style={"); n != 1 {
+		t.Errorf("expected a single yellow sticky style, got %d:\n%s", n, out)
+	}
+	if n := strings.Count(out, `\node[figz/sticky/yellow, minimum width=`); n != 2 {
+		t.Errorf("expected both yellow stickies to use the style, got %d:\n%s", n, out)
+	}
+	if !strings.Contains(out, "figz/sticky/blue/.style={fill={rgb,1:red,0.600;green,0.850;blue,1.000}") {
+		t.Errorf("expected a blue sticky style, got:\n%s", out)
+	}
+	if strings.Index(out, `\tikzset{`) > strings.Index(out, `\begin{tikzpicture}`) {
+		t.Errorf("expected styles to be defined before the picture, got:\n%s", out)
+	}
+}
+
+func TestStylesAreNumbered(t *testing.T) {
+	left := textNode(1, "left")
+	right := textNode(2, "right")
+	right.TextAlignHorizontal = fig.TextAlignHorizontalRight
+	out := compile(t, nil, left, right)
+
+	for _, want := range []string{"figz/text/.style={align=left}", "figz/text/2/.style={align=right}"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestStyleOverrides(t *testing.T) {
+	out := compile(t, &CompilerOpts{StyleOverrides: `\tikzset{figz/text/.append style={red}}`}, textNode(1, "Hi"))
+	want := "}\n% Style overrides\n\\tikzset{figz/text/.append style={red}}\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected overrides after the generated styles, got:\n%s", out)
+	}
+}
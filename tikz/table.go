@@ -0,0 +1,40 @@
+package tikz
+
+func (c *Compiler) drawTable(w DrawingNode) {
+	grid := w.Element.Table
+	if len(grid.Cells) == 0 {
+		c.drawAnchor(w)
+		return
+	}
+
+	c.RequireLibrary("matrix")
+	q1, _ := w.Frame()
+	m := &Matrix{
+		Attributes:   append(AttributeList{AnchorAttribute("north west")}, w.TextAttributes()...),
+		Name:         NodeName(w.Node),
+		Position:     q1,
+		RowHeights:   c.lengths(grid.RowHeights),
+		ColumnWidths: c.lengths(grid.ColumnWidths),
+		Cells:        make([][]MatrixCell, len(grid.Cells)),
+	}
+	if fill := w.Element.Style.Fill; fill != nil {
+		m.Attributes = append(m.Attributes, &FillAttribute{ColorSpec(fill)})
+	}
+	m.Attributes = c.Styled("figz/table", m.Attributes...)
+
+	for i, row := range grid.Cells {
+		m.Cells[i] = make([]MatrixCell, len(row))
+		for j, cell := range row {
+			if cell == nil {
+				continue
+			}
+			mc := MatrixCell{Text: EscapeText(cell.Text.String())}
+			if cell.Fill != nil {
+				mc.Attributes = c.Styled("figz/table/cell", &FillAttribute{ColorSpec(cell.Fill)})
+			}
+			m.Cells[i][j] = mc
+		}
+	}
+
+	c.AddFramed(w, m)
+}
@@ -0,0 +1,62 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+// table builds a table whose rows and columns are listed out of order, so
+// that their fractional positions decide where each cell goes.
+func table() *fig.NodeChange {
+	cell := func(local uint, x, y float64, text string) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: guid(local), Type: fig.NodeTypeTableCell, Visible: true, Opacity: 1,
+			Transform: translate(x, y), Size: &fig.Vector{X: 100, Y: 40},
+			TextData: &fig.TextData{Characters: text},
+		}
+	}
+	return &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeTable, Visible: true, Opacity: 1,
+		Transform: translate(0, 0), Size: &fig.Vector{X: 1, Y: 1},
+		TableRowPositions: &fig.TableRowColumnPositionMap{Entries: []*fig.TableRowColumnPositionMapEntry{
+			{Id: guid(101), Position: "b"},
+			{Id: guid(100), Position: "a"},
+		}},
+		TableRowHeights: &fig.TableRowColumnSizeMap{Entries: []*fig.TableRowColumnSizeMapEntry{
+			{Id: guid(100), Size: 40},
+			{Id: guid(101), Size: 60},
+		}},
+		TableColumnPositions: &fig.TableRowColumnPositionMap{Entries: []*fig.TableRowColumnPositionMapEntry{
+			{Id: guid(201), Position: "b"},
+			{Id: guid(200), Position: "a"},
+		}},
+		TableColumnWidths: &fig.TableRowColumnSizeMap{Entries: []*fig.TableRowColumnSizeMapEntry{
+			{Id: guid(200), Size: 100},
+			{Id: guid(201), Size: 200},
+		}},
+		Children: []*fig.NodeChange{
+			cell(2, 100, 40, "50% done"),
+			cell(3, 0, 0, "Name"),
+			cell(4, 100, 0, "Status"),
+		},
+	}
+}
+
+func TestDrawTable(t *testing.T) {
+	out := compile(t, nil, table())
+
+	if !strings.Contains(out, `\matrix[matrix of nodes`) {
+		t.Fatalf("expected a matrix, got:\n%s", out)
+	}
+	for _, want := range []string{
+		`{Name} & {Status} \\`,
+		`{} & {50\% done} \\`,
+		"column 2/.style",
+		"row 2/.style",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+)
+
+// textAligns maps the alignment of text nodes to the align option of the
+// nodes drawing them.
+var textAligns = map[fig.TextAlignHorizontal]string{
+	fig.TextAlignHorizontalLeft:      "left",
+	fig.TextAlignHorizontalCenter:    "center",
+	fig.TextAlignHorizontalRight:     "right",
+	fig.TextAlignHorizontalJustified: "justify",
+}
+
+var texEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`^`, `\textasciicircum{}`,
+	`_`, `\_`,
+	`%`, `\%`,
+	`~`, `\textasciitilde{}`,
+	"\n", `\\`,
+)
+
+func EscapeText(t string) string {
+	return texEscaper.Replace(strings.TrimRight(t, "\n"))
+}
@@ -2,668 +2,531 @@ package tikz
 
 import (
 	"fmt"
-	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"io"
 	"math"
-	"strings"
 )
 
-const scale = float32(0.018)
 const VERSION = "v0.1"
 
 type CompilerOpts struct {
 	DebugMagnets       bool
 	DebugControlPoints bool
 	FilePath           string
+	CodeHighlighter    CodeHighlighter
+
+	// ImageDir is where image fills are written to.
+	ImageDir string
+
+	// OmitDecorations skips highlights, washi tapes, stamps and emoji
+	// reactions.
+	OmitDecorations bool
+
+	// UnicodeEmoji typesets emoji, which requires compiling with XeLaTeX or
+	// LuaLaTeX and a font providing them. Otherwise, as pdflatex cannot
+	// typeset them, a star is drawn in their place.
+	UnicodeEmoji bool
+
+	// Standalone emits a complete document, loading the packages and
+	// libraries used by the picture.
+	Standalone       bool
+	StandaloneBorder string
+
+	// StyleOverrides is emitted right after the generated styles, allowing
+	// them to be redefined or extended.
+	StyleOverrides string
+
+	// Scale converts canvas units into centimetres, and defaults to
+	// DefaultScale. Width and Height, in centimetres, override it with the
+	// largest scale fitting the scene's bounds into them.
+	Scale  float32
+	Width  float32
+	Height float32
+
+	// Precision is the amount of decimal places numbers are printed with,
+	// and defaults to DefaultPrecision.
+	Precision int
+
+	// DryRun compiles pictures without writing image fills to ImageDir.
+	DryRun bool
 }
 
-func NewCompiler(page *fig.NodeChange, opts *CompilerOpts) string {
+// Renderer renders scenes as TikZ pictures.
+type Renderer struct {
+	Opts *CompilerOpts
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	_, err := io.WriteString(w, NewCompiler(s, r.Opts))
+	return err
+}
+
+func NewCompiler(s *scene.Scene, opts *CompilerOpts) string {
+	return newCompiler(s, opts).ConvertPageToTikz()
+}
+
+// Drawn compiles s, discarding the picture, and returns the elements it
+// draws something for, in drawing order. Elements left out, such as groups
+// and those only producing comments, are skipped by the compiler.
+func Drawn(s *scene.Scene, opts *CompilerOpts) []*scene.Element {
+	c := newCompiler(s, opts)
+	c.ConvertPageToTikz()
+	var res []*scene.Element
+	s.Walk(func(e *scene.Element) bool {
+		if c.drawn[e] {
+			res = append(res, e)
+		}
+		return true
+	})
+	return res
+}
+
+func newCompiler(s *scene.Scene, opts *CompilerOpts) *Compiler {
 	if opts == nil {
 		opts = &CompilerOpts{}
 	}
-	nodes := make([]DrawingNode, len(page.Children))
-	nodeMap := make(map[string]DrawingNode)
-	for i, v := range page.Children {
-		nodes[i] = MakeDrawingNode(v)
-		nodeMap[fmt.Sprintf("%d:%d", v.Guid.SessionId, v.Guid.LocalId)] = nodes[i]
+	if opts.CodeHighlighter == "" {
+		opts.CodeHighlighter = HighlighterListings
 	}
+	size := s.Bounds.Size()
+	scale := resolveScale(Position{X: float32(size.X), Y: float32(size.Y)}, opts)
+
 	c := &Compiler{
-		b:       &sbuf{},
-		opts:    opts,
-		page:    page,
-		nodes:   nodes,
-		nodeMap: nodeMap,
+		b:         &sbuf{},
+		opts:      opts,
+		scene:     s,
+		scale:     scale,
+		precision: DefaultPrecision,
+		languages: map[string]bool{},
+		images:    map[string]string{},
+		badges:    map[string]int{},
+		drawn:     map[*scene.Element]bool{},
+		requirements: requirements{
+			libraries: map[string]struct{}{},
+			packages:  map[string]struct{}{},
+		},
+		styles: styleSheet{
+			defs:  map[string]string{},
+			byKey: map[string]string{},
+		},
+	}
+	if opts.Precision > 0 {
+		c.precision = opts.Precision
+	}
+	if s.Bounded {
+		c.bounds = &[2]Position{c.Point(s.Bounds.Min), c.Point(s.Bounds.Max)}
 	}
-	return c.ConvertPageToTikz()
+	return c
 }
 
 type Compiler struct {
 	b        *sbuf
-	nodes    []DrawingNode
-	nodeMap  map[string]DrawingNode
-	page     *fig.NodeChange
+	scene    *scene.Scene
+	bounds   *[2]Position
 	opts     *CompilerOpts
-	elements []fmt.Stringer
-}
+	elements []Element
+	preamble []string
+	scale    float32
+	drawn    map[*scene.Element]bool
 
-func (c *Compiler) FindNode(g *fig.GUID) DrawingNode {
-	return c.nodeMap[fmt.Sprintf("%d:%d", g.SessionId, g.LocalId)]
-}
+	// precision is the amount of decimal places numbers are printed with.
+	precision int
 
-func (c *Compiler) IsArrowDiagonal(start, end Position) bool {
-	return !(start.X == end.X || start.Y == end.Y)
+	codeBlocks int
+	languages  map[string]bool
+	images     map[string]string
+	badges     map[string]int
+
+	requirements requirements
+	styles       styleSheet
 }
 
-func (c *Compiler) PositionForNode(node DrawingNode, magnet fig.ConnectorMagnet) (pos Position) {
-	toSize := node.Q2.Diff(node.Q1)
-	switch magnet {
-	case fig.ConnectorMagnetNone, fig.ConnectorMagnetAutoHorizontal,
-		fig.ConnectorMagnetAuto, fig.ConnectorMagnetCenter, fig.ConnectorMagnetTop:
-		pos = Position{
-			X: node.Q2.X - toSize.X/2.0,
-			Y: node.Q1.Y - 0.1,
-		}
-	case fig.ConnectorMagnetLeft:
-		pos = Position{
-			X: node.Q1.X - 0.1,
-			Y: node.Q2.Y - toSize.Y/2.0,
-		}
-	case fig.ConnectorMagnetBottom:
-		pos = Position{
-			X: node.Q2.X - toSize.X/2.0,
-			Y: node.Q2.Y + 0.1,
-		}
-	case fig.ConnectorMagnetRight:
-		pos = Position{
-			X: node.Q2.X + 0.1,
-			Y: node.Q2.Y - toSize.Y/2.0,
-		}
+func (c *Compiler) ConvertPageToTikz() string {
+	c.b.Writef("%% This file was generated automatically by figz %s. https://github.com/heyvito/figz", VERSION)
+	c.b.Writef("%% Input file: %s", c.opts.FilePath)
+	if root := c.scene.Root; root != c.scene.Page {
+		c.b.Writef("%% Root: %s (%s)", root.Name, scene.GUIDKey(root.Guid))
 	}
 
-	return
-}
+	for _, e := range c.scene.Elements {
+		c.draw(e)
+	}
 
-func (c *Compiler) MagnetToDirection(magnet fig.ConnectorMagnet) Direction {
-	switch magnet {
-	case fig.ConnectorMagnetNone, fig.ConnectorMagnetAutoHorizontal,
-		fig.ConnectorMagnetAuto, fig.ConnectorMagnetCenter, fig.ConnectorMagnetTop:
-		return TopDirection
-	case fig.ConnectorMagnetLeft:
-		return LeftDirection
-	case fig.ConnectorMagnetBottom:
-		return BottomDirection
-	case fig.ConnectorMagnetRight:
-		return RightDirection
-	default:
-		panic("unreachable")
+	origin := c.findOrigin()
+	if c.bounds != nil {
+		origin = c.bounds[0]
+		if c.scene.Clip {
+			clip := *c.bounds
+			c.elements = []Element{&Scope{Clip: &clip, Elements: c.elements}}
+		}
 	}
-}
 
-var emptyComponentNames = map[string]struct{}{
-	"Connector Name":  {},
-	"Shape with text": {},
-	"Connector line":  {},
+	if c.opts.Standalone {
+		c.writeDocumentHeader()
+	} else if len(c.requirements.packages) > 0 || len(c.requirements.libraries) > 0 {
+		c.b.Writef("%% %s", c.packageComment())
+	}
+	for _, v := range c.preamble {
+		c.b.Writef("%s", v)
+	}
+	c.writeStyles()
+	c.b.Writef("\\begin{tikzpicture}[yscale=-1]")
+	for _, v := range c.elements {
+		v.(Adjuster).Adjust(origin)
+		c.b.Writef("%s", v.Emit(c.precision))
+	}
+	c.b.Writef(`\end{tikzpicture}`)
+	if c.opts.Standalone {
+		c.writeDocumentFooter()
+	}
+	c.b.Writef("")
+
+	return c.b.String()
 }
 
-func (c *Compiler) CleanupText(t string) string {
-	if _, ok := emptyComponentNames[t]; ok {
-		return ""
+// draw adds the elements drawing e to the picture, followed by those
+// drawing the children of containers.
+func (c *Compiler) draw(e *scene.Element) {
+	n := len(c.elements)
+	c.drawElement(e)
+	for _, el := range c.elements[n:] {
+		if _, ok := el.(Comment); !ok {
+			c.drawn[e] = true
+			break
+		}
+	}
+	for _, child := range e.Children {
+		c.draw(child)
 	}
-	return t
 }
 
-func (c *Compiler) ConvertPageToTikz() string {
-	c.b.Writef("%% This file was generated automatically by figz %s. https://github.com/heyvito/figz", VERSION)
-	c.b.Writef("%% Input file: %s", c.opts.FilePath)
-
-	c.b.Writef("\\begin{tikzpicture}[yscale=-1]")
-	for _, v := range c.page.Children {
-		w := MakeDrawingNode(v)
-		switch v.Type {
-		case fig.NodeTypeText:
-			c.drawText(w)
-		case fig.NodeTypeShapeWithText:
-			c.drawShapeWithText(w)
-		case fig.NodeTypeConnector:
+// drawElement adds the elements drawing e to the picture.
+func (c *Compiler) drawElement(e *scene.Element) {
+	w := MakeDrawingNode(e, c.scale)
+	if e.Kind.IsDecoration() {
+		if !c.opts.OmitDecorations {
+			c.drawDecoration(w)
+		}
+		return
+	}
+	c.drawImageFills(w)
+	switch e.Kind {
+	case scene.KindLinkPreview:
+		c.drawLinkPreview(w)
+		return
+	case scene.KindText:
+		c.drawText(w)
+	case scene.KindShape:
+		c.drawShapeWithText(w)
+	case scene.KindSticky:
+		c.drawSticky(w)
+	case scene.KindConnector:
+		if e.Connector.Clipped {
+			c.drawClipped(w)
+		} else {
 			c.drawArrow(w)
 		}
+	case scene.KindTable:
+		c.drawTable(w)
+	case scene.KindCodeBlock:
+		c.drawCodeBlock(w)
+	case scene.KindRectangle, scene.KindEllipse, scene.KindLine, scene.KindStar,
+		scene.KindPolygon, scene.KindVector:
+		c.drawPrimitive(w)
+	case scene.KindSection, scene.KindFrame, scene.KindGroup:
+		c.drawContainer(w)
+	}
+	c.drawLinks(w)
+	if e.Emoji != nil && !c.opts.OmitDecorations {
+		c.drawBadge(w, c.EmojiText(e.Emoji))
 	}
+}
 
-	minX := c.findMinX()
+func (c *Compiler) AddElement(el Element) {
+	c.elements = append(c.elements, el)
+}
 
-	for _, v := range c.elements {
-		v.(XAdjuster).AdjustX(minX)
-		c.b.Writef(v.String())
+// AddFramed adds elements drawn within w's frame, wrapping them in a scope
+// that applies w's transform when required.
+func (c *Compiler) AddFramed(w DrawingNode, els ...Element) {
+	if w.Transform.IsTranslation() {
+		for _, el := range els {
+			c.AddElement(el)
+		}
+		return
 	}
-	c.b.Writef(`\end{tikzpicture}` + "\n")
+	c.AddElement(&Scope{
+		Transform: w.Transform.Attribute(),
+		Elements:  els,
+	})
+}
 
-	return c.b.String()
+// IsDrawn reports whether e has already been drawn, so that the nodes it
+// defines can be referenced.
+func (c *Compiler) IsDrawn(e *scene.Element) bool {
+	return e != nil && c.drawn[e]
 }
 
-func (c *Compiler) AddElement(el fmt.Stringer) {
-	c.elements = append(c.elements, el)
+// drawClipped draws a connector crossing the edge of the selected region,
+// clipping it to the region.
+func (c *Compiler) drawClipped(w DrawingNode) {
+	n := len(c.elements)
+	c.drawArrow(w)
+	clip := *c.bounds
+	scope := &Scope{Clip: &clip, Elements: append([]Element{}, c.elements[n:]...)}
+	c.elements = append(c.elements[:n], scope)
+}
+
+func (c *Compiler) AddPreamble(line string) {
+	c.preamble = append(c.preamble, line)
 }
 
 func (c *Compiler) drawArrow(w DrawingNode) {
+	c.RequireLibrary("arrows.meta")
 	var (
 		v             = w.Node
-		nodeFrom      = c.FindNode(v.ConnectorStart.EndpointNodeId)
-		magnetFrom    = v.ConnectorStart.Magnet
-		nodeTo        = c.FindNode(v.ConnectorEnd.EndpointNodeId)
-		magnetTo      = v.ConnectorEnd.Magnet
-		connectorText = c.CleanupText(v.Name)
-		positionStart = c.PositionForNode(nodeFrom, magnetFrom)
-		positionEnd   = c.PositionForNode(nodeTo, magnetTo)
+		conn          = w.Element.Connector
+		positionStart = c.EndpointPosition(conn.Start)
+		positionEnd   = c.EndpointPosition(conn.End)
 	)
 
 	if c.opts.DebugMagnets {
 		c.AddElement(&FillDraw{
-			Attributes: AttributeList{ColorAttribute("red")},
+			Attributes: c.Styled("figz/debug", ColorAttribute("red")),
 			Position:   positionStart,
 			Shape:      "circle",
 			Size:       "3pt",
 		})
 		c.AddElement(&FillDraw{
-			Attributes: AttributeList{ColorAttribute("red")},
+			Attributes: c.Styled("figz/debug", ColorAttribute("red")),
 			Position:   positionEnd,
 			Shape:      "circle",
 			Size:       "3pt",
 		})
 	}
 
-	var points []Position
+	start := c.ConnectorEndpoint(conn.Start)
+	end := c.ConnectorEndpoint(conn.End)
+	var label string
+	if conn.Label != nil {
+		label = c.TextString(conn.Label)
+	}
 
-	if v.ConnectorControlPoints != nil {
+	if conn.Route == scene.RoutePolyline {
 		if c.opts.DebugControlPoints {
 			for _, con := range v.ConnectorControlPoints {
+				if con == nil || con.Position == nil {
+					continue
+				}
 				pos := Position{
-					X: float32(con.Position.X) * scale,
-					Y: float32(con.Position.Y) * scale,
+					X: float32(con.Position.X) * c.scale,
+					Y: float32(con.Position.Y) * c.scale,
 				}
 				c.AddElement(&FillDraw{
-					Attributes: AttributeList{ColorAttribute("red")},
+					Attributes: c.Styled("figz/debug", ColorAttribute("red")),
 					Position:   pos,
 					Shape:      "blue",
 					Size:       "3pt",
 				})
 			}
 		}
-		curPos := positionStart
-		points = append(points, positionStart)
-		completePath := []Position{positionStart}
-
-		for _, con := range v.ConnectorControlPoints {
-			var newPos Position
-			if con.Axis.X == 1 {
-				newPos = Position{
-					X: curPos.X,
-					Y: float32(con.Position.Y) * scale,
-				}
-			} else {
-				newPos = Position{
-					X: float32(con.Position.X) * scale,
-					Y: curPos.Y,
-				}
-			}
-			completePath = append(completePath, newPos)
-			points = append(points, newPos)
-			curPos = newPos
-		}
-		rawPos := v.ConnectorControlPoints[len(v.ConnectorControlPoints)-1].Position
-		lastPos := Position{X: float32(rawPos.X), Y: float32(rawPos.Y)}
-		lastPos.X *= scale
-		lastPos.Y *= scale
-		points = append(points, lastPos)
-		c.AddElement(&Draw{
-			Attributes: AttributeList{&ThickAttribute{}, &RoundedCornersAttribute{10}},
-			Points:     points,
-			Text:       nil,
-			Kind:       nil,
-		})
-		straight := c.isStraight(curPos, lastPos, magnetTo)
-
-		completePath = append(completePath, lastPos)
-
-		if straight {
-			completePath = append(completePath, positionEnd)
-			c.AddElement(&Draw{
-				Attributes: AttributeList{&ToAttribute{}, &ThickAttribute{}},
-				Points:     []Position{lastPos, positionEnd},
-				Text:       nil,
-				Kind:       nil,
-			})
-		} else {
-			midPath := Position{
-				X: positionEnd.X,
-				Y: lastPos.Y,
-			}
-			finalPosition := positionEnd
-			if lastPos.Y <= finalPosition.Y {
-				finalPosition.Y -= 0.3
-			} else {
-				finalPosition.Y += 0.3
+		inner := conn.Points[1 : len(conn.Points)-1]
+		if n := len(inner); n > 1 {
+			// The corner before the end is left to TikZ, so that the last
+			// leg stays orthogonal when the end references an anchor.
+			corner, before, anchor := inner[n-1], inner[n-2], conn.End.Anchor()
+			switch {
+			case conn.End.Direction.IsVertical() && corner.X == anchor.X && corner.Y == before.Y:
+				inner, end.Operator = inner[:n-1], "-|"
+			case !conn.End.Direction.IsVertical() && corner.Y == anchor.Y && corner.X == before.X:
+				inner, end.Operator = inner[:n-1], "|-"
 			}
-			completePath = append(completePath, midPath, positionEnd)
-			c.AddElement(&Draw{
-				Attributes: AttributeList{&ThickAttribute{}, &RoundedCornersAttribute{10}},
-				Points:     []Position{lastPos, midPath, finalPosition},
-				Text:       nil,
-				Kind:       nil,
-			})
-			c.AddElement(&Draw{
-				Attributes: AttributeList{&ToAttribute{}, &ThickAttribute{}},
-				Points:     []Position{finalPosition, positionEnd},
-				Text:       nil,
-				Kind:       nil,
-			})
 		}
-
-		if connectorText != "" {
-			c.drawArrowTextComplex(completePath, connectorText, v.ConnectorTextMidpoint)
+		points := []PathPoint{start}
+		for _, p := range inner {
+			points = append(points, PathPoint{Position: c.Point(p)})
 		}
-
-	} else if c.IsArrowDiagonal(positionStart, positionEnd) {
-		if midPoint := c.isPathLShaped(positionStart, positionEnd, magnetFrom, magnetTo); midPoint != nil {
-			c.makeLArrow(positionStart, *midPoint, positionEnd)
-		} else {
-			c.makeSArrow(positionStart, positionEnd)
-		}
-	} else {
-		c.AddElement(&Draw{
-			Attributes: AttributeList{&ToAttribute{}, &ThickAttribute{}},
-			Points:     []Position{positionStart, positionEnd},
+		c.AddElement(&Connector{
+			Attributes: c.Styled("figz/connector/elbow", &ToAttribute{}, &ThickAttribute{}, &RoundedCornersAttribute{10}),
+			Points:     append(points, end),
 		})
 
-		if len(connectorText) != 0 {
-			midPoint := v.ConnectorTextMidpoint
-			c.drawArrowTextStraight(positionStart, positionEnd, connectorText, midPoint)
+		if label != "" {
+			c.AddElement(&Node{
+				Attributes: c.Styled("figz/connector/label", DrawAttribute("none"), &FillAttribute{"white"}),
+				Position:   c.Point(conn.LabelPoint()),
+				Text:       &label,
+			})
 		}
-	}
-}
-
-func (c *Compiler) isPathLShaped(p1, p2 Position, startMag, endMag fig.ConnectorMagnet) *Position {
-	if p1.X == p2.X || p1.Y == p2.Y {
-		return nil
+		return
 	}
 
-	// Project possible tangential point for p1, p2
-	possibilities := []Position{
-		{p1.X, p2.Y},
-		{p2.X, p1.Y},
+	connector := &Connector{
+		Attributes: c.Styled("figz/connector", &ToAttribute{}, &ThickAttribute{}),
+		Points:     []PathPoint{start, end},
+		LabelIndex: 1,
 	}
-
-	for _, z := range possibilities {
-		if (p1.X != z.X || p2.Y != z.Y) && (p1.Y != z.Y || p2.X != z.X) {
-			continue
+	switch conn.Route {
+	case scene.RouteElbow:
+		connector.Attributes = c.Styled("figz/connector/elbow", &ToAttribute{}, &ThickAttribute{}, &RoundedCornersAttribute{10})
+		if conn.Points[1].X == conn.Points[0].X {
+			connector.Points[1].Operator = "|-"
+		} else {
+			connector.Points[1].Operator = "-|"
 		}
-		if c.isMagnetAligned(p1, z, startMag) && c.isMagnetAligned(p2, z, endMag) {
-			return &z
+	case scene.RouteStep:
+		connector.Attributes = c.Styled("figz/connector/elbow", &ToAttribute{}, &ThickAttribute{}, &RoundedCornersAttribute{10})
+		c.makeSArrow(connector, positionStart, positionEnd, conn.Start.Direction)
+	}
+	if label != "" {
+		pos := float32(conn.LabelPosition)
+		if len(connector.Points) > 2 {
+			// S-shaped connectors carry their label at the midpoint.
+			pos = 1
 		}
+		connector.Label = &label
+		connector.LabelAttributes = c.Styled("figz/connector/label", DrawAttribute("none"), &FillAttribute{"white"}, PosAttribute(pos))
 	}
-
-	return nil
+	c.AddElement(connector)
 }
 
-func (c *Compiler) isMagnetAligned(p1, p2 Position, mag fig.ConnectorMagnet) bool {
-	return (p1.X < p2.X && mag == fig.ConnectorMagnetRight) ||
-		(p1.X > p2.X && mag == fig.ConnectorMagnetLeft) ||
-		(p1.Y < p2.Y && mag == fig.ConnectorMagnetBottom) ||
-		(p1.Y > p2.Y && mag == fig.ConnectorMagnetTop)
+// EndpointPosition returns the point a connector starts or ends at,
+// slightly away from the outline of its node.
+func (c *Compiler) EndpointPosition(e scene.Endpoint) Position {
+	return c.Point(e.Anchor())
 }
 
-func (c *Compiler) makeSArrow(positionStart, positionEnd Position) {
-	midPoint := float32(math.Abs(float64(positionStart.X-positionEnd.X))) / 2.0
-
-	var x1, x2 float32
-	switch positionStart.DirectionTo(positionEnd) {
-	case RightDirection:
-		x1 = positionStart.X + midPoint
-		x2 = positionEnd.X - midPoint
-	case LeftDirection:
-		x1 = positionStart.X - midPoint
-		x2 = positionEnd.X + midPoint
-	case TopDirection:
-	case BottomDirection:
+// ConnectorEndpoint returns the point a connector starts or ends at,
+// referencing its node's anchor when it is named.
+func (c *Compiler) ConnectorEndpoint(e scene.Endpoint) PathPoint {
+	p := PathPoint{Position: c.EndpointPosition(e)}
+	if !isNamed(e.Element) || !c.IsDrawn(e.Element) {
+		return p
 	}
-	cp1 := Position{
-		Y: positionStart.Y,
-		X: x1,
+	switch e.Direction {
+	case scene.SideTop:
+		p.Coordinate = NodeName(e.Element.Node) + ".north"
+	case scene.SideLeft:
+		p.Coordinate = NodeName(e.Element.Node) + ".west"
+	case scene.SideBottom:
+		p.Coordinate = NodeName(e.Element.Node) + ".south"
+	case scene.SideRight:
+		p.Coordinate = NodeName(e.Element.Node) + ".east"
 	}
+	return p
+}
 
-	cp2 := Position{
-		Y: positionEnd.Y,
-		X: x2,
+// makeSArrow turns connector into a path leaving its start along dir,
+// turning at the middle of the way, and reaching its end along the same
+// axis.
+func (c *Compiler) makeSArrow(connector *Connector, positionStart, positionEnd Position, dir scene.Side) {
+	first, second := "-|", "|-"
+	if dir.IsVertical() {
+		first, second = second, first
 	}
-
-	c.AddElement(&Draw{
-		Attributes: AttributeList{&ToAttribute{}, &ThickAttribute{}, &RoundedCornersAttribute{10}},
-		Points:     []Position{positionStart, cp1, cp2, positionEnd},
-	})
+	start, end := connector.Points[0], connector.Points[1]
+	mid := PathPoint{Position: positionStart.MiddleWith(positionEnd), Operator: first}
+	if start.Coordinate != "" && end.Coordinate != "" {
+		c.RequireLibrary("calc")
+		mid.Coordinate = fmt.Sprintf("$(%s)!0.5!(%s)$", start.Coordinate, end.Coordinate)
+	}
+	end.Operator = second
+	connector.Points = []PathPoint{start, mid, end}
 }
 
-func (c *Compiler) makeLArrow(positionStart, positionMid, positionEnd Position) {
-	c.AddElement(&Draw{
-		Attributes: AttributeList{&ToAttribute{}, &ThickAttribute{}, &RoundedCornersAttribute{10}},
-		Points:     []Position{positionStart, positionMid, positionEnd},
-	})
+// findOrigin returns the top left corner of the picture. Nodes are placed
+// at their centers, so the corners of their bounds are taken into account,
+// along with the points other elements reach.
+func (c *Compiler) findOrigin() Position {
+	m := elementsMin(c.elements)
+	for e := range c.drawn {
+		if isNamed(e) {
+			m = m.Min(c.Point(e.Bounds.Min))
+		}
+	}
+	return m
 }
 
-func (c *Compiler) findMinX() float32 {
-	minX := float32(math.MaxFloat32)
-	for _, v := range c.elements {
+func elementsMin(elements []Element) Position {
+	m := Position{X: math.MaxFloat32, Y: math.MaxFloat32}
+	for _, v := range elements {
 		switch t := v.(type) {
 		case *Draw:
-			hasMinAttr := t.Attributes != nil
-			minAttrX := float32(math.MaxFloat32)
-			if hasMinAttr {
-				minAttrX = t.Attributes.MinX()
-			}
-			theMin := float32(math.MaxFloat32)
+			m = m.Min(t.Attributes.Min())
 			for _, p := range t.Points {
-				if p.X < theMin {
-					theMin = p.X
-				}
-			}
-			theMin = min(minAttrX, theMin)
-			if theMin < minX {
-				minX = theMin
+				m = m.Min(p)
 			}
 		case *Shape:
-			hasMinAttr := t.Attributes != nil
-			minAttrX := float32(math.MaxFloat32)
-			if hasMinAttr {
-				minAttrX = t.Attributes.MinX()
-			}
-			theMin := min(minAttrX, t.P1.X)
-			if theMin < minX {
-				minX = theMin
-			}
+			m = m.Min(t.Attributes.Min()).Min(t.P1).Min(t.P2)
 		case *Node:
-			hasMinAttr := t.Attributes != nil
-			minAttrX := float32(math.MaxFloat32)
-			if hasMinAttr {
-				minAttrX = t.Attributes.MinX()
+			m = m.Min(t.Attributes.Min()).Min(t.Position)
+		case *Matrix:
+			m = m.Min(t.Attributes.Min()).Min(t.Position)
+		case *Scope:
+			switch {
+			case t.Transform != nil:
+				m = m.Min(t.Transform.Position)
+			case t.Clip != nil:
+				m = m.Min(elementsMin(t.Elements)).Min(t.Clip[0]).Min(t.Clip[1])
+			default:
+				m = m.Min(elementsMin(t.Elements))
 			}
-			theMin := min(minAttrX, t.Position.X)
-			if theMin < minX {
-				minX = theMin
+		case *Connector:
+			m = m.Min(t.Attributes.Min())
+			for _, p := range t.Points {
+				if p.Coordinate == "" {
+					m = m.Min(p.Position)
+				}
 			}
+		case *Path:
+			m = m.Min(t.Attributes.Min())
 		case *FillDraw:
-			hasMinAttr := t.Attributes != nil
-			minAttrX := float32(math.MaxFloat32)
-			if hasMinAttr {
-				minAttrX = t.Attributes.MinX()
-			}
-			theMin := min(minAttrX, t.Position.X)
-			if theMin < minX {
-				minX = theMin
-			}
+			m = m.Min(t.Attributes.Min()).Min(t.Position)
 		}
 	}
-	return minX
+	return m
 }
 
-func (c *Compiler) drawShapeWithText(w DrawingNode) {
-	text := c.CleanupText(w.Node.Name)
-
-	switch w.Node.ShapeWithTextType {
-	case fig.ShapeWithTextTypeSquare, fig.ShapeWithTextTypePredefinedProcess:
-		c.AddElement(&Shape{
-			P1:         w.Q1,
-			P2:         w.Q2,
-			Text:       &text,
-			Kind:       "rectangle",
-			Attributes: []Attribute{AlignAttribute("center")},
-		})
-
-	case fig.ShapeWithTextTypeEllipse:
-	case fig.ShapeWithTextTypeDiamond:
-		pos := w.Q1.MiddleWith(w.Q2)
-		c.AddElement(&Shape{
-			Attributes: AttributeList{&RotateAroundAttribute{45, pos}, &ScaleAroundAttribute{0.75, pos}},
-			P1:         w.Q1,
-			P2:         w.Q2,
-			Text:       &text,
-			Kind:       "rectangle",
-		})
-	case fig.ShapeWithTextTypeTriangleUp:
-	case fig.ShapeWithTextTypeTriangleDown:
-	case fig.ShapeWithTextTypeRoundedRectangle:
-	case fig.ShapeWithTextTypeParallelogramRight:
-	case fig.ShapeWithTextTypeParallelogramLeft:
-	case fig.ShapeWithTextTypeEngDatabase:
-	case fig.ShapeWithTextTypeEngQueue:
-	case fig.ShapeWithTextTypeEngFile:
-	case fig.ShapeWithTextTypeEngFolder:
-	case fig.ShapeWithTextTypeTrapezoid:
-	case fig.ShapeWithTextTypeShield:
-	case fig.ShapeWithTextTypeDocumentSingle:
-	case fig.ShapeWithTextTypeDocumentMultiple:
-	case fig.ShapeWithTextTypeManualInput:
-	case fig.ShapeWithTextTypeHexagon:
-	case fig.ShapeWithTextTypeChevron:
-	case fig.ShapeWithTextTypePentagon:
-	case fig.ShapeWithTextTypeOctagon:
-	case fig.ShapeWithTextTypeStar:
-	case fig.ShapeWithTextTypePlus:
-	case fig.ShapeWithTextTypeArrowLeft:
-	case fig.ShapeWithTextTypeArrowRight:
-	case fig.ShapeWithTextTypeSummingJunction:
-	case fig.ShapeWithTextTypeOr:
-	case fig.ShapeWithTextTypeSpeechBubble:
-	case fig.ShapeWithTextTypeInternalStorage:
-	}
-}
-
-func (c *Compiler) isStraight(start, lastPos Position, magnet fig.ConnectorMagnet) (straight bool) {
-	mag := directionFromMagnet(magnet)
-	src := lastPos.DirectionTo(start)
-	switch {
-	case mag == BottomDirection && src == TopDirection,
-		mag == TopDirection && src == BottomDirection,
-		mag == LeftDirection && src == RightDirection,
-		mag == RightDirection && src == LeftDirection:
-		// Already aligned, just go straight
-		straight = true
-	case mag == BottomDirection && src == LeftDirection,
-		mag == TopDirection && src == LeftDirection:
-	case mag == BottomDirection && src == RightDirection,
-		mag == TopDirection && src == RightDirection:
-	case mag == LeftDirection && src == TopDirection,
-		mag == RightDirection && src == TopDirection:
-	case mag == LeftDirection && src == BottomDirection,
-		mag == RightDirection && src == BottomDirection:
-	case mag == BottomDirection && src == BottomDirection:
-		// TODO: This is a problem
-	case mag == TopDirection && src == TopDirection:
-		// TODO: This is a problem
-	case mag == LeftDirection && src == LeftDirection:
-		// TODO: This is a problem
-	case mag == RightDirection && src == RightDirection:
-		// TODO: This is a problem
-	default:
-		panic("unreachable")
-	}
-	return
-}
-
-func (c *Compiler) drawText(v DrawingNode) {
-	point := v.Q1
-	point.X += (v.Q2.Sub(v.Q1)).X/2.0 - 0.55
-	point.Y += (v.Q2.Sub(v.Q1)).Y / 2.0
-	text := strings.ReplaceAll(v.Node.Name, "_", "\\_")
-	c.AddElement(&Node{
-		Position: point,
-		Text:     &text,
-	})
-}
-
-func (c *Compiler) drawArrowTextStraight(startPos Position, endPos Position, text string, midPoint *fig.ConnectorTextMidpoint) {
-	dir := startPos.DirectionTo(endPos)
-	pos := Position{}
-	var start, end float32
-
-	isVertical := dir == TopDirection || dir == BottomDirection
-
-	if isVertical {
-		start, end = startPos.Y, endPos.Y
-		pos.X = startPos.X
-	} else {
-		start, end = startPos.X, endPos.X
-		pos.Y = startPos.Y
-	}
-
-	length := float32(math.Abs(float64(end-start) / 2.0))
-	fPos := end - length
-
-	if midPoint.Section == fig.ConnectorTextSectionMiddleToEnd {
-		fPos += length * float32(midPoint.Offset)
-	} else {
-		fPos -= length * float32(midPoint.Offset)
+func (c *Compiler) drawSticky(w DrawingNode) {
+	q1, q2 := w.Frame()
+	size := q2.Sub(q1)
+	text := c.RichText(w.Element)
+	attrs := AttributeList{
+		&FillAttribute{"white"},
+		AlignAttribute("center"),
+		FontAttribute(`\small`),
+		&MinimumSizeAttribute{size.X, size.Y},
+		TextWidthAttribute(max(size.X-0.4, 0)),
 	}
-
-	if isVertical {
-		pos.Y = fPos
-	} else {
-		pos.X = fPos
+	if fill := w.Element.Style.Fill; fill != nil {
+		attrs[0] = &FillAttribute{ColorSpec(fill)}
 	}
-
-	c.AddElement(&Node{
-		Attributes: AttributeList{DrawAttribute("none"), &FillAttribute{"white"}},
-		Position:   pos,
+	name := "figz/sticky/" + scene.StickyColorName(w.Element.Style.Fill)
+	c.AddFramed(w, &Node{
+		Attributes: c.Styled(name, append(attrs, w.TextAttributes()...)...),
+		Name:       NodeName(w.Node),
+		Position:   q1.MiddleWith(q2),
 		Text:       &text,
 	})
 }
 
-func (c *Compiler) drawArrowTextComplex(path []Position, text string, connectorMid *fig.ConnectorTextMidpoint) {
-	totalLength := float32(0)
-	for i := 1; i < len(path); i++ {
-		last := path[i-1]
-		curr := path[i]
-		var l float32
-		if last.Y == curr.Y {
-			l = float32(math.Abs(float64(curr.X - last.X)))
-		} else {
-			l = float32(math.Abs(float64(curr.Y - last.Y)))
-		}
-		totalLength += l
-	}
-
-	middlePoint := totalLength / 2.0
-	textOffset := middlePoint * float32(connectorMid.Offset)
-
-	ran := float32(0)
-	var prev, curr Position
-	var axis Axis
-	for i := 1; i < len(path); i++ {
-		prev = path[i-1]
-		curr = path[i]
-		if axis = prev.AxisRelativeTo(curr); axis == AxisY {
-			ran += float32(math.Abs(float64(curr.Y - prev.Y)))
-		} else {
-			ran += float32(math.Abs(float64(curr.X - prev.X)))
-		}
-		if ran > textOffset {
-			break
-		}
+func (c *Compiler) drawText(v DrawingNode) {
+	q1, q2 := v.Frame()
+	size := q2.Sub(q1)
+	text := c.RichText(v.Element)
+	// Line breaks in the text require an alignment, and wrapping it
+	// requires a width.
+	attrs := AttributeList{
+		AlignAttribute(textAligns[v.Node.TextAlignHorizontal]),
+		&MinimumSizeAttribute{size.X, size.Y},
 	}
-
-	var textPos Position
-	if axis == AxisX {
-		textPos.X = curr.X
-		textPos.Y = middlePoint + textOffset
-	} else {
-		textPos.X = ran - float32(math.Abs(float64(curr.X-prev.X))) + textOffset*float32(connectorMid.Offset)
-		textPos.Y = prev.Y
+	if size.X > 0.4 {
+		attrs = append(attrs, TextWidthAttribute(size.X-0.4))
 	}
-
-	c.AddElement(&Node{
-		Attributes: AttributeList{DrawAttribute("none"), &FillAttribute{"white"}},
-		Position:   textPos,
+	c.AddFramed(v, &Node{
+		Attributes: c.Styled("figz/text", append(attrs, v.TextAttributes()...)...),
+		Name:       NodeName(v.Node),
+		Position:   q1.MiddleWith(q2),
 		Text:       &text,
 	})
 }
-
-//func (c *Compiler) drawArrowTextComplex(path []Position, text string, connectorMid *fig.ConnectorTextMidpoint) {
-//	totalLength := float32(0)
-//	for i := 1; i < len(path); i++ {
-//		last := path[i-1]
-//		curr := path[i]
-//		var l float32
-//		if last.Y == curr.Y {
-//			l = float32(math.Abs(float64(curr.X - last.X)))
-//		} else {
-//			l = float32(math.Abs(float64(curr.Y - last.Y)))
-//		}
-//		totalLength += l
-//	}
-//
-//	middlePoint := totalLength / 2.0
-//	offset := middlePoint * float32(math.Abs(connectorMid.Offset-1.0))
-//	if connectorMid.Section == fig.ConnectorTextSectionMiddleToEnd {
-//		slices.Reverse(path)
-//	}
-//
-//	textPos := Position{}
-//	isVertical := false
-//	for i := 1; i < len(path); i++ {
-//		prev := path[i-1]
-//		current := path[i]
-//		var distance float32
-//		if prev.X == current.X {
-//			distance = float32(math.Abs(float64(current.Y - prev.Y)))
-//			textPos.X = current.X
-//			isVertical = true
-//
-//			if current.Y > prev.Y {
-//				textPos.Y = prev.Y + offset
-//			} else {
-//				textPos.Y = prev.Y - offset
-//			}
-//
-//		} else {
-//			distance = float32(math.Abs(float64(current.X - prev.X)))
-//			textPos.Y = current.Y
-//			isVertical = false
-//
-//			if current.X > prev.X {
-//				textPos.X = prev.X + offset
-//			} else {
-//				textPos.X = prev.X - offset
-//			}
-//		}
-//
-//		if distance > offset {
-//			break
-//		}
-//
-//		offset = offset - distance
-//	}
-//
-//	attrs := AttributeList{DrawAttribute("none"), &FillAttribute{"white"}}
-//	if isVertical {
-//		attrs = append(attrs, AnchorAttribute("south"))
-//	}
-//
-//	c.AddElement(&Node{
-//		Attributes: attrs,
-//		Position:   textPos,
-//		Text:       &text,
-//	})
-//
-//}
-
-func directionFromMagnet(mag fig.ConnectorMagnet) Direction {
-	switch mag {
-	case fig.ConnectorMagnetNone, fig.ConnectorMagnetAutoHorizontal,
-		fig.ConnectorMagnetAuto, fig.ConnectorMagnetCenter, fig.ConnectorMagnetTop:
-		return TopDirection
-	case fig.ConnectorMagnetLeft:
-		return LeftDirection
-	case fig.ConnectorMagnetBottom:
-		return BottomDirection
-	case fig.ConnectorMagnetRight:
-		return RightDirection
-	default:
-		panic("unreachable")
-	}
-}
@@ -0,0 +1,74 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/decoder"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"slices"
+	"testing"
+)
+
+func guid(local uint) *fig.GUID {
+	return &fig.GUID{SessionId: 1, LocalId: local}
+}
+
+func translate(x, y float64) *fig.Matrix {
+	return &fig.Matrix{M00: 1, M11: 1, M02: x, M12: y}
+}
+
+func solid(r, g, b float64) []*fig.Paint {
+	return []*fig.Paint{{Type: fig.PaintTypeSolid, Visible: true, Opacity: 1, Color: &fig.Color{R: r, G: g, B: b, A: 1}}}
+}
+
+// render places nodes on a page, builds it into a scene from doc with
+// sopts, and compiles the scene with opts.
+func render(t *testing.T, doc *decoder.Document, sopts scene.Options, opts *CompilerOpts, nodes ...*fig.NodeChange) string {
+	t.Helper()
+	sopts.Page = &fig.NodeChange{Guid: guid(0), Type: fig.NodeTypeCanvas, Name: "Page", Children: nodes}
+	s, err := scene.Build(doc, &sopts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCompiler(s, opts)
+}
+
+// compile places nodes on a page and compiles it with opts.
+func compile(t *testing.T, opts *CompilerOpts, nodes ...*fig.NodeChange) string {
+	t.Helper()
+	return render(t, nil, scene.Options{}, opts, nodes...)
+}
+
+func TestDrawn(t *testing.T) {
+	sticky := func(local uint, x float64) *fig.NodeChange {
+		return &fig.NodeChange{
+			Guid: guid(local), Type: fig.NodeTypeSticky, Visible: true, Opacity: 1,
+			Transform: translate(x, 0), Size: &fig.Vector{X: 100, Y: 100},
+		}
+	}
+	page := &fig.NodeChange{Guid: guid(0), Type: fig.NodeTypeCanvas, Children: []*fig.NodeChange{
+		sticky(1, 0),
+		{
+			Guid: guid(2), Type: fig.NodeTypeFrame, Visible: true, Opacity: 1, Transform: translate(200, 0),
+			Size: &fig.Vector{X: 300, Y: 200}, Children: []*fig.NodeChange{sticky(3, 20)},
+		},
+		{Guid: guid(4), Type: fig.NodeTypeStamp, Visible: true, Opacity: 1, Transform: translate(0, 0), Size: &fig.Vector{X: 20, Y: 20}},
+	}}
+	s, err := scene.Build(nil, &scene.Options{Page: page})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(elements []*scene.Element) []string {
+		var res []string
+		for _, e := range elements {
+			res = append(res, e.ID)
+		}
+		return res
+	}
+	if got, want := ids(Drawn(s, nil)), []string{"1:1", "1:2", "1:3", "1:4"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v to be drawn, got %v", want, got)
+	}
+	if got, want := ids(Drawn(s, &CompilerOpts{OmitDecorations: true})), []string{"1:1", "1:2", "1:3"}; !slices.Equal(got, want) {
+		t.Errorf("expected decorations to be left out when omitted, got %v", got)
+	}
+}
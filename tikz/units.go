@@ -0,0 +1,82 @@
+package tikz
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultScale converts canvas units into centimetres.
+const DefaultScale = float32(0.018)
+
+// DefaultPrecision is the amount of decimal places numbers are printed
+// with.
+const DefaultPrecision = 3
+
+// formatNumber prints v with the given amount of decimal places, omitting
+// trailing zeros.
+func formatNumber(v float32, precision int) string {
+	return scene.FormatNumber(float64(v), precision)
+}
+
+var lengthUnits = map[string]float32{
+	"cm": 1,
+	"mm": 0.1,
+	"in": 2.54,
+	"pt": 2.54 / 72.27,
+	"bp": 2.54 / 72,
+}
+
+// ParseLength parses a TeX length such as "12cm" or "4in", returning its
+// value in centimetres. Lengths without a unit are taken as centimetres.
+func ParseLength(s string) (float32, error) {
+	s = strings.TrimSpace(s)
+	unit := float32(1)
+	for name, factor := range lengthUnits {
+		if strings.HasSuffix(s, name) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, name)), factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid length %q", s)
+	}
+	return float32(v) * unit, nil
+}
+
+// Point converts p, given in canvas units, into the picture's coordinate
+// space.
+func (c *Compiler) Point(p scene.Point) Position {
+	return Position{X: float32(p.X) * c.scale, Y: float32(p.Y) * c.scale}
+}
+
+func (c *Compiler) lengths(values []float64) []float32 {
+	res := make([]float32, len(values))
+	for i, v := range values {
+		res[i] = float32(v) * c.scale
+	}
+	return res
+}
+
+// resolveScale returns the scale requested by opts, fitting a bounding box
+// of the given size into Width and Height when they are set.
+func resolveScale(size Position, opts *CompilerOpts) float32 {
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	fit := float32(math.MaxFloat32)
+	if opts.Width > 0 && size.X > 0 {
+		fit = opts.Width / size.X
+	}
+	if opts.Height > 0 && size.Y > 0 {
+		fit = min(fit, opts.Height/size.Y)
+	}
+	if fit == math.MaxFloat32 {
+		return scale
+	}
+	return fit
+}
@@ -0,0 +1,87 @@
+package tikz
+
+import (
+	"github.com/heyvito/figz/fig"
+	"strings"
+	"testing"
+)
+
+func TestFormatNumber(t *testing.T) {
+	for _, tc := range []struct {
+		v         float32
+		precision int
+		want      string
+	}{
+		{1.8, 3, "1.8"},
+		{2, 3, "2"},
+		{0.12345, 3, "0.123"},
+		{0.12345, 1, "0.1"},
+		{-0.0001, 3, "0"},
+		{-1.5, 2, "-1.5"},
+	} {
+		if got := formatNumber(tc.v, tc.precision); got != tc.want {
+			t.Errorf("formatNumber(%v, %d): expected %q, got %q", tc.v, tc.precision, tc.want, got)
+		}
+	}
+}
+
+func TestParseLength(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want float32
+	}{
+		{"12cm", 12},
+		{"12", 12},
+		{"5mm", 0.5},
+		{"2in", 5.08},
+		{" 72bp ", 2.54},
+	} {
+		got, err := ParseLength(tc.s)
+		if err != nil {
+			t.Errorf("ParseLength(%q): unexpected error %v", tc.s, err)
+			continue
+		}
+		if d := got - tc.want; d > 1e-4 || d < -1e-4 {
+			t.Errorf("ParseLength(%q): expected %v, got %v", tc.s, tc.want, got)
+		}
+	}
+	for _, s := range []string{"", "cm", "wide", "-3cm", "0"} {
+		if _, err := ParseLength(s); err == nil {
+			t.Errorf("ParseLength(%q): expected an error", s)
+		}
+	}
+}
+
+func diamond(x, y float64) *fig.NodeChange {
+	return &fig.NodeChange{
+		Guid: guid(1), Type: fig.NodeTypeShapeWithText, ShapeWithTextType: fig.ShapeWithTextTypeDiamond,
+		Name: "Go?", Visible: true, Opacity: 1,
+		Transform: translate(x, y), Size: &fig.Vector{X: 100, Y: 50},
+	}
+}
+
+func TestOriginIsTopLeftCorner(t *testing.T) {
+	out := compile(t, nil, diamond(100, 100))
+	if !strings.Contains(out, "(n-1-1) at (0.9, 0.45)") {
+		t.Errorf("expected the node's corner to sit at the origin, got:\n%s", out)
+	}
+}
+
+func TestScaleOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts *CompilerOpts
+		want string
+	}{
+		{"scale", &CompilerOpts{Scale: 0.01}, "minimum width=1cm, minimum height=0.5cm"},
+		{"width", &CompilerOpts{Width: 10}, "minimum width=10cm, minimum height=5cm"},
+		{"height", &CompilerOpts{Height: 1}, "minimum width=2cm, minimum height=1cm"},
+		{"both", &CompilerOpts{Width: 10, Height: 1}, "minimum width=2cm, minimum height=1cm"},
+		{"precision", &CompilerOpts{Scale: 0.0123456, Precision: 5}, "minimum width=1.23456cm"},
+	} {
+		out := compile(t, tc.opts, diamond(0, 0))
+		if !strings.Contains(out, tc.want) {
+			t.Errorf("%s: expected output to contain %q, got:\n%s", tc.name, tc.want, out)
+		}
+	}
+}
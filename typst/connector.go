@@ -0,0 +1,112 @@
+package typst
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+const (
+	connectorWidth = 1.5
+	// endpointGap is the distance left between connectors and the outline
+	// of the nodes they are attached to.
+	endpointGap = 5
+	// markLength and markWidth are the size of the tips drawn on
+	// connectors, in canvas units.
+	markLength = 10
+	markWidth  = 8
+)
+
+// markSymbol describes the CeTZ mark drawn for a stroke cap.
+type markSymbol struct {
+	name   string
+	filled bool
+}
+
+var markSymbols = map[fig.StrokeCap]markSymbol{
+	fig.StrokeCapArrowLines:       {"straight", false},
+	fig.StrokeCapArrowEquilateral: {"triangle", true},
+	fig.StrokeCapTriangleFilled:   {"stealth", true},
+	fig.StrokeCapDiamondFilled:    {"diamond", true},
+	fig.StrokeCapCircleFilled:     {"circle", true},
+}
+
+// mark returns the mark drawing cap in the given color, or an empty string
+// when the cap is not drawn as a tip.
+func mark(cap fig.StrokeCap, col *scene.Color) string {
+	symbol, ok := markSymbols[cap]
+	if !ok {
+		return ""
+	}
+	fill := "none"
+	if symbol.filled {
+		fill = color(col)
+	}
+	return fmt.Sprintf("(symbol: %s, fill: %s, length: %d, width: %d)", str(symbol.name), fill, markLength, markWidth)
+}
+
+// endpointPosition returns the point a connector starts or ends at,
+// slightly away from the outline of its node.
+func endpointPosition(e scene.Endpoint) scene.Point {
+	if e.ID == "" {
+		return e.Point
+	}
+	return e.Point.Add(e.Direction.Normal().Mul(endpointGap))
+}
+
+func (c *Compiler) drawConnector(e *scene.Element) {
+	conn := e.Connector
+	points := append([]scene.Point{}, conn.Points...)
+	points[0] = endpointPosition(conn.Start)
+	points[len(points)-1] = endpointPosition(conn.End)
+
+	style := scene.Style{
+		Stroke:      e.Style.Stroke,
+		StrokeWidth: connectorWidth,
+		Dash:        e.Style.Dash,
+		Join:        e.Style.Join,
+		Opacity:     e.Style.Opacity,
+	}
+	if style.Stroke == nil {
+		style.Stroke = &scene.Color{A: 1}
+	}
+	if e.Style.StrokeWidth > 0 {
+		style.StrokeWidth = e.Style.StrokeWidth
+	}
+
+	var marks []string
+	if m := mark(conn.StartCap, style.Stroke); m != "" {
+		marks = append(marks, "start: "+m)
+	}
+	if m := mark(conn.EndCap, style.Stroke); m != "" {
+		marks = append(marks, "end: "+m)
+	}
+	args := "stroke: " + c.stroke(style)
+	if len(marks) > 0 {
+		args += ", mark: (" + strings.Join(marks, ", ") + ")"
+	}
+
+	coords := make([]string, len(points))
+	for i, p := range points {
+		coords[i] = c.point(p)
+	}
+	if conn.Clipped && c.scene.Bounded {
+		c.comment("%s crosses the bounds of the drawing", e.Node.Name)
+	}
+	if conn.LineStyle == fig.ConnectorLineStyleCurved && len(points) > 2 {
+		c.line("catmull(%s, %s)", strings.Join(coords, ", "), args)
+	} else {
+		c.line("line(%s, %s)", strings.Join(coords, ", "), args)
+	}
+	if conn.Label != nil {
+		c.drawLabel(e, conn.LabelPoint())
+	}
+}
+
+// drawLabel draws the label of connector e centered at p, over a white
+// background.
+func (c *Compiler) drawLabel(e *scene.Element, p scene.Point) {
+	c.line("content(%s, box(fill: white, inset: %s, text(size: %s, fill: black)%s))",
+		c.point(p), c.length(4), c.length(fontSize(e.Node)), markup(e.Connector.Label))
+}
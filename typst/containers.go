@@ -0,0 +1,52 @@
+package typst
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+// titleHeight is the height of the line section and frame titles are
+// written on, relative to their font size.
+const titleHeight = 1.4
+
+// drawContainer draws the background and title of sections and frames.
+// Groups have neither, and only their children are drawn.
+func (c *Compiler) drawContainer(e *scene.Element) {
+	if e.Kind == scene.KindGroup {
+		return
+	}
+	paint := "fill: none, stroke: gray"
+	if e.Style.Fill != nil || e.Style.Stroked() {
+		paint = c.paint(e.Style)
+	}
+	if e.Kind == scene.KindSection {
+		paint += ", radius: 8"
+	}
+
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, %s)", c.point(scene.Point{}), c.point(e.Size), paint)
+		if e.Node.Name == "" {
+			return
+		}
+		// Section titles are placed within their top left corner, and
+		// frame titles right above it.
+		b := &textBlock{
+			body:   markup(scene.PlainText(e.Node.Name)),
+			size:   defaultFontSize,
+			align:  fig.TextAlignHorizontalLeft,
+			color:  textColor(e.Style.Fill),
+			weight: "bold",
+		}
+		height := defaultFontSize * titleHeight
+		if e.Kind == scene.KindFrame {
+			b.box = scene.Rect{Min: scene.Point{Y: -height}, Max: scene.Point{X: e.Size.X}}
+			b.color, b.weight = "black", ""
+		} else {
+			b.box = scene.Rect{
+				Min: scene.Point{X: textPadding, Y: textPadding},
+				Max: scene.Point{X: max(e.Size.X-textPadding, textPadding), Y: textPadding + height},
+			}
+		}
+		c.drawTextBlock(b)
+	})
+}
@@ -0,0 +1,102 @@
+package typst
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+)
+
+const (
+	highlightOpacity = 0.4
+	washiTapeOpacity = 0.6
+	badgeRadius      = 10
+	badgeSpacing     = 25
+)
+
+// washiTapePatterns holds the tiles drawn over washi tapes, in a 4pt×4pt
+// box.
+var washiTapePatterns = map[fig.StrokeCap]string{
+	fig.StrokeCapWashiTape1: `place(line(start: (0pt, 4pt), end: (4pt, 0pt), stroke: 0.75pt + white))`,
+	fig.StrokeCapWashiTape2: `place(dx: 1.25pt, dy: 1.25pt, circle(radius: 0.75pt, fill: white))`,
+	fig.StrokeCapWashiTape3: `place(line(start: (0pt, 4pt), end: (4pt, 0pt), stroke: 0.5pt + white)) + place(line(start: (0pt, 0pt), end: (4pt, 4pt), stroke: 0.5pt + white))`,
+	fig.StrokeCapWashiTape4: `place(dy: 2pt, line(length: 4pt, stroke: 0.75pt + white))`,
+	fig.StrokeCapWashiTape5: `place(dy: 2pt, line(length: 4pt, stroke: 0.5pt + white)) + place(dx: 2pt, line(length: 4pt, angle: 90deg, stroke: 0.5pt + white))`,
+	fig.StrokeCapWashiTape6: `place(dx: 0.5pt, dy: 0.5pt, circle(radius: 0.5pt, fill: white)) + place(dx: 2.5pt, dy: 2.5pt, circle(radius: 0.5pt, fill: white))`,
+}
+
+func decorationColor(style scene.Style, fallback *scene.Color) *scene.Color {
+	if style.Stroke != nil {
+		return style.Stroke
+	}
+	if style.Fill != nil {
+		return style.Fill
+	}
+	return fallback
+}
+
+func (c *Compiler) drawHighlight(e *scene.Element) {
+	col := color(withOpacity(decorationColor(e.Style, &scene.Color{R: 1, G: 1, A: 1}), highlightOpacity))
+	c.frame(e.Transform, func() {
+		if len(e.Paths) == 0 {
+			// Without geometry, approximate the marker stroke by a line
+			// crossing the node's bounds.
+			y := e.Size.Y / 2
+			c.line(`line(%s, %s, stroke: (paint: %s, thickness: %s, cap: "round"))`,
+				c.point(scene.Point{Y: y}), c.point(scene.Point{X: e.Size.X, Y: y}), col, c.length(e.Size.Y))
+		}
+		for _, cmds := range e.Paths {
+			c.drawPath(cmds, "fill: "+col+", stroke: none")
+		}
+	})
+}
+
+func (c *Compiler) drawWashiTape(e *scene.Element) {
+	col := color(withOpacity(decorationColor(e.Style, &scene.Color{R: 0.5, G: 0.5, B: 0.5, A: 1}), washiTapeOpacity))
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, fill: %s, stroke: none)", c.point(scene.Point{}), c.point(e.Size), col)
+		if tile, ok := washiTapePatterns[e.Node.StrokeCap]; ok {
+			c.line("rect(%s, %s, fill: tiling(size: (4pt, 4pt), %s), stroke: none)",
+				c.point(scene.Point{}), c.point(e.Size), tile)
+		}
+	})
+}
+
+func (c *Compiler) drawBadge(e *scene.Element, label string) {
+	pos := e.Bounds.Center()
+	if e.Host != nil {
+		// Badges are stacked along the top edge of their host, starting
+		// from its right corner.
+		key := e.Host.ID
+		pos = scene.Point{X: e.Host.Bounds.Max.X - float64(c.badges[key])*badgeSpacing, Y: e.Host.Bounds.Min.Y}
+		c.badges[key]++
+	}
+	c.line("circle(%s, radius: %s, fill: white, stroke: %s)",
+		c.point(pos), c.num(badgeRadius), color(decorationColor(e.Style, &scene.Color{A: 1})))
+	c.line("content(%s, text(size: %s, %s))", c.point(pos), c.length(badgeRadius), str(label))
+}
+
+// drawStamp draws the badge of a stamp, labelled with its text or emoji.
+// Other stamps are labelled "+1" when they are votes, or with a star.
+func (c *Compiler) drawStamp(e *scene.Element) {
+	c.comment("%s", scene.DescribeStamp(e.Node))
+	label := e.Text.String()
+	if e.Emoji != nil {
+		label = emojiText(e.Emoji)
+	}
+	if label == "" && scene.IsVote(e.Node) {
+		label = "+1"
+	} else if label == "" {
+		label = "★"
+	}
+	c.drawBadge(e, label)
+}
+
+func (c *Compiler) drawDecoration(e *scene.Element) {
+	switch e.Kind {
+	case scene.KindHighlight:
+		c.drawHighlight(e)
+	case scene.KindWashiTape:
+		c.drawWashiTape(e)
+	case scene.KindStamp:
+		c.drawStamp(e)
+	}
+}
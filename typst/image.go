@@ -0,0 +1,102 @@
+package typst
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// ResolveImage writes img to the configured image directory, and returns
+// the file name it is loaded from.
+func (c *Compiler) ResolveImage(img *scene.Image) (string, error) {
+	if name, ok := c.images[img.Hash]; ok {
+		return name, nil
+	}
+	if img.Format == "" {
+		return "", fmt.Errorf("image %s has an unsupported format", img.Hash)
+	}
+	name := img.Hash + "." + img.Format
+	if err := os.WriteFile(filepath.Join(c.opts.ImageDir, name), img.Data, 0644); err != nil {
+		return "", fmt.Errorf("unable to write image %s: %w", name, err)
+	}
+	c.images[img.Hash] = name
+	return name, nil
+}
+
+func (c *Compiler) drawImageFills(e *scene.Element) {
+	for _, fill := range e.Images {
+		c.drawImagePaint(e, fill)
+	}
+}
+
+func (c *Compiler) drawImagePaint(e *scene.Element, fill scene.ImageFill) {
+	p := fill.Paint
+	err := fill.Err
+	var name string
+	if err == nil {
+		name, err = c.ResolveImage(fill.Image)
+	}
+	if err != nil {
+		c.comment("Skipping image fill of %s: %s", e.Node.Name, err)
+		return
+	}
+
+	size := e.Size
+	rotation := math.Mod(p.Rotation, 360)
+	rotated := math.Mod(math.Abs(p.Rotation), 180) == 90
+
+	// Natural image size, as displayed within the node's bounds.
+	imgSize := scene.Point{X: float64(p.OriginalImageWidth), Y: float64(p.OriginalImageHeight)}
+	if imgSize.X == 0 || imgSize.Y == 0 {
+		imgSize = size
+	}
+
+	// image returns the image displayed with the given size, rotated by the
+	// paint around its center.
+	image := func(display scene.Point, fit string) string {
+		if rotated {
+			display = scene.Point{X: display.Y, Y: display.X}
+		}
+		res := fmt.Sprintf("image(%s, width: %s, height: %s, fit: %s)", str(name), c.length(display.X), c.length(display.Y), str(fit))
+		if rotation != 0 {
+			res = fmt.Sprintf("rotate(%sdeg, reflow: true, %s)", c.num(-rotation), res)
+		}
+		return res
+	}
+
+	var body string
+	switch p.ImageScaleMode {
+	case fig.ImageScaleModeFit:
+		body = image(size, "contain")
+	case fig.ImageScaleModeFill:
+		body = image(size, "cover")
+	case fig.ImageScaleModeTile:
+		tileScale := p.Scale
+		if tileScale == 0 {
+			tileScale = 1
+		}
+		tile := imgSize.Mul(tileScale)
+		if rotated {
+			tile = scene.Point{X: tile.Y, Y: tile.X}
+		}
+		body = fmt.Sprintf("box(width: 100%%, height: 100%%, fill: tiling(size: (%s, %s), %s))",
+			c.length(tile.X), c.length(tile.Y), image(tile, "stretch"))
+	default:
+		// Stretch; a non-identity paint transform means the image was
+		// cropped, and maps the node's bounds into the image's unit square.
+		display, corner := size, scene.Point{}
+		if t := p.Transform; t != nil && t.M00 > 0 && t.M11 > 0 {
+			display = scene.Point{X: size.X / t.M00, Y: size.Y / t.M11}
+			corner = scene.Point{X: -t.M02 * display.X, Y: -t.M12 * display.Y}
+		}
+		body = fmt.Sprintf("place(dx: %s, dy: %s, %s)", c.length(corner.X), c.length(corner.Y), image(display, "stretch"))
+	}
+
+	c.frame(e.Transform, func() {
+		c.line("content(%s, box(width: %s, height: %s, clip: true, %s)%s)",
+			c.point(size.Mul(0.5)), c.length(size.X), c.length(size.Y), body, c.angleArg())
+	})
+}
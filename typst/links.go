@@ -0,0 +1,57 @@
+package typst
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+)
+
+// linkArea returns an invisible box of the given size linking to url.
+func (c *Compiler) linkArea(url string, size scene.Point) string {
+	return fmt.Sprintf("link(%s, box(width: %s, height: %s))", str(url), c.length(size.X), c.length(size.Y))
+}
+
+// drawLinks makes whole nodes with a hyperlink clickable, along with the
+// linked spans of their text. Text nodes link their text directly.
+func (c *Compiler) drawLinks(e *scene.Element) {
+	link := e.Link != "" && e.Kind != scene.KindText && e.Kind != scene.KindLinkPreview
+	if !link && len(e.LinkAreas) == 0 {
+		return
+	}
+	c.frame(e.Transform, func() {
+		if link {
+			c.line("content(%s, %s%s)", c.point(e.Size.Mul(0.5)), c.linkArea(e.Link, e.Size), c.angleArg())
+		}
+		for _, area := range e.LinkAreas {
+			c.line("content(%s, %s%s)", c.point(area.Bounds.Center()), c.linkArea(area.URL, area.Bounds.Size()), c.angleArg())
+		}
+	})
+}
+
+func (c *Compiler) drawLinkPreview(e *scene.Element) {
+	data := e.Node.LinkPreviewData
+	title := data.Title
+	if title == "" {
+		title = data.Url
+	}
+	body := fmt.Sprintf("#strong(%s)", str(title))
+	if data.Provider != "" {
+		body += fmt.Sprintf("#linebreak()#text(size: %s, fill: gray, %s)", c.length(defaultFontSize*0.75), str(data.Provider))
+	}
+	if data.Description != "" {
+		body += fmt.Sprintf("#linebreak()#text(size: %s, %s)", c.length(defaultFontSize*0.875), str(data.Description))
+	}
+	body = "[" + body + "]"
+	if data.Url != "" {
+		body = fmt.Sprintf("[#link(%s)%s]", str(data.Url), body)
+	}
+
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, radius: %s, fill: white, stroke: gray)", c.point(scene.Point{}), c.point(e.Size), c.num(4))
+		c.drawTextBlock(&textBlock{
+			body:  body,
+			box:   padded(e.Size),
+			size:  defaultFontSize,
+			color: "black",
+		})
+	})
+}
@@ -0,0 +1,220 @@
+package typst
+
+import (
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+// shapeStrokeWidth is the width of the outline of shapes with text.
+const shapeStrokeWidth = 1
+
+var joins = map[fig.StrokeJoin]string{
+	fig.StrokeJoinBevel: "bevel",
+	fig.StrokeJoinRound: "round",
+}
+
+// drawPath draws cmds with the given style arguments. Subpaths made of
+// straight lines are drawn as lines, and others are merged from their
+// segments. Each subpath is drawn on its own, so holes are filled.
+func (c *Compiler) drawPath(cmds []scene.PathCommand, args string) {
+	var subpath []scene.PathCommand
+	flush := func(closed bool) {
+		if len(subpath) > 1 {
+			c.drawSubpath(subpath, closed, args)
+		}
+		subpath = nil
+	}
+	for _, cmd := range cmds {
+		switch cmd.Kind {
+		case scene.PathMoveTo:
+			flush(false)
+			subpath = append(subpath, cmd)
+		case scene.PathClose:
+			flush(true)
+		default:
+			subpath = append(subpath, cmd)
+		}
+	}
+	flush(false)
+}
+
+func (c *Compiler) drawSubpath(cmds []scene.PathCommand, closed bool, args string) {
+	if closed {
+		args = "close: true, " + args
+	}
+	straight := true
+	for _, cmd := range cmds {
+		if cmd.Kind != scene.PathMoveTo && cmd.Kind != scene.PathLineTo {
+			straight = false
+		}
+	}
+	if straight {
+		points := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			points[i] = c.point(cmd.Points[0])
+		}
+		c.line("line(%s, %s)", strings.Join(points, ", "), args)
+		return
+	}
+
+	c.line("merge-path(%s, {", args)
+	c.indent++
+	cur := cmds[0].Points[0]
+	for _, cmd := range cmds[1:] {
+		end := cmd.Points[len(cmd.Points)-1]
+		switch cmd.Kind {
+		case scene.PathLineTo:
+			c.line("line(%s, %s)", c.point(cur), c.point(end))
+		case scene.PathQuadTo:
+			c.line("bezier(%s, %s, %s)", c.point(cur), c.point(end), c.point(cmd.Points[0]))
+		case scene.PathCubicTo:
+			c.line("bezier(%s, %s, %s, %s)", c.point(cur), c.point(end), c.point(cmd.Points[0]), c.point(cmd.Points[1]))
+		}
+		cur = end
+	}
+	c.indent--
+	c.line("})")
+}
+
+func (c *Compiler) drawShapeWithText(e *scene.Element) {
+	style := scene.Style{
+		Fill:        e.Style.Fill,
+		FillOpacity: 1,
+		Stroke:      e.Style.Stroke,
+		StrokeWidth: shapeStrokeWidth,
+		Opacity:     1,
+	}
+	if style.Stroke == nil {
+		style.Stroke = &scene.Color{A: 1}
+	}
+	b := newTextBlock(e, padded(e.Size))
+	b.color = textColor(e.Style.Fill)
+	c.frame(e.Transform, func() {
+		c.drawPath(scene.Outline(e), c.paint(style))
+		c.drawTextBlock(b)
+	})
+}
+
+func (c *Compiler) drawSticky(e *scene.Element) {
+	fill := "white"
+	if e.Style.Fill != nil {
+		fill = color(e.Style.Fill)
+	}
+	b := newTextBlock(e, padded(e.Size))
+	b.color = textColor(e.Style.Fill)
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, fill: %s, stroke: none)", c.point(scene.Point{}), c.point(e.Size), fill)
+		c.drawTextBlock(b)
+	})
+}
+
+func (c *Compiler) drawPrimitive(e *scene.Element) {
+	n := e.Node
+	outline := scene.Outline(e)
+	if len(outline) == 0 {
+		c.comment("Skipping %s: no geometry available", n.Name)
+		return
+	}
+
+	args := c.paint(e.Style)
+	if e.Kind == scene.KindLine {
+		style := e.Style
+		if !style.Stroked() {
+			if style.Stroke == nil {
+				style.Stroke = &scene.Color{A: 1}
+			}
+			style.StrokeWidth = max(n.StrokeWeight, 1)
+		}
+		stroke := strings.TrimSuffix(c.stroke(style), ")")
+		switch n.StrokeCap {
+		case fig.StrokeCapRound:
+			stroke += `, cap: "round"`
+		case fig.StrokeCapSquare:
+			stroke += `, cap: "square"`
+		}
+		args = "stroke: " + stroke + ")"
+		if m := mark(n.StrokeCap, style.Stroke); m != "" {
+			args += ", mark: (end: " + m + ")"
+		}
+	}
+	c.frame(e.Transform, func() {
+		c.drawPath(outline, args)
+	})
+}
+
+func (c *Compiler) drawTable(e *scene.Element) {
+	grid := e.Table
+	size := grid.Size()
+	fill := "white"
+	if e.Style.Fill != nil {
+		fill = color(e.Style.Fill)
+	}
+
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, fill: %s, stroke: black)", c.point(scene.Point{}), c.point(size), fill)
+		y := 0.0
+		for i, row := range grid.Cells {
+			x := 0.0
+			for j, cell := range row {
+				box := scene.Rect{
+					Min: scene.Point{X: x, Y: y},
+					Max: scene.Point{X: x + grid.ColumnWidths[j], Y: y + grid.RowHeights[i]},
+				}
+				x = box.Max.X
+				if cell == nil {
+					continue
+				}
+				if cell.Fill != nil {
+					c.line("rect(%s, %s, fill: %s, stroke: black)", c.point(box.Min), c.point(box.Max), color(cell.Fill))
+				}
+				inner := padded(box.Size())
+				c.drawTextBlock(&textBlock{
+					body:   markup(cell.Text),
+					box:    scene.Rect{Min: box.Min.Add(inner.Min), Max: box.Min.Add(inner.Max)},
+					size:   defaultFontSize,
+					align:  fig.TextAlignHorizontalCenter,
+					middle: true,
+					color:  textColor(cell.Fill),
+				})
+			}
+			y += grid.RowHeights[i]
+		}
+
+		// Grid lines between rows and columns.
+		y = 0
+		for _, h := range grid.RowHeights[:max(len(grid.RowHeights)-1, 0)] {
+			y += h
+			c.line("line(%s, %s)", c.point(scene.Point{Y: y}), c.point(scene.Point{X: size.X, Y: y}))
+		}
+		x := 0.0
+		for _, w := range grid.ColumnWidths[:max(len(grid.ColumnWidths)-1, 0)] {
+			x += w
+			c.line("line(%s, %s)", c.point(scene.Point{X: x}), c.point(scene.Point{X: x, Y: size.Y}))
+		}
+	})
+}
+
+func (c *Compiler) drawCodeBlock(e *scene.Element) {
+	fill := &scene.Color{R: 0.96, G: 0.96, B: 0.96, A: 1}
+	if e.Style.Fill != nil {
+		fill = e.Style.Fill
+	}
+	code := "raw(" + str(e.Text.String()) + ", block: true"
+	if lang, ok := scene.CodeLanguage(e.Node.CodeBlockLanguage); ok {
+		code += ", lang: " + str(lang)
+	}
+	code += ")"
+	b := &textBlock{
+		body:  "[#" + code + "]",
+		box:   padded(e.Size),
+		size:  fontSize(e.Node),
+		align: fig.TextAlignHorizontalLeft,
+		color: textColor(fill),
+	}
+	c.frame(e.Transform, func() {
+		c.line("rect(%s, %s, radius: %s, fill: %s, stroke: none)",
+			c.point(scene.Point{}), c.point(e.Size), c.num(4), color(fill))
+		c.drawTextBlock(b)
+	})
+}
@@ -0,0 +1,23 @@
+// This file was generated automatically by figz. https://github.com/heyvito/figz
+// Input file: board.jam
+#import "@preview/cetz:0.3.4"
+
+#cetz.canvas(length: 0.018cm, {
+  import cetz.draw: *
+  content((100, 45), box(width: 3.6cm, height: 0.54cm, align(left + top, text(size: 0.288cm, fill: rgb("#000000"))[#"Retro <2024>"])))
+  rect((0, 0), (320, -200), fill: rgb("#e6f2ff"), stroke: none, radius: 8)
+  content((160, -21.2), box(width: 5.4cm, height: 0.403cm, align(left + top, text(size: 0.288cm, fill: black, weight: "bold")[#"Ideas"])))
+  rect((20, -40), (140, -160), fill: rgb("#ffd966"), stroke: none)
+  content((80, -100), box(width: 1.8cm, height: 1.8cm, align(center + horizon, text(size: 0.288cm, fill: black)[#"Ship it"])))
+  rect((180, -40), (300, -160), fill: rgb("#ffd966"), stroke: none)
+  content((240, -100), box(width: 1.8cm, height: 1.8cm, align(center + horizon, text(size: 0.288cm, fill: black)[#"Write \"tests\" & docs"])))
+  // Vote by user 42
+  circle((140, -40), radius: 10, fill: white, stroke: rgb("#000000"))
+  content((140, -40), text(size: 0.18cm, "+1"))
+  line((560, -60), (620, -100), (560, -140), (500, -100), close: true, fill: rgb("#cce6cc"), stroke: (paint: rgb("#000000"), thickness: 0.018cm))
+  content((560, -100), box(width: 1.8cm, height: 1.08cm, align(center + horizon, text(size: 0.288cm, fill: black)[#"Decide"])))
+  line((495, -100), (287.5, -100), (287.5, -35), (80, -35), stroke: (paint: rgb("#000000"), thickness: 0.036cm), mark: (end: (symbol: "straight", fill: none, length: 10, width: 8)))
+  content((287.5, -67.5), box(fill: white, inset: 0.072cm, text(size: 0.288cm, fill: black)[#"yes"]))
+  rect((0, -260), (300, -340), radius: 4, fill: rgb("#f5f5f5"), stroke: none)
+  content((150, -300), box(width: 5.04cm, height: 1.08cm, align(left + top, text(size: 0.288cm, fill: black)[#raw("fmt.Println(\"hi\")\n", block: true, lang: "go")])))
+})
@@ -0,0 +1,160 @@
+package typst
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/fig"
+	"github.com/heyvito/figz/scene"
+	"strings"
+)
+
+const (
+	defaultFontSize = 16
+	// textPadding is the space left between text and the edges of the
+	// element holding it.
+	textPadding = 10
+)
+
+var alignments = map[fig.TextAlignHorizontal]string{
+	fig.TextAlignHorizontalLeft:      "left",
+	fig.TextAlignHorizontalCenter:    "center",
+	fig.TextAlignHorizontalRight:     "right",
+	fig.TextAlignHorizontalJustified: "left",
+}
+
+// textBlock is text laid out within a box, in the coordinates of the
+// element holding it.
+type textBlock struct {
+	// body is the Typst content of the block.
+	body   string
+	box    scene.Rect
+	size   float64
+	align  fig.TextAlignHorizontal
+	middle bool
+	color  string
+	font   string
+	weight string
+	style  string
+	// nowrap keeps lines from being broken at the width of the box.
+	nowrap bool
+}
+
+func fontSize(n *fig.NodeChange) float64 {
+	if n.FontSize > 0 {
+		return n.FontSize
+	}
+	return defaultFontSize
+}
+
+// textColor returns the color of text drawn over fill.
+func textColor(fill *scene.Color) string {
+	if fill.IsDark() {
+		return "white"
+	}
+	return "black"
+}
+
+// padded returns the area of an element of the given size text is laid out
+// in.
+func padded(size scene.Point) scene.Rect {
+	p := scene.Point{X: min(textPadding, size.X/2), Y: min(textPadding, size.Y/2)}
+	return scene.Rect{Min: p, Max: size.Sub(p)}
+}
+
+// emojiText returns the characters of the given code points.
+func emojiText(codePoints []uint) string {
+	chars := make([]rune, len(codePoints))
+	for i, v := range codePoints {
+		chars[i] = rune(v)
+	}
+	return string(chars)
+}
+
+// markup returns t as Typst content, with its mentions styled.
+func markup(t *scene.Text) string {
+	if t == nil {
+		return "[]"
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	for _, run := range t.Runs {
+		if run.Mention {
+			fmt.Fprintf(&b, "#text(fill: blue, %s)", str(run.Text))
+		} else {
+			b.WriteString("#" + str(run.Text))
+		}
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// newTextBlock lays the text of e out within box.
+func newTextBlock(e *scene.Element, box scene.Rect) *textBlock {
+	n := e.Node
+	b := &textBlock{
+		body:   markup(e.Text),
+		box:    box,
+		size:   fontSize(n),
+		align:  fig.TextAlignHorizontalCenter,
+		middle: true,
+		color:  "black",
+	}
+	if f := n.FontName; f != nil {
+		b.font = f.Family
+		style := strings.ToLower(f.Style)
+		if strings.Contains(style, "bold") {
+			b.weight = "bold"
+		}
+		if strings.Contains(style, "italic") {
+			b.style = "italic"
+		}
+	}
+	return b
+}
+
+func (c *Compiler) drawTextBlock(b *textBlock) {
+	if b.body == "[]" || b.body == "" {
+		return
+	}
+	args := []string{"size: " + c.length(b.size), "fill: " + b.color}
+	if b.font != "" {
+		args = append(args, "font: "+str(b.font))
+	}
+	if b.weight != "" {
+		args = append(args, "weight: "+str(b.weight))
+	}
+	if b.style != "" {
+		args = append(args, "style: "+str(b.style))
+	}
+	align := alignments[b.align]
+	if align == "" {
+		align = "left"
+	}
+	if b.middle {
+		align += " + horizon"
+	} else {
+		align += " + top"
+	}
+	body := fmt.Sprintf("align(%s, text(%s)%s)", align, strings.Join(args, ", "), b.body)
+	if !b.nowrap {
+		size := b.box.Size()
+		body = fmt.Sprintf("box(width: %s, height: %s, %s)", c.length(size.X), c.length(size.Y), body)
+	}
+	c.line("content(%s, %s%s)", c.point(b.box.Center()), body, c.angleArg())
+}
+
+func (c *Compiler) drawText(e *scene.Element) {
+	n := e.Node
+	b := newTextBlock(e, scene.Rect{Max: e.Size})
+	b.align = n.TextAlignHorizontal
+	b.middle = n.TextAlignVertical == fig.TextAlignVerticalCenter
+	b.nowrap = n.TextAutoResize == fig.TextAutoResizeWidthAndHeight
+	if fill := e.Style.Fill; fill != nil {
+		b.color = color(fill)
+	}
+	if e.Link != "" {
+		b.body = fmt.Sprintf("[#link(%s)%s]", str(e.Link), b.body)
+	}
+	c.frame(e.Transform, func() {
+		c.drawTextBlock(b)
+	})
+}
@@ -0,0 +1,349 @@
+package typst
+
+import (
+	"fmt"
+	"github.com/heyvito/figz/scene"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultScale converts canvas units into centimetres.
+const DefaultScale = 0.018
+
+// DefaultPrecision is the amount of decimal places numbers are printed
+// with.
+const DefaultPrecision = 3
+
+// CetzVersion is the version of the CeTZ package drawings are written for.
+const CetzVersion = "0.3.4"
+
+type Options struct {
+	FilePath string
+
+	// ImageDir is the directory image fills are written to, next to the
+	// generated document.
+	ImageDir string
+
+	// OmitDecorations skips highlights, washi tapes, stamps and emoji
+	// reactions.
+	OmitDecorations bool
+
+	// Standalone emits a complete document with its page sized to the
+	// drawing, surrounded by Border.
+	Standalone bool
+	Border     string
+
+	// Scale is the amount of centimetres per canvas unit, and defaults to
+	// DefaultScale. Width and Height, in centimetres, scale the drawing to
+	// fit them instead.
+	Scale  float64
+	Width  float64
+	Height float64
+
+	// Precision is the amount of decimal places numbers are printed with,
+	// and defaults to DefaultPrecision.
+	Precision int
+}
+
+// Renderer renders scenes as CeTZ canvases for Typst documents.
+type Renderer struct {
+	Opts *Options
+}
+
+func (r *Renderer) Render(s *scene.Scene, w io.Writer) error {
+	opts := r.Opts
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Compiler{
+		scene:     s,
+		opts:      opts,
+		precision: DefaultPrecision,
+		images:    map[string]string{},
+		badges:    map[string]int{},
+	}
+	if opts.Precision > 0 {
+		c.precision = opts.Precision
+	}
+	_, err := io.WriteString(w, c.Compile())
+	return err
+}
+
+type Compiler struct {
+	scene     *scene.Scene
+	opts      *Options
+	precision int
+	scale     float64
+	b         strings.Builder
+	indent    int
+
+	// origin is added to the coordinates of the element being drawn, and
+	// angle is the rotation of its frame, in degrees.
+	origin scene.Point
+	angle  float64
+
+	images map[string]string
+	badges map[string]int
+}
+
+// Compile returns the Typst source drawing the scene.
+func (c *Compiler) Compile() string {
+	bounds := c.contentBounds()
+	c.scale = c.resolveScale(bounds.Size())
+
+	c.indent = 1
+	c.line("import cetz.draw: *")
+	if c.scene.Bounded {
+		// An invisible rectangle keeps the canvas at least as large as the
+		// scene's bounds.
+		c.line("rect(%s, %s, stroke: none)", c.point(bounds.Min), c.point(bounds.Max))
+		if c.scene.Clip {
+			c.comment("Content outside of the bounds is not clipped")
+		}
+	}
+	for _, e := range c.scene.Elements {
+		c.drawElement(e)
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "// This file was generated automatically by figz. https://github.com/heyvito/figz\n")
+	fmt.Fprintf(&out, "// Input file: %s\n", c.opts.FilePath)
+	if root := c.scene.Root; root != c.scene.Page {
+		fmt.Fprintf(&out, "// Root: %s (%s)\n", root.Name, scene.GUIDKey(root.Guid))
+	}
+	fmt.Fprintf(&out, "#import \"@preview/cetz:%s\"\n", CetzVersion)
+	if c.opts.Standalone {
+		border := c.opts.Border
+		if border == "" {
+			border = "5pt"
+		}
+		fmt.Fprintf(&out, "#set page(width: auto, height: auto, margin: %s)\n", border)
+	}
+	out.WriteString("\n")
+	// The scale is printed in full, as rounding it would scale the whole
+	// drawing.
+	fmt.Fprintf(&out, "#cetz.canvas(length: %scm, {\n", strconv.FormatFloat(c.scale, 'f', -1, 64))
+	out.WriteString(c.b.String())
+	out.WriteString("})\n")
+	return out.String()
+}
+
+// contentBounds returns the area of the scene the drawing covers.
+func (c *Compiler) contentBounds() scene.Rect {
+	if c.scene.Bounded {
+		return c.scene.Bounds
+	}
+	r := c.scene.Bounds
+	for _, e := range c.scene.Elements {
+		if !e.Bounds.IsEmpty() {
+			r = r.Union(e.Bounds)
+		}
+	}
+	return r
+}
+
+// resolveScale returns the amount of centimetres per canvas unit, fitting a
+// drawing of the given size within the requested width and height.
+func (c *Compiler) resolveScale(size scene.Point) float64 {
+	scale := c.opts.Scale
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	fit := math.MaxFloat64
+	if c.opts.Width > 0 && size.X > 0 {
+		fit = c.opts.Width / size.X
+	}
+	if c.opts.Height > 0 && size.Y > 0 {
+		fit = min(fit, c.opts.Height/size.Y)
+	}
+	if fit == math.MaxFloat64 {
+		return scale
+	}
+	return fit
+}
+
+func (c *Compiler) drawElement(e *scene.Element) {
+	if e.Kind.IsDecoration() {
+		if !c.opts.OmitDecorations {
+			c.drawDecoration(e)
+		}
+		return
+	}
+
+	c.drawImageFills(e)
+	switch e.Kind {
+	case scene.KindLinkPreview:
+		c.drawLinkPreview(e)
+	case scene.KindText:
+		c.drawText(e)
+	case scene.KindShape:
+		c.drawShapeWithText(e)
+	case scene.KindSticky:
+		c.drawSticky(e)
+	case scene.KindConnector:
+		c.drawConnector(e)
+	case scene.KindTable:
+		c.drawTable(e)
+	case scene.KindCodeBlock:
+		c.drawCodeBlock(e)
+	case scene.KindRectangle, scene.KindEllipse, scene.KindLine, scene.KindStar,
+		scene.KindPolygon, scene.KindVector:
+		c.drawPrimitive(e)
+	case scene.KindSection, scene.KindFrame, scene.KindGroup:
+		c.drawContainer(e)
+	}
+	c.drawLinks(e)
+	if e.Emoji != nil && !c.opts.OmitDecorations {
+		c.drawBadge(e, emojiText(e.Emoji))
+	}
+	for _, child := range e.Children {
+		c.drawElement(child)
+	}
+}
+
+func (c *Compiler) line(format string, args ...any) {
+	c.b.WriteString(strings.Repeat("  ", c.indent))
+	fmt.Fprintf(&c.b, format, args...)
+	c.b.WriteString("\n")
+}
+
+// comment writes a note about content that could not be converted.
+func (c *Compiler) comment(format string, args ...any) {
+	c.line("// %s", strings.ReplaceAll(fmt.Sprintf(format, args...), "\n", " "))
+}
+
+// frame draws the content written by draw in the coordinates of an element
+// placed by t. Elements that are not only translated are drawn within a
+// group of their own.
+func (c *Compiler) frame(t scene.Transform, draw func()) {
+	if t.IsTranslation() {
+		c.origin = scene.Point{X: t.M02, Y: t.M12}
+		draw()
+		c.origin = scene.Point{}
+		return
+	}
+
+	// The y axis points up in CeTZ, which reverses rotations.
+	rotation := t.Rotation()
+	sx := math.Hypot(t.M00, t.M10)
+	sy := (t.M00*t.M11 - t.M01*t.M10) / sx
+	c.line("group({")
+	c.indent++
+	c.line("set-origin(%s)", c.point(scene.Point{X: t.M02, Y: t.M12}))
+	if rotation != 0 {
+		c.line("rotate(%sdeg)", c.num(-rotation))
+	}
+	if math.Abs(sx-1) > 1e-6 || math.Abs(sy-1) > 1e-6 {
+		c.line("scale(x: %s, y: %s)", c.num(sx), c.num(sy))
+	}
+	c.angle = rotation
+	draw()
+	c.angle = 0
+	c.indent--
+	c.line("})")
+}
+
+// num prints v with the configured precision, omitting trailing zeros.
+func (c *Compiler) num(v float64) string {
+	return scene.FormatNumber(v, c.precision)
+}
+
+// point returns the coordinate of p, given in the current frame.
+func (c *Compiler) point(p scene.Point) string {
+	p = p.Add(c.origin)
+	return "(" + c.num(p.X) + ", " + c.num(-p.Y) + ")"
+}
+
+// length returns v, given in canvas units, as a Typst length.
+func (c *Compiler) length(v float64) string {
+	return c.num(v*c.scale) + "cm"
+}
+
+// angleArg returns the argument rotating content along with the current
+// frame.
+func (c *Compiler) angleArg() string {
+	if c.angle == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", angle: %sdeg", c.num(-c.angle))
+}
+
+// str returns s as a Typst string literal. Characters that are not
+// printable, such as zero width joiners, are escaped as \u{...}.
+func str(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if unicode.IsPrint(r) {
+				b.WriteRune(r)
+			} else {
+				fmt.Fprintf(&b, `\u{%x}`, r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// color returns col as a Typst color, or none.
+func color(col *scene.Color) string {
+	if col == nil {
+		return "none"
+	}
+	if col.A < 1 {
+		return fmt.Sprintf("rgb(%s)", str(col.Hex()+fmt.Sprintf("%02x", int(math.Round(col.A*255)))))
+	}
+	return fmt.Sprintf("rgb(%s)", str(col.Hex()))
+}
+
+// withOpacity returns the color fill paints with the given opacity.
+func withOpacity(col *scene.Color, opacity float64) *scene.Color {
+	if col == nil || opacity >= 1 {
+		return col
+	}
+	res := *col
+	res.A *= opacity
+	return &res
+}
+
+// stroke returns the stroke drawn by style.
+func (c *Compiler) stroke(style scene.Style) string {
+	if !style.Stroked() {
+		return "none"
+	}
+	parts := []string{
+		"paint: " + color(withOpacity(style.Stroke, style.Opacity)),
+		"thickness: " + c.length(style.StrokeWidth),
+	}
+	if len(style.Dash) > 0 {
+		dash := make([]string, len(style.Dash))
+		for i, v := range style.Dash {
+			dash[i] = c.length(v)
+		}
+		parts = append(parts, "dash: ("+strings.Join(dash, ", ")+",)")
+	}
+	if join := joins[style.Join]; join != "" {
+		parts = append(parts, "join: "+str(join))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// paint returns the fill and stroke arguments of style.
+func (c *Compiler) paint(style scene.Style) string {
+	fill := withOpacity(withOpacity(style.Fill, style.FillOpacity), style.Opacity)
+	return "fill: " + color(fill) + ", stroke: " + c.stroke(style)
+}
@@ -0,0 +1,67 @@
+package typst
+
+import (
+	"bytes"
+	"github.com/heyvito/figz/scene/scenetest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, opts *Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := (&Renderer{Opts: opts}).Render(scenetest.Scene(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRender(t *testing.T) {
+	out := render(t, &Options{FilePath: "board.jam"})
+	scenetest.Golden(t, "board.typ", []byte(out))
+
+	for _, want := range []string{
+		`#import "@preview/cetz:` + CetzVersion + `"`,
+		`// Vote by user 42`,
+		`"Write \"tests\" & docs"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "#set page") {
+		t.Errorf("expected no page setup unless standalone")
+	}
+
+	out = render(t, &Options{Standalone: true, OmitDecorations: true})
+	if !strings.Contains(out, "#set page(width: auto, height: auto, margin: 5pt)") {
+		t.Errorf("expected a standalone document to size its page, got:\n%s", out)
+	}
+	if strings.Contains(out, "Vote by user") {
+		t.Errorf("expected decorations to be omitted, got:\n%s", out)
+	}
+}
+
+// Scales fitting a size are kept exact, as rounding them would scale the
+// whole drawing.
+func TestScale(t *testing.T) {
+	// The board spans 620 units, from the code block to the diamond.
+	out := render(t, &Options{Width: 10})
+	if !strings.Contains(out, "#cetz.canvas(length: "+strconv.FormatFloat(10.0/620, 'f', -1, 64)+"cm, {") {
+		t.Errorf("expected the drawing to fit 10cm exactly, got:\n%s", out)
+	}
+}
+
+func TestStr(t *testing.T) {
+	for in, want := range map[string]string{
+		`say "hi"`:   `"say \"hi\""`,
+		`C:\path`:    `"C:\\path"`,
+		"two\nlines": `"two\nlines"`,
+		"a\u200db":   `"a\u{200d}b"`,
+	} {
+		if got := str(in); got != want {
+			t.Errorf("str(%q) = %s, expected %s", in, got, want)
+		}
+	}
+}